@@ -18,20 +18,29 @@ import (
 
 // AppServer 应用服务器结构体，封装所有服务和处理器
 type AppServer struct {
-	xiaohongshuService *XiaohongshuService
-	mcpServer          *mcp.Server
-	router             *gin.Engine
-	httpServer         *http.Server
-	actualAddr         string
-	serveErr           chan error
-	listener           net.Listener
-	waitOnce           sync.Once
+	platform   Platform
+	mcpServer  *mcp.Server
+	router     *gin.Engine
+	httpServer *http.Server
+	actualAddr string
+	serveErr   chan error
+	listener   net.Listener
+	waitOnce   sync.Once
+
+	// 分端口模式：HTTP API（健康检查等）单独监听，MCP 仅监听 httpServer
+	apiBindAddr   string
+	apiRouter     *gin.Engine
+	apiServer     *http.Server
+	apiListener   net.Listener
+	apiActualAddr string
+
+	config ServerConfig // 生效配置，get_config 返回其脱敏副本
 }
 
 // NewAppServer 创建新的应用服务器实例
-func NewAppServer(xiaohongshuService *XiaohongshuService) *AppServer {
+func NewAppServer(platform Platform) *AppServer {
 	appServer := &AppServer{
-		xiaohongshuService: xiaohongshuService,
+		platform: platform,
 	}
 
 	// 初始化 MCP Server（需要在创建 appServer 之后，因为工具注册需要访问 appServer）
@@ -40,38 +49,99 @@ func NewAppServer(xiaohongshuService *XiaohongshuService) *AppServer {
 	return appServer
 }
 
-// Start 启动服务器
+// SetAPIAddr 设置 HTTP API 的独立监听地址，需在 Start 之前调用。
+// 为空时（默认）MCP 与 HTTP API 共用 Start 的监听地址。
+func (s *AppServer) SetAPIAddr(addr string) {
+	s.apiBindAddr = addr
+}
+
+// SetConfig 记录启动时解析出的生效配置
+func (s *AppServer) SetConfig(config ServerConfig) {
+	s.config = config
+}
+
+// Start 启动服务器。未设置独立 API 地址时，MCP 与 HTTP API 共用 addr；
+// 否则 addr 只提供 MCP，HTTP API 监听 SetAPIAddr 设置的地址。
 func (s *AppServer) Start(addr string) (string, error) {
 	if s.httpServer != nil {
 		return "", errors.New("server already started")
 	}
 
-	s.router = setupRoutes(s)
+	if s.apiBindAddr == "" {
+		s.router = setupRoutes(s)
+	} else {
+		s.router = newRouter()
+		registerMCPRoutes(s.router, s)
+
+		s.apiRouter = newRouter()
+		registerAPIRoutes(s.apiRouter, s)
+	}
 
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return "", err
 	}
 
+	if s.apiBindAddr != "" {
+		apiListener, err := net.Listen("tcp", s.apiBindAddr)
+		if err != nil {
+			listener.Close()
+			return "", err
+		}
+		s.apiListener = apiListener
+		s.apiActualAddr = apiListener.Addr().String()
+	}
+
 	s.listener = listener
 	s.actualAddr = listener.Addr().String()
-	s.serveErr = make(chan error, 1)
+	s.serveErr = make(chan error, 2)
 
 	s.httpServer = &http.Server{
 		Handler: s.router,
 	}
+	s.serve("HTTP", s.httpServer, listener)
+
+	if s.apiListener != nil {
+		s.apiServer = &http.Server{
+			Handler: s.apiRouter,
+		}
+		s.serve("HTTP API", s.apiServer, s.apiListener)
+	}
 
+	return s.actualAddr, nil
+}
+
+// serve 在后台运行 server，结束时把结果写入 serveErr
+func (s *AppServer) serve(name string, server *http.Server, listener net.Listener) {
 	go func() {
-		logrus.Infof("启动 HTTP 服务器: %s", s.actualAddr)
-		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
-			logrus.Errorf("服务器运行错误: %v", err)
+		logrus.Infof("启动 %s 服务器: %s", name, listener.Addr().String())
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("%s 服务器运行错误: %v", name, err)
 			s.serveErr <- err
 			return
 		}
 		s.serveErr <- nil
 	}()
+}
 
-	return s.actualAddr, nil
+// servers 返回所有已启动的 http.Server
+func (s *AppServer) servers() []*http.Server {
+	servers := []*http.Server{s.httpServer}
+	if s.apiServer != nil {
+		servers = append(servers, s.apiServer)
+	}
+	return servers
+}
+
+// shutdownAll 关闭所有 http.Server，返回第一个错误
+func (s *AppServer) shutdownAll(ctx context.Context) error {
+	var firstErr error
+	for _, server := range s.servers() {
+		if err := server.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 // Wait 等待服务器停止（捕获系统信号或内部错误）
@@ -81,25 +151,40 @@ func (s *AppServer) Wait() error {
 	}
 
 	errCh := s.serveErr
+	running := len(s.servers())
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
 
 	select {
 	case sig := <-quit:
 		logrus.Infof("收到信号 %s，正在关闭服务器...", sig)
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		if err := s.httpServer.Shutdown(ctx); err != nil {
+		if err := s.shutdownAll(ctx); err != nil {
 			logrus.Warnf("等待连接关闭超时，强制退出: %v", err)
 		} else {
 			logrus.Infof("服务器已优雅关闭")
 		}
-		signal.Stop(quit)
-		return <-errCh
 	case err := <-errCh:
-		return err
+		running--
+		// 任一服务器退出时关闭其余服务器
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.shutdownAll(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var firstErr error
+	for ; running > 0; running-- {
+		if err := <-errCh; err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+	return firstErr
 }
 
 // Shutdown 主动关闭服务器，供桌面应用调用
@@ -109,7 +194,7 @@ func (s *AppServer) Shutdown(ctx context.Context) error {
 	}
 
 	s.waitOnce.Do(func() {
-		if err := s.httpServer.Shutdown(ctx); err != nil {
+		if err := s.shutdownAll(ctx); err != nil {
 			logrus.Warnf("主动关闭服务器失败: %v", err)
 		}
 	})
@@ -122,6 +207,14 @@ func (s *AppServer) Address() string {
 	return s.actualAddr
 }
 
+// APIAddress 返回 HTTP API 实际监听地址；单端口模式下与 Address 相同
+func (s *AppServer) APIAddress() string {
+	if s.apiActualAddr != "" {
+		return s.apiActualAddr
+	}
+	return s.actualAddr
+}
+
 // Port 返回服务器监听端口
 func (s *AppServer) Port() string {
 	if s.actualAddr == "" {
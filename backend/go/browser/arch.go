@@ -0,0 +1,189 @@
+package browser
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/sirupsen/logrus"
+)
+
+// 浏览器因架构不匹配无法执行时常见的系统报错
+var archLaunchErrorMarkers = []string{
+	"exec format error",
+	"bad cpu type",
+	"cannot execute binary file",
+	"%1 is not a valid win32 application",
+	"not a valid win32 application",
+}
+
+// loggedBinaryArches 已记录过架构信息的浏览器路径
+var loggedBinaryArches sync.Map
+
+// ArchMismatchError 浏览器二进制与当前系统架构不兼容
+type ArchMismatchError struct {
+	BinPath    string
+	BinaryArch string // 无法识别时为空
+	HostOS     string
+	HostArch   string
+}
+
+func (e *ArchMismatchError) Error() string {
+	binary := e.BinaryArch
+	if binary == "" {
+		binary = "未知"
+	}
+	return fmt.Sprintf("浏览器 %s 的架构（%s）与当前系统 %s/%s 不兼容。"+
+		"请下载适用于 %s/%s 的 Chrome/Chromium 并通过 -bin 或 ROD_BROWSER_BIN 指定；"+
+		"不指定时会自动下载匹配当前系统的 Chromium",
+		e.BinPath, binary, e.HostOS, e.HostArch, e.HostOS, e.HostArch)
+}
+
+// CheckBinaryArch 检查浏览器二进制的 CPU 架构是否能在当前系统运行。
+// binPath 为空时检查 rod 会自动使用的本机浏览器；无法识别格式（如启动脚本）时不报错。
+func CheckBinaryArch(binPath string) error {
+	if binPath == "" {
+		path, found := launcher.LookPath()
+		if !found {
+			return nil
+		}
+		binPath = path
+	}
+
+	arches, err := binaryArches(binPath)
+	if err != nil {
+		logrus.Debugf("无法识别浏览器 %s 的架构: %v", binPath, err)
+		return nil
+	}
+	// 每个浏览器路径只记录一次，避免每次启动浏览器都打印
+	if _, logged := loggedBinaryArches.LoadOrStore(binPath, true); !logged {
+		logrus.Infof("系统 %s/%s，浏览器 %s 架构: %s", runtime.GOOS, runtime.GOARCH, binPath, strings.Join(arches, ","))
+	}
+
+	for _, arch := range arches {
+		if archCompatible(runtime.GOOS, runtime.GOARCH, arch) {
+			return nil
+		}
+	}
+
+	return &ArchMismatchError{
+		BinPath:    binPath,
+		BinaryArch: strings.Join(arches, ","),
+		HostOS:     runtime.GOOS,
+		HostArch:   runtime.GOARCH,
+	}
+}
+
+// explainLaunchError 将启动失败中与架构相关的系统报错转换为 ArchMismatchError，其余原样返回
+func explainLaunchError(binPath string, err error) error {
+	if err == nil || !isArchLaunchError(err.Error()) {
+		return err
+	}
+
+	mismatch := &ArchMismatchError{BinPath: binPath, HostOS: runtime.GOOS, HostArch: runtime.GOARCH}
+	if arches, archErr := binaryArches(binPath); archErr == nil {
+		mismatch.BinaryArch = strings.Join(arches, ",")
+	}
+	logrus.Errorf("浏览器启动失败: %v", err)
+	return mismatch
+}
+
+// isArchLaunchError 判断启动报错是否由可执行文件架构不匹配引起
+func isArchLaunchError(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, marker := range archLaunchErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// archCompatible 判断 binArch 的程序能否在 goos/goarch 上运行，
+// 包括 macOS Rosetta 与 Windows on ARM 的 x64 转译
+func archCompatible(goos, goarch, binArch string) bool {
+	if goarch == binArch {
+		return true
+	}
+	switch {
+	case goos == "darwin" && goarch == "arm64" && binArch == "amd64":
+		return true
+	case goos == "windows" && goarch == "arm64" && (binArch == "amd64" || binArch == "386"):
+		return true
+	case goos == "windows" && goarch == "amd64" && binArch == "386":
+		return true
+	}
+	return false
+}
+
+// binaryArches 读取可执行文件（ELF/Mach-O/PE）的 CPU 架构，macOS 通用二进制返回多个
+func binaryArches(path string) ([]string, error) {
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		return []string{elfArch(f.Machine)}, nil
+	}
+
+	if f, err := macho.OpenFat(path); err == nil {
+		defer f.Close()
+		arches := make([]string, 0, len(f.Arches))
+		for _, a := range f.Arches {
+			arches = append(arches, machoArch(a.Cpu))
+		}
+		return arches, nil
+	}
+
+	if f, err := macho.Open(path); err == nil {
+		defer f.Close()
+		return []string{machoArch(f.Cpu)}, nil
+	}
+
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		return []string{peArch(f.Machine)}, nil
+	}
+
+	return nil, fmt.Errorf("不是可识别的可执行文件格式")
+}
+
+func elfArch(m elf.Machine) string {
+	switch m {
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_386:
+		return "386"
+	case elf.EM_ARM:
+		return "arm"
+	}
+	return strings.ToLower(strings.TrimPrefix(m.String(), "EM_"))
+}
+
+func machoArch(c macho.Cpu) string {
+	switch c {
+	case macho.CpuAmd64:
+		return "amd64"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.Cpu386:
+		return "386"
+	}
+	return strings.ToLower(strings.TrimPrefix(c.String(), "Cpu"))
+}
+
+func peArch(m uint16) string {
+	switch m {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386"
+	}
+	return fmt.Sprintf("0x%x", m)
+}
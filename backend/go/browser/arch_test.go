@@ -0,0 +1,46 @@
+package browser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryArches(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	arches, err := binaryArches(exe)
+	require.NoError(t, err)
+	require.Equal(t, []string{runtime.GOARCH}, arches)
+	require.NoError(t, CheckBinaryArch(exe))
+
+	script := filepath.Join(t.TempDir(), "chrome.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nexec chromium \"$@\"\n"), 0o755))
+	_, err = binaryArches(script)
+	require.Error(t, err)
+	require.NoError(t, CheckBinaryArch(script))
+}
+
+func TestArchCompatible(t *testing.T) {
+	require.True(t, archCompatible("linux", "arm64", "arm64"))
+	require.False(t, archCompatible("linux", "arm64", "amd64"))
+	require.True(t, archCompatible("darwin", "arm64", "amd64"))
+	require.False(t, archCompatible("darwin", "amd64", "arm64"))
+	require.True(t, archCompatible("windows", "arm64", "amd64"))
+}
+
+func TestExplainLaunchError(t *testing.T) {
+	err := explainLaunchError("/opt/chrome", &os.PathError{Op: "fork/exec", Path: "/opt/chrome", Err: os.ErrInvalid})
+	require.NotErrorAs(t, err, new(*ArchMismatchError))
+
+	launchErr := explainLaunchError("/opt/chrome", errors.New("fork/exec /opt/chrome: exec format error"))
+	var mismatch *ArchMismatchError
+	require.ErrorAs(t, launchErr, &mismatch)
+	require.Equal(t, runtime.GOARCH, mismatch.HostArch)
+	require.Contains(t, mismatch.Error(), "-bin")
+}
@@ -0,0 +1,53 @@
+package browser
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultBin 未指定 -bin 时使用的浏览器，整个进程共享
+var defaultBin = &binResolver{resolve: lookupOrDownloadBin}
+
+// binResolver 首次使用时解析浏览器可执行文件路径。本机没有浏览器时 rod 会下载 Chromium，
+// 并发的首次请求若各自解析会同时下载到同一目录并启动多份；这里让它们等待并共享同一次解析。
+// 解析失败不缓存，下一次请求会重新尝试。
+type binResolver struct {
+	mu      sync.Mutex
+	path    string
+	resolve func() (string, error)
+}
+
+// get 返回已解析的路径，尚未解析时由第一个调用者解析，其余调用者等待其结果
+func (r *binResolver) get() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.path != "" {
+		return r.path, nil
+	}
+
+	path, err := r.resolve()
+	if err != nil {
+		return "", err
+	}
+	r.path = path
+	return path, nil
+}
+
+// lookupOrDownloadBin 优先使用本机安装的浏览器，没有时下载 rod 默认版本的 Chromium
+func lookupOrDownloadBin() (string, error) {
+	if path, found := launcher.LookPath(); found {
+		return path, nil
+	}
+
+	logrus.Info("本机没有找到 Chrome/Chromium，开始下载 Chromium")
+	path, err := launcher.NewBrowser().Get()
+	if err != nil {
+		return "", fmt.Errorf("下载 Chromium 失败（可通过 -bin 指定本机浏览器）: %w", err)
+	}
+	logrus.Infof("Chromium 已下载到 %s", path)
+	return path, nil
+}
@@ -0,0 +1,65 @@
+package browser
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinResolverConcurrentFirstUse(t *testing.T) {
+	var calls int32
+	r := &binResolver{resolve: func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		// 模拟下载耗时，让其余请求在解析完成前到达
+		time.Sleep(20 * time.Millisecond)
+		return "/cache/chromium", nil
+	}}
+
+	const requests = 16
+	var wg sync.WaitGroup
+	paths := make([]string, requests)
+	start := make(chan struct{})
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			path, err := r.get()
+			require.NoError(t, err)
+			paths[i] = path
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	for _, path := range paths {
+		require.Equal(t, "/cache/chromium", path)
+	}
+}
+
+func TestBinResolverRetriesAfterFailure(t *testing.T) {
+	calls := 0
+	r := &binResolver{resolve: func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("download interrupted")
+		}
+		return "/cache/chromium", nil
+	}}
+
+	_, err := r.get()
+	require.Error(t, err)
+
+	path, err := r.get()
+	require.NoError(t, err)
+	require.Equal(t, "/cache/chromium", path)
+
+	_, err = r.get()
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
@@ -1,6 +1,8 @@
 package browser
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/headless_browser"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
@@ -8,6 +10,9 @@ import (
 
 type browserConfig struct {
 	binPath string
+
+	launchAttempts int
+	launchBackoff  time.Duration
 }
 
 type Option func(*browserConfig)
@@ -18,12 +23,42 @@ func WithBinPath(binPath string) Option {
 	}
 }
 
+// NewBrowser 启动加载 cookies 的浏览器，启动失败时按 WithLaunchRetry 的设置重试，仍然失败时 panic。
+// 浏览器与系统架构不兼容时 panic 的值为 *ArchMismatchError，提示下载正确的版本，且不会重试。
 func NewBrowser(headless bool, options ...Option) *headless_browser.Browser {
 	cfg := &browserConfig{}
 	for _, opt := range options {
 		opt(cfg)
 	}
 
+	if cfg.binPath == "" {
+		path, err := defaultBin.get()
+		if err != nil {
+			panic(err)
+		}
+		cfg.binPath = path
+	}
+
+	if err := CheckBinaryArch(cfg.binPath); err != nil {
+		panic(err)
+	}
+
+	return launchWithRetry(cfg.launchAttempts, cfg.launchBackoff, func() *headless_browser.Browser {
+		return launchBrowser(headless, cfg)
+	})
+}
+
+// launchBrowser 启动一次浏览器，失败时 panic
+func launchBrowser(headless bool, cfg *browserConfig) *headless_browser.Browser {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); ok {
+				panic(explainLaunchError(cfg.binPath, err))
+			}
+			panic(r)
+		}
+	}()
+
 	opts := []headless_browser.Option{
 		headless_browser.WithHeadless(headless),
 	}
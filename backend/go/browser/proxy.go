@@ -0,0 +1,96 @@
+package browser
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/stealth"
+	"github.com/pkg/errors"
+)
+
+// ProxyBrowser 通过代理启动的一次性浏览器，不加载 cookies，关闭后删除临时用户目录
+type ProxyBrowser struct {
+	browser  *rod.Browser
+	launcher *launcher.Launcher
+}
+
+// ParseProxyURL 校验代理地址，支持 http/https/socks5，返回解析后的 URL
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(proxyURL))
+	if err != nil {
+		return nil, errors.Wrap(err, "代理地址格式错误")
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("不支持的代理协议 %q，可选: http|https|socks5", u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("代理地址缺少主机或端口: %s", proxyURL)
+	}
+	if u.Scheme == "socks5" && u.User != nil {
+		return nil, errors.New("Chrome 不支持带认证的 socks5 代理")
+	}
+
+	return u, nil
+}
+
+// NewProxyBrowser 通过代理启动一个独立的浏览器，启动失败返回错误而不是 panic
+func NewProxyBrowser(headless bool, proxy *url.URL, options ...Option) (*ProxyBrowser, error) {
+	cfg := &browserConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	if err := CheckBinaryArch(cfg.binPath); err != nil {
+		return nil, err
+	}
+
+	l := launcher.New().
+		Headless(headless).
+		Set("--no-sandbox").
+		Proxy(fmt.Sprintf("%s://%s", proxy.Scheme, proxy.Host))
+	if cfg.binPath != "" {
+		l = l.Bin(cfg.binPath)
+	}
+
+	controlURL, err := l.Launch()
+	if err != nil {
+		if mismatch := explainLaunchError(cfg.binPath, err); mismatch != err {
+			return nil, mismatch
+		}
+		return nil, errors.Wrap(err, "启动浏览器失败")
+	}
+
+	b := rod.New().ControlURL(controlURL)
+	if err := b.Connect(); err != nil {
+		l.Kill()
+		l.Cleanup()
+		return nil, errors.Wrap(err, "连接浏览器失败")
+	}
+
+	// 代理需要认证时自动应答首次认证请求，Chrome 会在本次会话内复用
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		wait := b.HandleAuth(proxy.User.Username(), password)
+		go func() { _ = wait() }()
+	}
+
+	return &ProxyBrowser{browser: b, launcher: l}, nil
+}
+
+// NewPage 创建启用 stealth 的新页面
+func (b *ProxyBrowser) NewPage() (*rod.Page, error) {
+	return stealth.Page(b.browser)
+}
+
+// Close 关闭浏览器并删除临时用户目录
+func (b *ProxyBrowser) Close() {
+	_ = b.browser.Close()
+	b.launcher.Kill()
+	b.launcher.Cleanup()
+}
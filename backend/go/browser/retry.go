@@ -0,0 +1,78 @@
+package browser
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultLaunchAttempts 浏览器启动的默认尝试次数
+	DefaultLaunchAttempts = 3
+	// DefaultLaunchBackoff 第一次重试前的等待时间，之后每次翻倍
+	DefaultLaunchBackoff = 1 * time.Second
+)
+
+// WithLaunchRetry 设置浏览器启动失败时的尝试次数与初始退避时间，attempts 不大于 0 时使用默认值
+func WithLaunchRetry(attempts int, backoff time.Duration) Option {
+	return func(c *browserConfig) {
+		c.launchAttempts = attempts
+		c.launchBackoff = backoff
+	}
+}
+
+// launchWithRetry 调用 launch 直到成功或用完 attempts 次，launch 通过 panic 报告失败。
+// 架构不兼容这类不会自行恢复的错误不重试；每次重试前按指数退避并加入随机抖动，
+// 避免多个实例同时重试。全部失败时以最后一次的错误 panic。
+func launchWithRetry[T any](attempts int, backoff time.Duration, launch func() T) T {
+	if attempts <= 0 {
+		attempts = DefaultLaunchAttempts
+	}
+	if backoff <= 0 {
+		backoff = DefaultLaunchBackoff
+	}
+
+	var lastErr any
+	for i := 1; i <= attempts; i++ {
+		result, err := tryLaunch(launch)
+		if err == nil {
+			if i > 1 {
+				logrus.Infof("浏览器第 %d 次启动成功", i)
+			}
+			return result
+		}
+		lastErr = err
+
+		if _, permanent := err.(*ArchMismatchError); permanent {
+			break
+		}
+		if i == attempts {
+			break
+		}
+
+		wait := jitter(backoff << (i - 1))
+		logrus.Warnf("浏览器启动失败（第 %d/%d 次），%s 后重试: %v", i, attempts, wait.Round(time.Millisecond), err)
+		time.Sleep(wait)
+	}
+
+	panic(lastErr)
+}
+
+// tryLaunch 执行一次启动，把 panic 转换为返回值
+func tryLaunch[T any](launch func() T) (result T, err any) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r
+		}
+	}()
+	return launch(), nil
+}
+
+// jitter 返回 [d/2, 3d/2) 之间的随机时长
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
+}
@@ -0,0 +1,55 @@
+package browser
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchWithRetry(t *testing.T) {
+	calls := 0
+	result := launchWithRetry(3, time.Millisecond, func() string {
+		calls++
+		if calls < 3 {
+			panic(errors.New("websocket: bad handshake"))
+		}
+		return "ok"
+	})
+	require.Equal(t, "ok", result)
+	require.Equal(t, 3, calls)
+}
+
+func TestLaunchWithRetryGivesUp(t *testing.T) {
+	calls := 0
+	launchErr := errors.New("failed to launch")
+	require.PanicsWithValue(t, launchErr, func() {
+		launchWithRetry(2, time.Millisecond, func() int {
+			calls++
+			panic(launchErr)
+		})
+	})
+	require.Equal(t, 2, calls)
+}
+
+func TestLaunchWithRetrySkipsArchMismatch(t *testing.T) {
+	calls := 0
+	mismatch := &ArchMismatchError{BinPath: "/bin/chrome", HostOS: "linux", HostArch: "arm64"}
+	require.Panics(t, func() {
+		launchWithRetry(5, time.Millisecond, func() int {
+			calls++
+			panic(mismatch)
+		})
+	})
+	require.Equal(t, 1, calls)
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := jitter(time.Second)
+		require.GreaterOrEqual(t, d, 500*time.Millisecond)
+		require.Less(t, d, 1500*time.Millisecond)
+	}
+	require.Zero(t, jitter(0))
+}
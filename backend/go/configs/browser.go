@@ -1,20 +1,70 @@
 package configs
 
+import (
+	"sync"
+	"time"
+)
+
 var (
+	headlessMu  sync.RWMutex
 	useHeadless = true
 
+	// 无头浏览器被拦截时是否自动切换到有界面模式
+	headfulFallback bool
+	fallbackAt      time.Time
+	fallbackReason  string
+
 	binPath = ""
 )
 
 func InitHeadless(h bool) {
+	headlessMu.Lock()
+	defer headlessMu.Unlock()
 	useHeadless = h
 }
 
-// IsHeadless 是否无头模式。
+// IsHeadless 是否无头模式。开启 -headful-fallback 后运行中可能切换为有界面模式。
 func IsHeadless() bool {
+	headlessMu.RLock()
+	defer headlessMu.RUnlock()
 	return useHeadless
 }
 
+// SetHeadfulFallback 设置无头浏览器被拦截时是否自动切换到有界面模式
+func SetHeadfulFallback(enabled bool) {
+	headlessMu.Lock()
+	defer headlessMu.Unlock()
+	headfulFallback = enabled
+}
+
+// IsHeadfulFallback 是否开启了自动切换到有界面模式
+func IsHeadfulFallback() bool {
+	headlessMu.RLock()
+	defer headlessMu.RUnlock()
+	return headfulFallback
+}
+
+// FallbackToHeadful 开启自动切换且当前为无头模式时切换到有界面模式，之后启动的浏览器都有界面。
+// 只有实际发生切换的调用返回 true。
+func FallbackToHeadful(reason string) bool {
+	headlessMu.Lock()
+	defer headlessMu.Unlock()
+	if !headfulFallback || !useHeadless {
+		return false
+	}
+	useHeadless = false
+	fallbackAt = time.Now()
+	fallbackReason = reason
+	return true
+}
+
+// GetHeadfulFallback 返回切换到有界面模式的时间与原因，没有切换过时时间为零值
+func GetHeadfulFallback() (time.Time, string) {
+	headlessMu.RLock()
+	defer headlessMu.RUnlock()
+	return fallbackAt, fallbackReason
+}
+
 func SetBinPath(b string) {
 	binPath = b
 }
@@ -22,3 +72,28 @@ func SetBinPath(b string) {
 func GetBinPath() string {
 	return binPath
 }
+
+var (
+	launchAttempts = 3
+	launchBackoff  = 1 * time.Second
+)
+
+// SetBrowserLaunchRetry 设置浏览器启动失败时的尝试次数与初始退避时间
+func SetBrowserLaunchRetry(attempts int, backoff time.Duration) {
+	if attempts > 0 {
+		launchAttempts = attempts
+	}
+	if backoff > 0 {
+		launchBackoff = backoff
+	}
+}
+
+// GetBrowserLaunchAttempts 浏览器启动的尝试次数
+func GetBrowserLaunchAttempts() int {
+	return launchAttempts
+}
+
+// GetBrowserLaunchBackoff 浏览器启动第一次重试前的等待时间，之后每次翻倍
+func GetBrowserLaunchBackoff() time.Duration {
+	return launchBackoff
+}
@@ -0,0 +1,62 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	MediaDir = "media"
+
+	// ConvertedImagesDir 格式转换后的图片目录
+	ConvertedImagesDir = "converted"
+
+	// TemplatesDir 发布模板目录
+	TemplatesDir = "templates"
+
+	// MediaTokenTTL 预上传素材令牌的有效期
+	MediaTokenTTL = 24 * time.Hour
+
+	// ConvertedImagesTTL 格式转换结果的保留时间，超过后在下次转换时清理
+	ConvertedImagesTTL = 24 * time.Hour
+
+	// ArchiveDir 笔记长截图等归档文件目录
+	ArchiveDir = "archive"
+
+	// EventDeadLetterFile 事件回调多次投递失败后的死信文件
+	EventDeadLetterFile = "event_dead_letters.jsonl"
+)
+
+// GetDataDir 获取数据目录，优先使用环境变量 DATA_DIR，默认为当前目录下的 data
+func GetDataDir() string {
+	if dir := os.Getenv("DATA_DIR"); dir != "" {
+		return dir
+	}
+	return "data"
+}
+
+// GetMediaPath 预上传素材的缓存目录
+func GetMediaPath() string {
+	return filepath.Join(GetDataDir(), MediaDir)
+}
+
+// GetConvertedImagesPath 格式转换（HEIC/WebP 等转 JPEG）输出目录
+func GetConvertedImagesPath() string {
+	return filepath.Join(GetDataDir(), ConvertedImagesDir)
+}
+
+// GetTemplatesPath 发布模板的保存目录
+func GetTemplatesPath() string {
+	return filepath.Join(GetDataDir(), TemplatesDir)
+}
+
+// GetArchivePath 归档文件的保存目录
+func GetArchivePath() string {
+	return filepath.Join(GetDataDir(), ArchiveDir)
+}
+
+// GetEventDeadLetterPath 事件回调死信文件路径
+func GetEventDeadLetterPath() string {
+	return filepath.Join(GetDataDir(), EventDeadLetterFile)
+}
@@ -0,0 +1,13 @@
+package configs
+
+var autoDismissGates = true
+
+// SetAutoDismissGates 设置是否自动确认地区提示等无害的拦截弹窗
+func SetAutoDismissGates(enabled bool) {
+	autoDismissGates = enabled
+}
+
+// AutoDismissGates 是否自动确认无害的拦截弹窗，默认开启
+func AutoDismissGates() bool {
+	return autoDismissGates
+}
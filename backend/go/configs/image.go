@@ -1,6 +1,7 @@
 package configs
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 )
@@ -12,3 +13,72 @@ const (
 func GetImagesPath() string {
 	return filepath.Join(os.TempDir(), ImagesDir)
 }
+
+// 发布图片列表中出现内容相同的图片时的处理方式
+const (
+	DuplicateImagesDedupe = "dedupe" // 去掉重复的图片并记录警告（默认）
+	DuplicateImagesError  = "error"  // 拒绝发布
+)
+
+var duplicateImages = DuplicateImagesDedupe
+
+// ValidateDuplicateImages 校验重复图片的处理方式
+func ValidateDuplicateImages(mode string) error {
+	if mode != DuplicateImagesDedupe && mode != DuplicateImagesError {
+		return fmt.Errorf("无效的重复图片处理方式 %q，可选: %s|%s", mode, DuplicateImagesDedupe, DuplicateImagesError)
+	}
+	return nil
+}
+
+// SetDuplicateImages 设置重复图片的处理方式，无效值被忽略
+func SetDuplicateImages(mode string) {
+	if ValidateDuplicateImages(mode) == nil {
+		duplicateImages = mode
+	}
+}
+
+// GetDuplicateImages 重复图片的处理方式
+func GetDuplicateImages() string {
+	return duplicateImages
+}
+
+// 下载图片的默认并发数与瞬时错误的重试次数
+const (
+	DefaultDownloadConcurrency = 3
+	MaxDownloadConcurrency     = 16
+	DefaultDownloadRetries     = 2
+)
+
+var (
+	downloadConcurrency = DefaultDownloadConcurrency
+	downloadRetries     = DefaultDownloadRetries
+)
+
+// ValidateDownloadOptions 校验下载并发数与重试次数
+func ValidateDownloadOptions(concurrency, retries int) error {
+	if concurrency < 1 || concurrency > MaxDownloadConcurrency {
+		return fmt.Errorf("无效的下载并发数 %d，可选范围 1-%d", concurrency, MaxDownloadConcurrency)
+	}
+	if retries < 0 {
+		return fmt.Errorf("无效的下载重试次数 %d", retries)
+	}
+	return nil
+}
+
+// SetDownloadOptions 设置下载并发数与重试次数，无效值被忽略
+func SetDownloadOptions(concurrency, retries int) {
+	if ValidateDownloadOptions(concurrency, retries) == nil {
+		downloadConcurrency = concurrency
+		downloadRetries = retries
+	}
+}
+
+// GetDownloadConcurrency 同时下载的文件数
+func GetDownloadConcurrency() int {
+	return downloadConcurrency
+}
+
+// GetDownloadRetries 下载遇到瞬时错误（网络错误、408/429/5xx）时的重试次数
+func GetDownloadRetries() int {
+	return downloadRetries
+}
@@ -0,0 +1,70 @@
+package configs
+
+import "time"
+
+const (
+	// DefaultMaxItems 自动翻页未指定 max_items 时的默认上限
+	DefaultMaxItems = 100
+	// HardMaxItems 自动翻页的硬上限，防止一次调用无限滚动
+	HardMaxItems = 500
+
+	// DefaultExportItems 流式导出未指定 max_items 时的默认上限
+	DefaultExportItems = 1000
+	// HardMaxExportItems 流式导出的硬上限
+	HardMaxExportItems = 5000
+
+	// DefaultAggregateNotes 账号汇总统计未指定 max_notes 时统计的笔记数
+	DefaultAggregateNotes = 100
+	// HardMaxAggregateNotes 账号汇总统计的上限，每篇笔记都要打开一次详情页
+	HardMaxAggregateNotes = 500
+)
+
+// AccountStatsTTL 账号汇总统计结果的缓存时间
+const AccountStatsTTL = 30 * time.Minute
+
+var pageInterval = 2 * time.Second
+
+// SetPageInterval 设置自动翻页时两次加载之间的间隔
+func SetPageInterval(d time.Duration) {
+	if d > 0 {
+		pageInterval = d
+	}
+}
+
+// GetPageInterval 自动翻页时两次加载之间的间隔
+func GetPageInterval() time.Duration {
+	return pageInterval
+}
+
+// ClampMaxItems 将请求的 max_items 规范到 (0, HardMaxItems] 区间
+func ClampMaxItems(n int) int {
+	if n <= 0 {
+		return DefaultMaxItems
+	}
+	if n > HardMaxItems {
+		return HardMaxItems
+	}
+	return n
+}
+
+// ClampAggregateNotes 将账号汇总统计的 max_notes 规范到 (0, HardMaxAggregateNotes] 区间
+func ClampAggregateNotes(n int) int {
+	if n <= 0 {
+		return DefaultAggregateNotes
+	}
+	if n > HardMaxAggregateNotes {
+		return HardMaxAggregateNotes
+	}
+	return n
+}
+
+// ClampExportItems 将流式导出的 max_items 规范到 (0, HardMaxExportItems] 区间
+func ClampExportItems(n int) int {
+	if n <= 0 {
+		return DefaultExportItems
+	}
+	if n > HardMaxExportItems {
+		return HardMaxExportItems
+	}
+	return n
+}
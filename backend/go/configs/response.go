@@ -0,0 +1,51 @@
+package configs
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResultBufferTTL 截断结果剩余部分的保留时间，过期后需重新调用原工具
+const ResultBufferTTL = 10 * time.Minute
+
+var maxResponseBytes = 0
+
+// SetMaxResponseBytes 设置 MCP 工具结果的最大字节数，0 表示不截断
+func SetMaxResponseBytes(n int) {
+	if n >= 0 {
+		maxResponseBytes = n
+	}
+}
+
+// GetMaxResponseBytes MCP 工具结果的最大字节数，0 表示不截断
+func GetMaxResponseBytes() int {
+	return maxResponseBytes
+}
+
+// 响应格式
+const (
+	EnvelopeRaw     = "raw"     // 直接返回数据（默认，兼容已有客户端）
+	EnvelopeWrapped = "wrapped" // 统一包装为 {data, meta, error}
+)
+
+var responseEnvelope = EnvelopeRaw
+
+// ValidateEnvelope 校验响应格式
+func ValidateEnvelope(mode string) error {
+	if mode != EnvelopeRaw && mode != EnvelopeWrapped {
+		return fmt.Errorf("无效的响应格式 %q，可选: %s|%s", mode, EnvelopeRaw, EnvelopeWrapped)
+	}
+	return nil
+}
+
+// SetResponseEnvelope 设置默认的响应格式，无效值被忽略
+func SetResponseEnvelope(mode string) {
+	if ValidateEnvelope(mode) == nil {
+		responseEnvelope = mode
+	}
+}
+
+// GetResponseEnvelope 默认的响应格式
+func GetResponseEnvelope() string {
+	return responseEnvelope
+}
@@ -0,0 +1,19 @@
+package configs
+
+// UIVariantAuto 根据页面标记自动识别界面版本
+const UIVariantAuto = "auto"
+
+var uiVariant = UIVariantAuto
+
+// SetUIVariant 设置界面版本；auto 表示自动识别，其他值强制使用对应的选择器集合
+func SetUIVariant(name string) {
+	if name == "" {
+		name = UIVariantAuto
+	}
+	uiVariant = name
+}
+
+// GetUIVariant 获取配置的界面版本
+func GetUIVariant() string {
+	return uiVariant
+}
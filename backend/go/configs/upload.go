@@ -0,0 +1,49 @@
+package configs
+
+import (
+	"fmt"
+	"time"
+)
+
+// 发布时上传素材失败后的默认重试次数
+const (
+	DefaultUploadRetries = 1
+	MaxUploadRetries     = 10
+)
+
+var (
+	uploadTimeout time.Duration // 为 0 时按素材类型使用默认等待时间
+	uploadRetries = DefaultUploadRetries
+)
+
+// ValidateUploadOptions 校验上传超时与重试次数，timeout 为 0 表示使用默认值
+func ValidateUploadOptions(timeout time.Duration, retries int) error {
+	if timeout < 0 {
+		return fmt.Errorf("无效的上传超时 %s", timeout)
+	}
+	if retries < 0 || retries > MaxUploadRetries {
+		return fmt.Errorf("无效的上传重试次数 %d，可选范围 0-%d", retries, MaxUploadRetries)
+	}
+	return nil
+}
+
+// SetUploadOptions 设置上传超时与重试次数，无效值被忽略
+func SetUploadOptions(timeout time.Duration, retries int) {
+	if ValidateUploadOptions(timeout, retries) == nil {
+		uploadTimeout = timeout
+		uploadRetries = retries
+	}
+}
+
+// GetUploadTimeout 每次上传尝试等待素材上传完成的时间，未设置时返回 fallback
+func GetUploadTimeout(fallback time.Duration) time.Duration {
+	if uploadTimeout > 0 {
+		return uploadTimeout
+	}
+	return fallback
+}
+
+// GetUploadRetries 上传失败或超时后重新上传失败文件的次数
+func GetUploadRetries() int {
+	return uploadRetries
+}
@@ -0,0 +1,36 @@
+package configs
+
+import "time"
+
+var (
+	prePublishWebhook  string
+	prePublishTimeout  = 10 * time.Second
+	prePublishFailOpen bool
+)
+
+// EventShutdownTimeout 关闭服务时等待事件回调投递完成的最长时间，超时未完成的事件写入死信文件
+const EventShutdownTimeout = 30 * time.Second
+
+// SetPrePublishWebhook 设置发布前审批回调地址、超时以及回调不可用时是否放行，url 为空表示不审批
+func SetPrePublishWebhook(url string, timeout time.Duration, failOpen bool) {
+	prePublishWebhook = url
+	if timeout > 0 {
+		prePublishTimeout = timeout
+	}
+	prePublishFailOpen = failOpen
+}
+
+// PrePublishWebhook 发布前审批回调地址，为空表示不审批
+func PrePublishWebhook() string {
+	return prePublishWebhook
+}
+
+// PrePublishTimeout 发布前审批回调的超时
+func PrePublishTimeout() time.Duration {
+	return prePublishTimeout
+}
+
+// PrePublishFailOpen 审批回调不可用时是否放行，默认拒绝
+func PrePublishFailOpen() bool {
+	return prePublishFailOpen
+}
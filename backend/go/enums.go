@@ -0,0 +1,31 @@
+package main
+
+import "github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+
+// enumErrorCode 错误码枚举名称
+const enumErrorCode = "error_code"
+
+// 客户端需要区分处理的错误码；其余 HTTP 错误码为 <操作>_FAILED，表示该操作执行失败
+var sharedErrorCodes = []xiaohongshu.EnumValue{
+	{ID: "INVALID_REQUEST", Label: "请求参数错误"},
+	{ID: "SESSION_EXPIRED", Label: "登录已失效"},
+	{ID: "NOT_OWNER", Label: "不是当前账号的内容"},
+	{ID: "PUBLISH_REJECTED", Label: "发布未通过审批"},
+	{ID: "POLL_UNSUPPORTED", Label: "不支持添加投票"},
+	{ID: "DUPLICATE_IMAGES", Label: "图片列表中有重复的图片"},
+	{ID: "NICKNAME_COOLDOWN", Label: "昵称处于修改冷却期"},
+	{ID: "INTERNAL_ERROR", Label: "服务内部错误"},
+	{ID: toolErrorCode, Label: "MCP 工具执行失败（包装格式）"},
+	{ID: OutputShapeErrorCode, Label: "工具输出与声明的 Schema 不符"},
+}
+
+// EnumsResponse 输出中所有枚举字段的取值：id 为稳定的英文标识，label 为中文名称
+type EnumsResponse struct {
+	Enums map[string][]xiaohongshu.EnumValue `json:"enums"`
+}
+
+func enumsResponse() *EnumsResponse {
+	enums := xiaohongshu.EnumSets()
+	enums[enumErrorCode] = append([]xiaohongshu.EnumValue(nil), sharedErrorCodes...)
+	return &EnumsResponse{Enums: enums}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+const (
+	// envelopeHeader HTTP 请求可通过该请求头单独指定响应格式，覆盖 -response-envelope
+	envelopeHeader = "X-Response-Envelope"
+	// envelopeMetaKey MCP 调用可在 _meta 中单独指定响应格式
+	envelopeMetaKey = "envelope"
+	// requestStartedKey gin.Context 中记录请求开始时间的键
+	requestStartedKey = "request_started"
+	// toolErrorCode 包装格式下 MCP 工具错误的默认错误码
+	toolErrorCode = "tool_error"
+)
+
+// 从数据顶层提取到 meta.pagination 的字段
+var paginationKeys = []string{"count", "cursor", "has_more", "hasMore", "complete", "scrolled_items", "returned_items", "end_reached"}
+
+// Envelope 包装格式的统一响应：成功时 error 为 null，失败时 data 为 null
+type Envelope struct {
+	Data  any            `json:"data"`
+	Meta  EnvelopeMeta   `json:"meta"`
+	Error *EnvelopeError `json:"error"`
+}
+
+// EnvelopeMeta 响应元数据
+type EnvelopeMeta struct {
+	Tool       string         `json:"tool,omitempty"` // MCP 工具名
+	Path       string         `json:"path,omitempty"` // HTTP 请求路径
+	Message    string         `json:"message,omitempty"`
+	DurationMs int64          `json:"duration_ms"`
+	Pagination map[string]any `json:"pagination,omitempty"`
+}
+
+// EnvelopeError 错误信息
+type EnvelopeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// httpEnvelope 本次 HTTP 请求是否使用包装格式，无效的请求头被忽略
+func httpEnvelope(c *gin.Context) bool {
+	mode := configs.GetResponseEnvelope()
+	if v := strings.ToLower(strings.TrimSpace(c.GetHeader(envelopeHeader))); configs.ValidateEnvelope(v) == nil {
+		mode = v
+	}
+	return mode == configs.EnvelopeWrapped
+}
+
+// toolEnvelope 本次 MCP 调用是否使用包装格式
+func toolEnvelope(req *mcp.CallToolRequest) bool {
+	mode := configs.GetResponseEnvelope()
+	if req != nil && req.Params != nil {
+		if v, ok := req.Params.GetMeta()[envelopeMetaKey].(string); ok && configs.ValidateEnvelope(v) == nil {
+			mode = v
+		}
+	}
+	return mode == configs.EnvelopeWrapped
+}
+
+// requestDuration 请求开始至今的毫秒数，没有经过 requestTimingMiddleware 时为 0
+func requestDuration(c *gin.Context) int64 {
+	started := c.GetTime(requestStartedKey)
+	if started.IsZero() {
+		return 0
+	}
+	return time.Since(started).Milliseconds()
+}
+
+// paginationMeta 提取数据顶层的分页字段（数量、游标、是否还有更多），没有时返回 nil
+func paginationMeta(data any) map[string]any {
+	if data == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if json.Unmarshal(raw, &fields) != nil {
+		return nil
+	}
+
+	var pagination map[string]any
+	for _, key := range paginationKeys {
+		if v, ok := fields[key]; ok {
+			if pagination == nil {
+				pagination = map[string]any{}
+			}
+			pagination[key] = v
+		}
+	}
+	return pagination
+}
+
+// wrapToolOutput 把工具结果的文本内容替换为 Envelope JSON。
+// 结构化内容保持原样，以符合工具声明的输出 Schema；图片等非文本内容保留在 Envelope 之后。
+func wrapToolOutput(tool string, result *mcp.CallToolResult, duration time.Duration) *mcp.CallToolResult {
+	if result == nil {
+		return nil
+	}
+
+	var texts []string
+	var others []mcp.Content
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+			continue
+		}
+		others = append(others, c)
+	}
+	text := strings.Join(texts, "\n")
+
+	envelope := Envelope{Meta: EnvelopeMeta{Tool: tool, DurationMs: duration.Milliseconds()}}
+	switch {
+	case result.IsError:
+		code := toolErrorCode
+		if strings.HasPrefix(text, OutputShapeErrorCode+":") {
+			code = OutputShapeErrorCode
+		}
+		envelope.Error = &EnvelopeError{Code: code, Message: text}
+	case result.StructuredContent != nil:
+		envelope.Data = result.StructuredContent
+		envelope.Meta.Pagination = paginationMeta(result.StructuredContent)
+	default:
+		envelope.Data = text
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return result
+	}
+
+	wrapped := *result
+	wrapped.Content = append([]mcp.Content{&mcp.TextContent{Text: string(data)}}, others...)
+	return &wrapped
+}
@@ -4,3 +4,27 @@ import "errors"
 
 var ErrNoFeeds = errors.New("没有捕获到 feeds 数据")
 var ErrNoFeedDetail = errors.New("没有捕获到 feed 详情数据")
+
+// ErrContentRestricted 内容受地区/年龄等限制，当前账号无法查看
+var ErrContentRestricted = errors.New("内容受限，无法查看")
+
+// ErrContentGated 页面弹出需要人工确认的拦截层（如已关闭自动确认的地区提示）
+var ErrContentGated = errors.New("页面需要确认后才能查看内容")
+
+// ErrNotOwner 笔记不属于当前登录账号，删除、编辑、置顶等操作前检查
+var ErrNotOwner = errors.New("not_owner: 当前账号不是该笔记的作者")
+
+// ErrNicknameCooldown 昵称修改过于频繁，需等待平台的冷却期结束
+var ErrNicknameCooldown = errors.New("昵称处于修改冷却期，请稍后再试")
+
+// ErrSessionExpired 写操作前检查发现登录已失效
+var ErrSessionExpired = errors.New("登录已失效，请重新扫码登录后再试")
+
+// ErrPollUnsupported 当前笔记类型或编辑器不支持添加投票贴纸
+var ErrPollUnsupported = errors.New("poll_unsupported: 当前笔记类型不支持投票")
+
+// ErrDuplicateImages 发布的图片列表中有内容相同的图片（-duplicate-images=error 时）
+var ErrDuplicateImages = errors.New("duplicate_images: 图片列表中有重复的图片")
+
+// ErrPermissionUnsupported 当前笔记类型或编辑器不支持设置该发布权限（如图文笔记的允许合拍）
+var ErrPermissionUnsupported = errors.New("permission_unsupported: 当前笔记类型不支持该权限设置")
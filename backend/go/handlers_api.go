@@ -1,10 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/webhook"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 
 	"github.com/gin-gonic/gin"
@@ -13,35 +21,66 @@ import (
 
 // respondError 返回错误响应
 func respondError(c *gin.Context, statusCode int, code, message string, details any) {
+	logrus.Errorf("%s %s %s %d", c.Request.Method, c.Request.URL.Path,
+		c.GetString("account"), statusCode)
+
+	if httpEnvelope(c) {
+		c.JSON(statusCode, Envelope{
+			Meta:  EnvelopeMeta{Path: c.Request.URL.Path, DurationMs: requestDuration(c)},
+			Error: &EnvelopeError{Code: code, Message: message, Details: details},
+		})
+		return
+	}
+
 	response := ErrorResponse{
 		Error:   message,
 		Code:    code,
 		Details: details,
 	}
 
-	logrus.Errorf("%s %s %s %d", c.Request.Method, c.Request.URL.Path,
-		c.GetString("account"), statusCode)
-
 	c.JSON(statusCode, response)
 }
 
 // respondSuccess 返回成功响应
 func respondSuccess(c *gin.Context, data any, message string) {
+	logrus.Infof("%s %s %s %d", c.Request.Method, c.Request.URL.Path,
+		c.GetString("account"), http.StatusOK)
+
+	if httpEnvelope(c) {
+		c.JSON(http.StatusOK, Envelope{
+			Data: data,
+			Meta: EnvelopeMeta{
+				Path:       c.Request.URL.Path,
+				Message:    message,
+				DurationMs: requestDuration(c),
+				Pagination: paginationMeta(data),
+			},
+		})
+		return
+	}
+
 	response := SuccessResponse{
 		Success: true,
 		Data:    data,
 		Message: message,
 	}
 
-	logrus.Infof("%s %s %s %d", c.Request.Method, c.Request.URL.Path,
-		c.GetString("account"), http.StatusOK)
-
 	c.JSON(http.StatusOK, response)
 }
 
+// respondSessionExpired 写操作前发现登录已失效时返回 401，已处理时返回 true
+func respondSessionExpired(c *gin.Context, err error) bool {
+	if !errors.Is(err, xhserrors.ErrSessionExpired) {
+		return false
+	}
+	respondError(c, http.StatusUnauthorized, "SESSION_EXPIRED",
+		"登录已失效，请重新登录", err.Error())
+	return true
+}
+
 // checkLoginStatusHandler 检查登录状态
 func (s *AppServer) checkLoginStatusHandler(c *gin.Context) {
-	status, err := s.xiaohongshuService.CheckLoginStatus(c.Request.Context())
+	status, err := s.platform.CheckLoginStatus(c.Request.Context())
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "STATUS_CHECK_FAILED",
 			"检查登录状态失败", err.Error())
@@ -55,7 +94,7 @@ func (s *AppServer) checkLoginStatusHandler(c *gin.Context) {
 // getLoginQrcodeHandler 处理 [GET /api/login/qrcode] 请求。
 // 用于生成并返回登录二维码（Base64 图片 + 超时时间），供前端展示给用户扫码登录。
 func (s *AppServer) getLoginQrcodeHandler(c *gin.Context) {
-	result, err := s.xiaohongshuService.GetLoginQrcode(c.Request.Context())
+	result, err := s.platform.GetLoginQrcode(c.Request.Context())
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "STATUS_CHECK_FAILED",
 			"获取登录二维码失败", err.Error())
@@ -67,7 +106,7 @@ func (s *AppServer) getLoginQrcodeHandler(c *gin.Context) {
 
 // deleteCookiesHandler 删除 cookies，重置登录状态
 func (s *AppServer) deleteCookiesHandler(c *gin.Context) {
-	err := s.xiaohongshuService.DeleteCookies(c.Request.Context())
+	err := s.platform.DeleteCookies(c.Request.Context())
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "DELETE_COOKIES_FAILED",
 			"删除 cookies 失败", err.Error())
@@ -83,7 +122,7 @@ func (s *AppServer) deleteCookiesHandler(c *gin.Context) {
 
 // getCookiesInfoHandler 获取 cookies 文件信息
 func (s *AppServer) getCookiesInfoHandler(c *gin.Context) {
-	info, err := s.xiaohongshuService.GetCookiesInfo(c.Request.Context())
+	info, err := s.platform.GetCookiesInfo(c.Request.Context())
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "GET_COOKIES_INFO_FAILED",
 			"获取 cookies 信息失败", err.Error())
@@ -112,8 +151,31 @@ func (s *AppServer) publishHandler(c *gin.Context) {
 	}
 
 	// 执行发布
-	result, err := s.xiaohongshuService.PublishContent(c.Request.Context(), &req)
+	result, err := s.platform.PublishContent(c.Request.Context(), &req)
 	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		if errors.Is(err, webhook.ErrRejected) {
+			respondError(c, http.StatusForbidden, "PUBLISH_REJECTED",
+				"发布未通过审批", err.Error())
+			return
+		}
+		if errors.Is(err, xhserrors.ErrPollUnsupported) {
+			respondError(c, http.StatusUnprocessableEntity, "POLL_UNSUPPORTED",
+				"不支持添加投票", err.Error())
+			return
+		}
+		if errors.Is(err, xhserrors.ErrPermissionUnsupported) {
+			respondError(c, http.StatusUnprocessableEntity, "PERMISSION_UNSUPPORTED",
+				"不支持该权限设置", err.Error())
+			return
+		}
+		if errors.Is(err, xhserrors.ErrDuplicateImages) {
+			respondError(c, http.StatusUnprocessableEntity, "DUPLICATE_IMAGES",
+				"图片列表中有重复的图片", err.Error())
+			return
+		}
 		respondError(c, http.StatusInternalServerError, "PUBLISH_FAILED",
 			"发布失败", err.Error())
 		return
@@ -132,8 +194,26 @@ func (s *AppServer) publishVideoHandler(c *gin.Context) {
 	}
 
 	// 执行视频发布
-	result, err := s.xiaohongshuService.PublishVideo(c.Request.Context(), &req)
+	result, err := s.platform.PublishVideo(c.Request.Context(), &req)
 	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		if errors.Is(err, webhook.ErrRejected) {
+			respondError(c, http.StatusForbidden, "PUBLISH_REJECTED",
+				"发布未通过审批", err.Error())
+			return
+		}
+		if errors.Is(err, xhserrors.ErrPollUnsupported) {
+			respondError(c, http.StatusUnprocessableEntity, "POLL_UNSUPPORTED",
+				"不支持添加投票", err.Error())
+			return
+		}
+		if errors.Is(err, xhserrors.ErrPermissionUnsupported) {
+			respondError(c, http.StatusUnprocessableEntity, "PERMISSION_UNSUPPORTED",
+				"不支持该权限设置", err.Error())
+			return
+		}
 		respondError(c, http.StatusInternalServerError, "PUBLISH_VIDEO_FAILED",
 			"视频发布失败", err.Error())
 		return
@@ -144,8 +224,15 @@ func (s *AppServer) publishVideoHandler(c *gin.Context) {
 
 // listFeedsHandler 获取Feeds列表
 func (s *AppServer) listFeedsHandler(c *gin.Context) {
+	var paginate PaginateRequest
+	if err := c.ShouldBindQuery(&paginate); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
 	// 获取 Feeds 列表
-	result, err := s.xiaohongshuService.ListFeeds(c.Request.Context())
+	result, err := s.platform.ListFeeds(c.Request.Context(), paginate)
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "LIST_FEEDS_FAILED",
 			"获取Feeds列表失败", err.Error())
@@ -160,6 +247,9 @@ func (s *AppServer) listFeedsHandler(c *gin.Context) {
 func (s *AppServer) searchFeedsHandler(c *gin.Context) {
 	var keyword string
 	var filters xiaohongshu.FilterOption
+	var paginate PaginateRequest
+	var fields []string
+	resetFilters := true
 
 	switch c.Request.Method {
 	case http.MethodPost:
@@ -172,8 +262,20 @@ func (s *AppServer) searchFeedsHandler(c *gin.Context) {
 		}
 		keyword = searchReq.Keyword
 		filters = searchReq.Filters
+		paginate = searchReq.PaginateRequest
+		fields = searchReq.Fields
+		resetFilters = resetFiltersOrDefault(searchReq.ResetFilters)
 	default:
 		keyword = c.Query("keyword")
+		resetFilters = c.Query("reset_filters") != "false"
+		if v := c.Query("fields"); v != "" {
+			fields = strings.Split(v, ",")
+		}
+		if err := c.ShouldBindQuery(&paginate); err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+				"请求参数错误", err.Error())
+			return
+		}
 	}
 
 	if keyword == "" {
@@ -182,8 +284,14 @@ func (s *AppServer) searchFeedsHandler(c *gin.Context) {
 		return
 	}
 
+	if err := xiaohongshu.ValidateFeedFields(fields); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_FIELDS",
+			"字段参数错误", err.Error())
+		return
+	}
+
 	// 搜索 Feeds
-	result, err := s.xiaohongshuService.SearchFeeds(c.Request.Context(), keyword, filters)
+	result, err := s.platform.SearchFeeds(c.Request.Context(), keyword, paginate, resetFilters, filters)
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "SEARCH_FEEDS_FAILED",
 			"搜索Feeds失败", err.Error())
@@ -191,7 +299,7 @@ func (s *AppServer) searchFeedsHandler(c *gin.Context) {
 	}
 
 	c.Set("account", "ai-report")
-	respondSuccess(c, result, "搜索Feeds成功")
+	respondSuccess(c, result.Project(fields), "搜索Feeds成功")
 }
 
 // getFeedDetailHandler 获取Feed详情
@@ -203,8 +311,14 @@ func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
 		return
 	}
 
+	if err := xiaohongshu.ValidateFeedDetailFields(req.Fields); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_FIELDS",
+			"字段参数错误", err.Error())
+		return
+	}
+
 	// 获取 Feed 详情
-	result, err := s.xiaohongshuService.GetFeedDetail(c.Request.Context(), req.FeedID, req.XsecToken)
+	result, err := s.platform.GetFeedDetail(c.Request.Context(), req.FeedID, req.XsecToken, req.Fields, req.IncludeEngagement)
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "GET_FEED_DETAIL_FAILED",
 			"获取Feed详情失败", err.Error())
@@ -225,7 +339,7 @@ func (s *AppServer) userProfileHandler(c *gin.Context) {
 	}
 
 	// 获取用户信息
-	result, err := s.xiaohongshuService.UserProfile(c.Request.Context(), req.UserID, req.XsecToken)
+	result, err := s.platform.UserProfile(c.Request.Context(), req.UserID, req.XsecToken)
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "GET_USER_PROFILE_FAILED",
 			"获取用户主页失败", err.Error())
@@ -246,8 +360,11 @@ func (s *AppServer) postCommentHandler(c *gin.Context) {
 	}
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), req.FeedID, req.XsecToken, req.Content)
+	result, err := s.platform.PostCommentToFeed(c.Request.Context(), req.FeedID, req.XsecToken, req.Content)
 	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
 		respondError(c, http.StatusInternalServerError, "POST_COMMENT_FAILED",
 			"发表评论失败", err.Error())
 		return
@@ -270,7 +387,7 @@ func healthHandler(c *gin.Context) {
 // myProfileHandler 我的信息
 func (s *AppServer) myProfileHandler(c *gin.Context) {
 	// 获取当前登录用户信息
-	result, err := s.xiaohongshuService.GetMyProfile(c.Request.Context())
+	result, err := s.platform.GetMyProfile(c.Request.Context())
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "GET_MY_PROFILE_FAILED",
 			"获取我的主页失败", err.Error())
@@ -280,3 +397,961 @@ func (s *AppServer) myProfileHandler(c *gin.Context) {
 	c.Set("account", "ai-report")
 	respondSuccess(c, map[string]any{"data": result}, "获取我的主页成功")
 }
+
+// accountRegionHandler 当前账号的地区与语言
+func (s *AppServer) accountRegionHandler(c *gin.Context) {
+	result, err := s.platform.GetAccountRegion(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_ACCOUNT_REGION_FAILED",
+			"获取账号地区失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取账号地区成功")
+}
+
+// accountAggregateStatsHandler 汇总当前账号全部笔记的互动数据
+func (s *AppServer) accountAggregateStatsHandler(c *gin.Context) {
+	var req AccountAggregateStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetAccountAggregateStats(c.Request.Context(), req.MaxNotes, req.Refresh, nil)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_ACCOUNT_STATS_FAILED",
+			"账号汇总统计失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "账号汇总统计成功")
+}
+
+// updateProfileHandler 修改我的资料
+func (s *AppServer) updateProfileHandler(c *gin.Context) {
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	update := xiaohongshu.ProfileUpdate{Nickname: req.Nickname, Bio: req.Bio, AvatarPath: req.AvatarPath}
+	if err := xiaohongshu.ValidateProfileUpdate(update); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.UpdateProfile(c.Request.Context(), update)
+	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		if errors.Is(err, xhserrors.ErrNicknameCooldown) {
+			respondError(c, http.StatusConflict, "NICKNAME_COOLDOWN",
+				"昵称处于修改冷却期", err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "UPDATE_PROFILE_FAILED",
+			"修改资料失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "修改资料成功")
+}
+
+// editorConfigHandler 发布编辑器默认配置
+func (s *AppServer) editorConfigHandler(c *gin.Context) {
+	result, err := s.platform.GetEditorConfig(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_EDITOR_CONFIG_FAILED",
+			"获取发布编辑器配置失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取发布编辑器配置成功")
+}
+
+// viewHistoryHandler 分页获取浏览记录
+func (s *AppServer) viewHistoryHandler(c *gin.Context) {
+	cursor := c.Query("cursor")
+	if err := xiaohongshu.ValidateViewHistoryCursor(cursor); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_CURSOR",
+			"分页游标错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetViewHistory(c.Request.Context(), cursor)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_VIEW_HISTORY_FAILED",
+			"获取浏览记录失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取浏览记录成功")
+}
+
+// blockedUsersHandler 分页获取黑名单
+func (s *AppServer) blockedUsersHandler(c *gin.Context) {
+	cursor := c.Query("cursor")
+	if err := xiaohongshu.ValidateModerationCursor(cursor); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_CURSOR",
+			"分页游标错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetBlockedUsers(c.Request.Context(), cursor)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_BLOCKED_USERS_FAILED",
+			"获取黑名单失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取黑名单成功")
+}
+
+// mutedKeywordsHandler 分页获取屏蔽词
+func (s *AppServer) mutedKeywordsHandler(c *gin.Context) {
+	cursor := c.Query("cursor")
+	if err := xiaohongshu.ValidateModerationCursor(cursor); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_CURSOR",
+			"分页游标错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetMutedKeywords(c.Request.Context(), cursor)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_MUTED_KEYWORDS_FAILED",
+			"获取屏蔽词失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取屏蔽词成功")
+}
+
+// earningsHandler 创作者收益信息
+func (s *AppServer) earningsHandler(c *gin.Context) {
+	period := c.DefaultQuery("period", xiaohongshu.EarningsPeriod7d)
+	if err := xiaohongshu.ValidateEarningsPeriod(period); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_PERIOD",
+			"统计周期参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetEarnings(c.Request.Context(), period)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_EARNINGS_FAILED",
+			"获取收益信息失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取收益信息成功")
+}
+
+// bestPostingTimesHandler 获取推荐发布时间
+func (s *AppServer) bestPostingTimesHandler(c *gin.Context) {
+	result, err := s.platform.GetBestPostingTimes(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_POSTING_TIMES_FAILED",
+			"获取推荐发布时间失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取推荐发布时间成功")
+}
+
+// getAutoReplyHandler 获取私信自动回复设置
+func (s *AppServer) getAutoReplyHandler(c *gin.Context) {
+	result, err := s.platform.GetAutoReply(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_AUTO_REPLY_FAILED",
+			"获取自动回复设置失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取自动回复设置成功")
+}
+
+// setAutoReplyHandler 修改私信自动回复设置
+func (s *AppServer) setAutoReplyHandler(c *gin.Context) {
+	var req SetAutoReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateAutoReply(req.Text, req.Enabled); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.SetAutoReply(c.Request.Context(), req.Text, req.Enabled)
+	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "SET_AUTO_REPLY_FAILED",
+			"修改自动回复设置失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "修改自动回复设置成功")
+}
+
+// getNotificationSettingsHandler 获取通知设置
+func (s *AppServer) getNotificationSettingsHandler(c *gin.Context) {
+	result, err := s.platform.GetNotificationSettings(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTIFICATION_SETTINGS_FAILED",
+			"获取通知设置失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取通知设置成功")
+}
+
+// setNotificationSettingsHandler 修改通知设置
+func (s *AppServer) setNotificationSettingsHandler(c *gin.Context) {
+	var req SetNotificationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateNotificationSettings(req.Categories); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.SetNotificationSettings(c.Request.Context(), req.Categories)
+	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "SET_NOTIFICATION_SETTINGS_FAILED",
+			"修改通知设置失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "修改通知设置成功")
+}
+
+// proxyTestHandler 检测代理是否可以访问小红书
+func (s *AppServer) proxyTestHandler(c *gin.Context) {
+	var req ProxyTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.TestProxy(c.Request.Context(), req.ProxyURL)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "TEST_PROXY_FAILED",
+			"代理检测失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "代理检测完成")
+}
+
+// noteTypeHandler 批量检测笔记类型
+func (s *AppServer) noteTypeHandler(c *gin.Context) {
+	var req NoteTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteTypes(c.Request.Context(), req.Notes)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_TYPE_FAILED",
+			"检测笔记类型失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "检测笔记类型成功")
+}
+
+// checkMutualHandler 批量检查关注关系
+func (s *AppServer) checkMutualHandler(c *gin.Context) {
+	var req CheckMutualRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateUserRefs(req.Users); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.CheckMutual(c.Request.Context(), req.Users)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "CHECK_MUTUAL_FAILED",
+			"检查关注关系失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "检查关注关系成功")
+}
+
+// noteCommentsHandler 获取笔记评论
+func (s *AppServer) noteCommentsHandler(c *gin.Context) {
+	var req NoteCommentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	if err := xiaohongshu.ValidateCommentSort(req.Sort); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_SORT",
+			"评论排序参数错误", err.Error())
+		return
+	}
+
+	if err := xiaohongshu.ValidateCommentDepth(req.MaxDepth); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_DEPTH",
+			"评论深度参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteComments(c.Request.Context(), req.FeedID, req.XsecToken, req.Sort, req.MaxDepth)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_COMMENTS_FAILED",
+			"获取笔记评论失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取笔记评论成功")
+}
+
+// 评论流的轮询间隔与心跳间隔
+const (
+	defaultCommentStreamInterval = 30 * time.Second
+	minCommentStreamInterval     = 10 * time.Second
+	commentStreamHeartbeat       = 15 * time.Second
+)
+
+// commentStreamHandler 以 SSE 推送笔记的新评论，直到客户端断开连接
+func (s *AppServer) commentStreamHandler(c *gin.Context) {
+	var req CommentStreamRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	feedID := c.Param("id")
+	interval := defaultCommentStreamInterval
+	if req.Interval > 0 {
+		interval = max(time.Duration(req.Interval)*time.Second, minCommentStreamInterval)
+	}
+
+	ctx := c.Request.Context()
+	events := make(chan []xiaohongshu.Comment)
+	done := make(chan error, 1)
+
+	go func() {
+		// 浏览器操作失败会 panic，这里不在 gin 的 recovery 范围内，需要自行恢复
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("评论流异常: %v", r)
+			}
+		}()
+
+		done <- s.platform.StreamNoteComments(ctx, feedID, req.XsecToken, interval,
+			func(comments []xiaohongshu.Comment) error {
+				select {
+				case events <- comments:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.SSEvent("ready", gin.H{"feed_id": feedID, "interval_seconds": int(interval.Seconds())})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(commentStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	logrus.Infof("评论流开始: feed=%s, interval=%s", feedID, interval)
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Infof("评论流客户端断开: feed=%s", feedID)
+			<-done
+			return
+		case comments := <-events:
+			for _, comment := range comments {
+				c.SSEvent("comment", comment)
+			}
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"time": time.Now().Unix()})
+		case err := <-done:
+			if err != nil {
+				logrus.Errorf("评论流结束: feed=%s %v", feedID, err)
+				c.SSEvent("error", gin.H{"error": err.Error()})
+			}
+			c.Writer.Flush()
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// exportLine NDJSON 导出的一行：type 为 note 时 data 为笔记，为 summary 时为结尾汇总
+type exportLine struct {
+	Type  string `json:"type"`
+	Data  any    `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// exportNotesHandler 以 NDJSON 流式导出笔记，每抓到一条就写出一行，最后一行为汇总
+func (s *AppServer) exportNotesHandler(c *gin.Context) {
+	var req ExportNotesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if (req.Keyword == "") == (req.UserID == "") {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", "keyword 与 user_id 必须且只能指定一个")
+		return
+	}
+	if req.UserID != "" && req.XsecToken == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", "按用户导出时 xsec_token 必填")
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+	writeLine := func(line exportLine) error {
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	var summary *ExportSummary
+	var err error
+	written := 0
+	func() {
+		// 响应头已写出，浏览器操作 panic 时也要输出汇总行而不是交给 gin recovery
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("导出异常: %v", r)
+			}
+		}()
+
+		summary, err = s.platform.ExportNotes(c.Request.Context(), &req,
+			func(feed xiaohongshu.Feed) error {
+				if err := writeLine(exportLine{Type: "note", Data: feed}); err != nil {
+					return err
+				}
+				written++
+				return nil
+			})
+	}()
+
+	if summary == nil {
+		summary = &ExportSummary{
+			Scope:    req.scope(),
+			Count:    written,
+			MaxItems: configs.ClampExportItems(req.MaxItems),
+		}
+	}
+	last := exportLine{Type: "summary", Data: summary}
+	if err != nil {
+		logrus.Errorf("导出笔记中断: count=%d %v", summary.Count, err)
+		last.Error = err.Error()
+	} else {
+		logrus.Infof("导出笔记结束: scope=%s, count=%d, complete=%v", summary.Scope, summary.Count, summary.Complete)
+	}
+	_ = writeLine(last)
+}
+
+// videoCommentsHandler 获取视频弹幕
+func (s *AppServer) videoCommentsHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetVideoComments(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_VIDEO_COMMENTS_FAILED",
+			"获取视频弹幕失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取视频弹幕成功")
+}
+
+// noteCollaboratorsHandler 获取笔记合作信息
+func (s *AppServer) noteCollaboratorsHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteCollaborators(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_COLLABORATORS_FAILED",
+			"获取笔记合作信息失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取笔记合作信息成功")
+}
+
+// noteTaggedUsersHandler 获取笔记中标记的用户
+func (s *AppServer) noteTaggedUsersHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteTaggedUsers(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_TAGGED_USERS_FAILED",
+			"获取笔记标记用户失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取笔记标记用户成功")
+}
+
+// myCommentsHandler 列出当前账号在笔记下的评论
+func (s *AppServer) myCommentsHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetMyComments(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_MY_COMMENTS_FAILED",
+			"获取我的评论失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取我的评论成功")
+}
+
+// deleteCommentHandler 删除当前账号的一条评论
+func (s *AppServer) deleteCommentHandler(c *gin.Context) {
+	var req xiaohongshu.CommentRef
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateCommentRefs([]xiaohongshu.CommentRef{req}); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.DeleteComment(c.Request.Context(), req)
+	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		if errors.Is(err, xhserrors.ErrNotOwner) {
+			respondError(c, http.StatusForbidden, "NOT_OWNER",
+				"只能删除当前账号发表的评论", err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "DELETE_COMMENT_FAILED",
+			"删除评论失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "删除评论成功")
+}
+
+// batchDeleteCommentsHandler 批量删除当前账号的评论，单条失败不影响其余评论
+func (s *AppServer) batchDeleteCommentsHandler(c *gin.Context) {
+	var req BatchDeleteCommentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateCommentRefs(req.Comments); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.BatchDeleteComments(c.Request.Context(), req.Comments)
+	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "BATCH_DELETE_COMMENTS_FAILED",
+			"批量删除评论失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "批量删除评论完成")
+}
+
+// noteRepostsHandler 获取复用了相同图片/内容的笔记
+func (s *AppServer) noteRepostsHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteReposts(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_REPOSTS_FAILED",
+			"获取相同图片笔记失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取相同图片笔记成功")
+}
+
+// videoCoverHandler 获取视频笔记封面
+func (s *AppServer) videoCoverHandler(c *gin.Context) {
+	var req VideoCoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetVideoCover(c.Request.Context(), req.FeedID, req.XsecToken, req.Download)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_VIDEO_COVER_FAILED",
+			"获取视频封面失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取视频封面成功")
+}
+
+// noteMusicHandler 获取笔记背景音乐
+func (s *AppServer) noteMusicHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteMusic(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_MUSIC_FAILED",
+			"获取笔记音乐失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取笔记音乐成功")
+}
+
+// noteThreadHandler 获取笔记与完整评论树
+func (s *AppServer) noteThreadHandler(c *gin.Context) {
+	var req NoteThreadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateThreadComments(req.MaxComments); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"评论条数参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteThread(c.Request.Context(), req.FeedID, req.XsecToken, req.MaxComments)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_THREAD_FAILED",
+			"获取笔记评论串失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取笔记评论串成功")
+}
+
+// noteScreenshotHandler 截取笔记长图归档
+func (s *AppServer) noteScreenshotHandler(c *gin.Context) {
+	var req NoteScreenshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+	if err := xiaohongshu.ValidateThreadComments(req.MaxComments); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"评论条数参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.ArchiveNoteScreenshot(c.Request.Context(), req.FeedID, req.XsecToken, req.MaxComments)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "ARCHIVE_SCREENSHOT_FAILED",
+			"笔记长截图失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "笔记长截图成功")
+}
+
+// noteDiscoverabilityHandler 检测笔记能否被其他人搜到
+func (s *AppServer) noteDiscoverabilityHandler(c *gin.Context) {
+	var req NoteDiscoverabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	keywords, err := xiaohongshu.NormalizeDiscoverabilityKeywords(req.Keywords)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"关键词参数错误", err.Error())
+		return
+	}
+	if len(keywords) == 0 && req.XsecToken == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"缺少 xsec_token 参数", "xsec_token is required when keywords is empty")
+		return
+	}
+
+	result, err := s.platform.CheckNoteDiscoverability(c.Request.Context(), req.FeedID, req.XsecToken, keywords)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "CHECK_DISCOVERABILITY_FAILED",
+			"检测笔记可发现性失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "检测笔记可发现性成功")
+}
+
+// searchFiltersHandler 读取搜索结果页记住的筛选条件
+func (s *AppServer) searchFiltersHandler(c *gin.Context) {
+	keyword := strings.TrimSpace(c.Query("keyword"))
+	if keyword == "" {
+		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
+			"缺少关键词参数", "keyword parameter is required")
+		return
+	}
+
+	result, err := s.platform.GetSearchFilters(c.Request.Context(), keyword)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_SEARCH_FILTERS_FAILED",
+			"获取搜索筛选失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "获取搜索筛选成功")
+}
+
+// checkKeywordHandler 检测关键词是否被限制搜索
+func (s *AppServer) checkKeywordHandler(c *gin.Context) {
+	keyword := strings.TrimSpace(c.Query("keyword"))
+	if keyword == "" {
+		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
+			"缺少关键词参数", "keyword parameter is required")
+		return
+	}
+
+	result, err := s.platform.CheckKeyword(c.Request.Context(), keyword)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "CHECK_KEYWORD_FAILED",
+			"检测关键词失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "检测关键词成功")
+}
+
+// isMyNoteHandler 检查笔记是否属于当前账号
+func (s *AppServer) isMyNoteHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.IsMyNote(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "IS_MY_NOTE_FAILED",
+			"检查笔记归属失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "检查笔记归属成功")
+}
+
+// noteSettingsHandler 读取笔记的评论与互动权限
+func (s *AppServer) noteSettingsHandler(c *gin.Context) {
+	var req FeedDetailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.GetNoteSettings(c.Request.Context(), req.FeedID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTE_SETTINGS_FAILED",
+			"读取笔记互动权限失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "读取笔记互动权限成功")
+}
+
+// uploadImagesHandler 预上传图片
+func (s *AppServer) uploadImagesHandler(c *gin.Context) {
+	var req UploadImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.UploadImages(c.Request.Context(), req.Images)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "UPLOAD_IMAGES_FAILED",
+			"预上传图片失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "预上传图片成功")
+}
+
+// savePublishTemplateHandler 保存发布模板
+func (s *AppServer) savePublishTemplateHandler(c *gin.Context) {
+	var req SavePublishTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.platform.SavePublishTemplate(req.Name, req.Spec)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "SAVE_TEMPLATE_FAILED",
+			"保存发布模板失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "保存发布模板成功")
+}
+
+// listPublishTemplatesHandler 列出发布模板
+func (s *AppServer) listPublishTemplatesHandler(c *gin.Context) {
+	names, err := s.platform.ListPublishTemplates()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_TEMPLATES_FAILED",
+			"获取发布模板失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, gin.H{"templates": names, "count": len(names)}, "获取发布模板成功")
+}
+
+// publishFromTemplateHandler 按模板发布，请求体为覆盖项
+func (s *AppServer) publishFromTemplateHandler(c *gin.Context) {
+	var overrides templates.Overrides
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&overrides); err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+				"请求参数错误", err.Error())
+			return
+		}
+	}
+
+	result, err := s.platform.PublishFromTemplate(c.Request.Context(), c.Param("name"), overrides)
+	if err != nil {
+		if respondSessionExpired(c, err) {
+			return
+		}
+		status := http.StatusInternalServerError
+		if errors.Is(err, templates.ErrNotFound) {
+			status = http.StatusNotFound
+		} else if errors.Is(err, webhook.ErrRejected) {
+			status = http.StatusForbidden
+		} else if errors.Is(err, xhserrors.ErrDuplicateImages) {
+			status = http.StatusUnprocessableEntity
+		}
+		respondError(c, status, "PUBLISH_FROM_TEMPLATE_FAILED",
+			"按模板发布失败", err.Error())
+		return
+	}
+
+	respondSuccess(c, result, "按模板发布成功")
+}
+
+// enumsHandler 所有枚举字段的取值
+func (s *AppServer) enumsHandler(c *gin.Context) {
+	respondSuccess(c, enumsResponse(), "获取枚举取值成功")
+}
+
+// planCrawlHandler 估算抓取计划，不访问平台
+func (s *AppServer) planCrawlHandler(c *gin.Context) {
+	var req PlanCrawlRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	plan, err := xiaohongshu.PlanCrawl(strings.TrimSpace(req.Scope), req.MaxItems, req.IncludeDetails)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"抓取范围错误", err.Error())
+		return
+	}
+
+	respondSuccess(c, plan, "估算抓取计划成功")
+}
+
+// configHandler 生效配置（已脱敏）
+func (s *AppServer) configHandler(c *gin.Context) {
+	respondSuccess(c, s.config.Redacted(), "获取生效配置成功")
+}
+
+// serverStateHandler 服务运行状态
+func (s *AppServer) serverStateHandler(c *gin.Context) {
+	respondSuccess(c, serverState.snapshot(), "获取服务运行状态成功")
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// 无头模式下连续失败达到该次数时视为被拦截，切换到有界面模式
+const headlessFailureThreshold = 3
+
+// HeadfulFallbackState 自动切换到有界面模式的状态，未开启 -headful-fallback 时不返回
+type HeadfulFallbackState struct {
+	Switched   bool      `json:"switched"`
+	SwitchedAt time.Time `json:"switched_at,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	// Failures 无头模式下连续失败的次数，达到阈值时切换
+	Failures  int `json:"failures"`
+	Threshold int `json:"threshold"`
+}
+
+// headlessFailureCounter 统计无头模式下连续失败的浏览器操作，任一操作成功即清零
+type headlessFailureCounter struct {
+	mu       sync.Mutex
+	failures int
+}
+
+var headlessFailures = &headlessFailureCounter{}
+
+// observe 记录一次操作的结果，返回应当切换到有界面模式的原因；不需要切换时返回空
+func (c *headlessFailureCounter) observe(err error, blocked string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.failures = 0
+		return ""
+	}
+	// 调用方取消或超时不代表浏览器被拦截
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ""
+	}
+
+	c.failures++
+	if blocked != "" {
+		return blocked
+	}
+	if c.failures >= headlessFailureThreshold {
+		return fmt.Sprintf("连续 %d 次浏览器操作失败", c.failures)
+	}
+	return ""
+}
+
+func (c *headlessFailureCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.failures
+}
+
+// headfulFallbackState 返回自动切换的状态，未开启时返回 nil
+func headfulFallbackState() *HeadfulFallbackState {
+	if !configs.IsHeadfulFallback() {
+		return nil
+	}
+	at, reason := configs.GetHeadfulFallback()
+	return &HeadfulFallbackState{
+		Switched:   !at.IsZero(),
+		SwitchedAt: at,
+		Reason:     reason,
+		Failures:   headlessFailures.count(),
+		Threshold:  headlessFailureThreshold,
+	}
+}
+
+// runBrowserPage 在新浏览器的页面上执行 fn，fn 失败时返回页面上检测到的拦截原因
+func runBrowserPage(fn func(*rod.Page) error) (string, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	err := fn(page)
+	return detectHeadlessBlock(page, err), err
+}
+
+// detectHeadlessBlock 开启 -headful-fallback 且处于无头模式时，操作失败后检查页面是否停在拦截页，返回拦截原因
+func detectHeadlessBlock(page *rod.Page, err error) string {
+	if err != nil && configs.IsHeadless() && configs.IsHeadfulFallback() {
+		return xiaohongshu.DetectHeadlessBlock(page)
+	}
+	return ""
+}
+
+// withBrowserPage 执行需要浏览器页面的操作的通用函数。
+// 开启 -headful-fallback 时，无头浏览器被拦截或连续失败会切换到有界面模式并重试一次。
+func withBrowserPage(fn func(*rod.Page) error) error {
+	return runWithHeadfulFallback(fn, true)
+}
+
+// withBrowserPageOnce 用于不能重复执行的写操作（发布、评论）：失败同样计入连续失败次数并可能切换模式，
+// 但不自动重试，避免同一内容被发布两次
+func withBrowserPageOnce(fn func(*rod.Page) error) error {
+	return runWithHeadfulFallback(fn, false)
+}
+
+func runWithHeadfulFallback(fn func(*rod.Page) error, retry bool) error {
+	headless := configs.IsHeadless()
+
+	blocked, err := runBrowserPage(fn)
+	reason := recordBrowserResult(headless, blocked, err)
+	if reason == "" {
+		return err
+	}
+
+	if !retry {
+		logrus.Warnf("无头浏览器疑似被拦截（%s），已切换到有界面模式，写操作不自动重试: %v", reason, err)
+		return err
+	}
+	logrus.Warnf("无头浏览器疑似被拦截（%s），已切换到有界面模式并重试: %v", reason, err)
+	_, err = runBrowserPage(fn)
+	return err
+}
+
+// recordBrowserResult 记录无头模式下一次浏览器操作的结果，需要时切换到有界面模式。
+// 返回切换的原因；未开启 -headful-fallback、不在无头模式或无需切换时返回空
+func recordBrowserResult(headless bool, blocked string, err error) string {
+	if !headless || !configs.IsHeadfulFallback() {
+		return ""
+	}
+
+	reason := headlessFailures.observe(err, blocked)
+	if reason == "" || !configs.FallbackToHeadful(reason) {
+		return ""
+	}
+	return reason
+}
@@ -2,31 +2,113 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/browser"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/secrets"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/webhook"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
 func main() {
 	var (
-		headless    bool
-		binPath     string // 浏览器二进制文件路径
-		port        int
-		desktopMode bool
+		headless        bool
+		headfulFallback bool   // 无头浏览器被拦截时自动切换到有界面模式
+		binPath         string // 浏览器二进制文件路径
+		host            string
+		port            int
+		apiAddr         string // HTTP API 独立监听地址，为空表示与 MCP 共用端口
+		desktopMode     bool
+
+		launchAttempts int           // 浏览器启动尝试次数
+		launchBackoff  time.Duration // 浏览器启动重试的初始退避
+
+		autoDismissGates bool   // 是否自动确认地区提示
+		uiVariant        string // 界面版本，auto 表示自动识别
+
+		pageInterval time.Duration // 自动翻页间隔
+
+		platformName string // 内容平台
+
+		maxResponseBytes int    // MCP 工具结果的最大字节数
+		responseEnvelope string // 响应格式
+
+		prePublishWebhook  string        // 发布前审批回调地址
+		prePublishTimeout  time.Duration // 审批回调超时
+		prePublishFailOpen bool          // 审批回调不可用时是否放行
+
+		eventWebhook string // 写操作事件回调地址
+		eventNames   string // 订阅的事件
+
+		checkSessionBeforeWrite bool // 写操作前检查登录状态
+
+		publishConcurrency int // 全局同时执行的发布数上限
+
+		duplicateImages string // 重复图片的处理方式
+
+		downloadConcurrency int // 同时下载的图片数
+		downloadRetries     int // 下载瞬时错误的重试次数
+
+		uploadTimeout time.Duration // 每次上传素材的等待时间
+		uploadRetries int           // 上传失败后重新上传的次数
+
+		secretSource  string // 敏感配置的读取来源
+		secretService string // 钥匙串条目的服务名
+
+		profileAddr string // pprof 监听地址
 	)
 	flag.BoolVar(&headless, "headless", true, "是否无头模式")
+	flag.BoolVar(&headfulFallback, "headful-fallback", false, fmt.Sprintf("无头模式下检测到拦截页（验证码、环境异常提示等）或浏览器操作连续失败 %d 次时，切换到有界面模式并重试该操作（发布、评论不自动重试，避免重复发布），之后的操作都使用有界面模式直到重启；需要可用的图形界面", headlessFailureThreshold))
 	flag.StringVar(&binPath, "bin", "", "浏览器二进制文件路径")
+	flag.IntVar(&launchAttempts, "browser-launch-attempts", configs.GetBrowserLaunchAttempts(), "浏览器启动失败时的尝试次数（含第一次），用于容器/CI 等首次启动不稳定的环境")
+	flag.DurationVar(&launchBackoff, "browser-launch-backoff", configs.GetBrowserLaunchBackoff(), "浏览器启动重试的初始等待时间，之后每次翻倍并加入随机抖动")
+	flag.StringVar(&host, "host", "", "监听地址，如 127.0.0.1 表示仅本机访问，默认监听所有网卡")
 	flag.IntVar(&port, "port", 18060, "HTTP 端口，0 表示自动分配")
+	flag.StringVar(&apiAddr, "api-addr", "", "HTTP API（健康检查等）独立监听地址，如 0.0.0.0:18061；设置后 -host/-port 仅提供 MCP")
 	flag.BoolVar(&desktopMode, "desktop", false, "桌面应用模式（Electron）")
+	flag.BoolVar(&autoDismissGates, "auto-dismiss-gates", configs.AutoDismissGates(), "是否自动确认地区提示弹窗；关闭后遇到地区提示会返回错误")
+	flag.StringVar(&uiVariant, "ui-variant", configs.UIVariantAuto, "小红书界面版本: auto 根据页面自动识别，或强制指定 default|classic")
+	flag.DurationVar(&pageInterval, "page-interval", configs.GetPageInterval(), "自动翻页时两次加载之间的间隔")
+	flag.StringVar(&platformName, "platform", DefaultPlatform, "内容平台，可选: "+strings.Join(PlatformNames(), "|"))
+	flag.IntVar(&maxResponseBytes, "max-response-bytes", configs.GetMaxResponseBytes(), "MCP 工具结果的最大字节数，超出部分通过 continue_result 续取；0 表示不截断，客户端也可在调用的 _meta.max_response_bytes 中单独指定")
+	flag.StringVar(&responseEnvelope, "response-envelope", configs.GetResponseEnvelope(), "工具与 HTTP 响应格式: raw 直接返回数据；wrapped 统一包装为 {data, meta, error}，meta 含耗时与分页信息。HTTP 请求可用 X-Response-Envelope 请求头、MCP 调用可用 _meta.envelope 单独指定")
+	flag.StringVar(&prePublishWebhook, "prepublish-webhook", "", "发布前审批回调地址：发布前 POST 发布内容，返回 200 且未声明 approved=false 时才发布；为空表示不审批。也可通过环境变量 PREPUBLISH_WEBHOOK 或钥匙串（见 -secret-source）设置，避免出现在进程参数中")
+	flag.DurationVar(&prePublishTimeout, "prepublish-timeout", configs.PrePublishTimeout(), "发布前审批回调的超时")
+	flag.BoolVar(&prePublishFailOpen, "prepublish-fail-open", false, "审批回调不可用（超时、网络错误、5xx）时仍然发布；默认中止发布")
+	flag.StringVar(&eventWebhook, "event-webhook", "", "写操作（发布、评论、点赞等）成功后 POST 事件的回调地址，失败重试后写入数据目录下的死信文件；为空表示不发送。也可通过环境变量 EVENT_WEBHOOK 或钥匙串（见 -secret-source）设置")
+	flag.StringVar(&eventNames, "event-webhook-events", "all", "订阅的事件，逗号分隔，可选: all|"+strings.Join(webhook.EventNames(), "|"))
+	flag.StringVar(&profileAddr, "profile", "", "在该回环地址上提供 net/http/pprof（如 127.0.0.1:6060），用于性能分析；为空表示关闭")
+	flag.IntVar(&publishConcurrency, "publish-concurrency", 0, "全局同时执行的发布数上限（图文、视频、模板发布共用），超出的发布排队等待，队列深度见 get_server_state；0 表示不限制")
+	flag.BoolVar(&checkSessionBeforeWrite, "check-session-before-write", false, "每次写操作（发布、评论、点赞、修改设置等）前重新检查登录状态，已失效时直接返回 SESSION_EXPIRED 而不执行；每次写操作会多打开一次浏览器")
+	flag.StringVar(&duplicateImages, "duplicate-images", configs.DuplicateImagesDedupe, "发布的图片列表中有内容相同的图片时: dedupe 去掉重复的图片并在结果的 duplicate_images 中列出；error 拒绝发布")
+	flag.IntVar(&downloadConcurrency, "download-concurrency", configs.DefaultDownloadConcurrency, fmt.Sprintf("批量下载图片（发布时的图片 URL 等）时同时下载的文件数，1-%d", configs.MaxDownloadConcurrency))
+	flag.IntVar(&downloadRetries, "download-retries", configs.DefaultDownloadRetries, "下载遇到网络错误或 408/429/5xx 时的重试次数，重试间隔从 500ms 开始翻倍；0 表示不重试")
+	flag.DurationVar(&uploadTimeout, "upload-timeout", 0, "发布时每次上传图片或视频等待上传完成的时间，与工具整体超时分开计算；0 表示使用默认值（图片 60s，视频 10m）")
+	flag.IntVar(&uploadRetries, "upload-retries", configs.DefaultUploadRetries, fmt.Sprintf("上传失败或超时后只重新上传失败的文件而不重新开始整篇发布的次数，0-%d；0 表示不重试", configs.MaxUploadRetries))
+	flag.StringVar(&secretSource, "secret-source", secrets.SourceEnv, "回调地址等敏感配置的读取来源: env 使用命令行参数与环境变量；keychain 优先读取系统钥匙串（macOS 钥匙串、Windows 凭据管理器、Linux libsecret），条目不存在时回退到 env")
+	flag.StringVar(&secretService, "secret-service", secrets.DefaultService, "钥匙串条目的服务名，账户名为配置名: prepublish_webhook|event_webhook")
 	flag.Parse()
 
+	source, err := secrets.ParseSource(secretSource)
+	if err != nil {
+		logrus.Fatalf("invalid -secret-source: %v", err)
+	}
+	resolver := secrets.NewResolver(source, secretService)
+	var prePublishFrom, eventFrom string
+	prePublishWebhook, prePublishFrom = resolver.Resolve("prepublish_webhook", prePublishWebhook, "PREPUBLISH_WEBHOOK")
+	eventWebhook, eventFrom = resolver.Resolve("event_webhook", eventWebhook, "EVENT_WEBHOOK")
+
 	if desktopMode {
 		// 桌面模式默认使用非无头浏览器，端口自动分配
 		headless = false
@@ -40,26 +122,153 @@ func main() {
 	}
 
 	configs.InitHeadless(headless)
+	configs.SetHeadfulFallback(headfulFallback)
+	if headfulFallback && headless {
+		if runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			logrus.Warn("已开启 -headful-fallback，但没有检测到图形界面（DISPLAY/WAYLAND_DISPLAY），切换后浏览器可能无法启动")
+		}
+		logrus.Info("无头浏览器被拦截时将自动切换到有界面模式")
+	}
 	configs.SetBinPath(binPath)
+	configs.SetBrowserLaunchRetry(launchAttempts, launchBackoff)
+	if err := browser.CheckBinaryArch(binPath); err != nil {
+		// 不退出：健康检查等接口仍可用，浏览器相关工具会返回同样的提示
+		logrus.Errorf("浏览器检查失败: %v", err)
+	}
+	configs.SetPageInterval(pageInterval)
+	configs.SetMaxResponseBytes(maxResponseBytes)
+	if err := configs.ValidateEnvelope(responseEnvelope); err != nil {
+		logrus.Fatalf("invalid -response-envelope: %v", err)
+	}
+	configs.SetResponseEnvelope(responseEnvelope)
+	configs.SetPrePublishWebhook(prePublishWebhook, prePublishTimeout, prePublishFailOpen)
+	if prePublishWebhook != "" {
+		logrus.Infof("发布前审批回调: %s (来源 %s, 超时 %s, fail-open=%v)", redactURL(prePublishWebhook), prePublishFrom, prePublishTimeout, prePublishFailOpen)
+	}
+	if err := configs.ValidateDownloadOptions(downloadConcurrency, downloadRetries); err != nil {
+		logrus.Fatalf("invalid -download-concurrency/-download-retries: %v", err)
+	}
+	configs.SetDownloadOptions(downloadConcurrency, downloadRetries)
+	if err := configs.ValidateUploadOptions(uploadTimeout, uploadRetries); err != nil {
+		logrus.Fatalf("invalid -upload-timeout/-upload-retries: %v", err)
+	}
+	configs.SetUploadOptions(uploadTimeout, uploadRetries)
+	if err := configs.ValidateDuplicateImages(duplicateImages); err != nil {
+		logrus.Fatalf("invalid -duplicate-images: %v", err)
+	}
+	configs.SetDuplicateImages(duplicateImages)
+	configs.SetAutoDismissGates(autoDismissGates)
+	if err := xiaohongshu.ValidateUIVariant(uiVariant); err != nil {
+		logrus.Fatalf("invalid -ui-variant: %v", err)
+	}
+	configs.SetUIVariant(uiVariant)
+
+	if profileAddr != "" {
+		addr, err := startProfileServer(profileAddr)
+		if err != nil {
+			logrus.Fatalf("invalid -profile: %v", err)
+		}
+		logrus.Infof("pprof: http://%s/debug/pprof/", addr)
+	}
 
 	// 初始化服务
-	xiaohongshuService := NewXiaohongshuService()
+	platform, err := NewPlatform(platformName)
+	if err != nil {
+		logrus.Fatalf("invalid -platform: %v", err)
+	}
+	logrus.Infof("使用平台: %s", platformName)
+
+	if checkSessionBeforeWrite {
+		platform = withSessionCheck(platform)
+		logrus.Info("写操作前检查登录状态: 已开启")
+	}
+
+	var emitter *webhook.Emitter
+	if eventWebhook != "" {
+		events, err := webhook.ParseEvents(eventNames)
+		if err != nil {
+			logrus.Fatalf("invalid -event-webhook-events: %v", err)
+		}
+		emitter = webhook.NewEmitter(eventWebhook, events, configs.GetEventDeadLetterPath())
+		platform = withEvents(platform, platformName, emitter)
+		logrus.Infof("写操作事件回调: %s (来源 %s), 事件: %s", redactURL(eventWebhook), eventFrom, strings.Join(events, ","))
+	}
+
+	if publishConcurrency < 0 {
+		logrus.Fatalf("invalid -publish-concurrency: %d", publishConcurrency)
+	}
+	if publishConcurrency > 0 {
+		queue := newPublishQueue(publishConcurrency)
+		platform = withPublishQueue(platform, queue)
+		serverState.setPublishQueue(queue)
+		logrus.Infof("全局发布队列: 同时最多 %d 个发布", publishConcurrency)
+	}
 
 	// 创建并启动应用服务器
-	appServer := NewAppServer(xiaohongshuService)
-	addr := fmt.Sprintf(":%d", port)
+	appServer := NewAppServer(platform)
+	appServer.SetAPIAddr(apiAddr)
+
+	config := ServerConfig{
+		Platform:                platformName,
+		Host:                    host,
+		Port:                    port,
+		APIAddr:                 apiAddr,
+		Desktop:                 desktopMode,
+		DataDir:                 configs.GetDataDir(),
+		Headless:                configs.IsHeadless(),
+		HeadfulFallback:         configs.IsHeadfulFallback(),
+		BinPath:                 configs.GetBinPath(),
+		BrowserLaunchAttempts:   configs.GetBrowserLaunchAttempts(),
+		BrowserLaunchBackoff:    configs.GetBrowserLaunchBackoff().String(),
+		UIVariant:               configs.GetUIVariant(),
+		AutoDismissGates:        configs.AutoDismissGates(),
+		PageInterval:            configs.GetPageInterval().String(),
+		MaxResponseBytes:        configs.GetMaxResponseBytes(),
+		ResponseEnvelope:        configs.GetResponseEnvelope(),
+		PrePublishWebhook:       configs.PrePublishWebhook(),
+		PrePublishTimeout:       configs.PrePublishTimeout().String(),
+		PrePublishFailOpen:      configs.PrePublishFailOpen(),
+		EventWebhook:            eventWebhook,
+		CheckSessionBeforeWrite: checkSessionBeforeWrite,
+		PublishConcurrency:      publishConcurrency,
+		DuplicateImages:         configs.GetDuplicateImages(),
+		DownloadConcurrency:     configs.GetDownloadConcurrency(),
+		DownloadRetries:         configs.GetDownloadRetries(),
+		UploadTimeout:           configs.GetUploadTimeout(0).String(),
+		UploadRetries:           configs.GetUploadRetries(),
+		SecretSource:            resolver.Source(),
+		SecretService:           resolver.Service(),
+		ProfileAddr:             profileAddr,
+	}
+	if eventWebhook != "" {
+		config.EventWebhookEvents, _ = webhook.ParseEvents(eventNames)
+	}
+	appServer.SetConfig(config)
+	if data, err := json.Marshal(config.Redacted()); err == nil {
+		logrus.Infof("生效配置: %s", data)
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
 	actualAddr, err := appServer.Start(addr)
 	if err != nil {
 		logrus.Fatalf("failed to start server: %v", err)
 	}
-	if err := waitForHealth(actualAddr, 15*time.Second); err != nil {
+	if err := waitForHealth(appServer.APIAddress(), 15*time.Second); err != nil {
 		logrus.Fatalf("server health check failed: %v", err)
 	}
 
-	logrus.Infof("HTTP 服务监听地址: %s", actualAddr)
+	if apiAddr == "" {
+		logrus.Infof("HTTP 服务监听地址: %s", actualAddr)
+	} else {
+		logrus.Infof("MCP 服务监听地址: %s, HTTP API 监听地址: %s", actualAddr, appServer.APIAddress())
+		fmt.Printf("API_SERVER_ADDR=%s\n", appServer.APIAddress())
+	}
 	fmt.Printf("APP_SERVER_ADDR=%s\n", actualAddr)
 
-	if err := appServer.Wait(); err != nil {
+	err = appServer.Wait()
+	if n := emitter.Close(configs.EventShutdownTimeout); n > 0 {
+		logrus.Warnf("关闭服务时仍有 %d 个事件未投递完成，已写入死信文件 %s", n, configs.GetEventDeadLetterPath())
+	}
+	if err != nil {
 		logrus.Fatalf("server stopped with error: %v", err)
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 	"strings"
 	"time"
@@ -17,7 +19,7 @@ import (
 func (s *AppServer) handleCheckLoginStatus(ctx context.Context) *MCPToolResult {
 	logrus.Info("MCP: 检查登录状态")
 
-	status, err := s.xiaohongshuService.CheckLoginStatus(ctx)
+	status, err := s.platform.CheckLoginStatus(ctx)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -35,7 +37,7 @@ func (s *AppServer) handleCheckLoginStatus(ctx context.Context) *MCPToolResult {
 	} else {
 		resultText = fmt.Sprintf("❌ 未登录\n\n请使用 get_login_qrcode 工具获取二维码进行登录。")
 	}
-	
+
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -49,7 +51,7 @@ func (s *AppServer) handleCheckLoginStatus(ctx context.Context) *MCPToolResult {
 func (s *AppServer) handleGetLoginQrcode(ctx context.Context) *MCPToolResult {
 	logrus.Info("MCP: 获取登录扫码图片")
 
-	result, err := s.xiaohongshuService.GetLoginQrcode(ctx)
+	result, err := s.platform.GetLoginQrcode(ctx)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{Type: "text", Text: "获取登录扫码图片失败: " + err.Error()}},
@@ -88,7 +90,7 @@ func (s *AppServer) handleGetLoginQrcode(ctx context.Context) *MCPToolResult {
 func (s *AppServer) handleDeleteCookies(ctx context.Context) *MCPToolResult {
 	logrus.Info("MCP: 删除 cookies，重置登录状态")
 
-	err := s.xiaohongshuService.DeleteCookies(ctx)
+	err := s.platform.DeleteCookies(ctx)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{Type: "text", Text: "删除 cookies 失败: " + err.Error()}},
@@ -130,18 +132,38 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 		}
 	}
 
-	logrus.Infof("MCP: 发布内容 - 标题: %s, 图片数量: %d, 标签数量: %d", title, len(imagePaths), len(tags))
+	tokensInterface, _ := args["image_tokens"].([]interface{})
+	var imageTokens []string
+	for _, token := range tokensInterface {
+		if tokenStr, ok := token.(string); ok {
+			imageTokens = append(imageTokens, tokenStr)
+		}
+	}
+
+	normalizeImages, _ := args["normalize_images"].(bool)
+	visibility, _ := args["visibility"].(string)
+	poll, _ := args["poll"].(*xiaohongshu.Poll)
+	verify, _ := args["verify_after_publish"].(bool)
+	permissions, _ := args["permissions"].(xiaohongshu.PublishPermissions)
+
+	logrus.Infof("MCP: 发布内容 - 标题: %s, 图片数量: %d, 素材令牌数量: %d, 标签数量: %d", title, len(imagePaths), len(imageTokens), len(tags))
 
 	// 构建发布请求
 	req := &PublishRequest{
-		Title:   title,
-		Content: content,
-		Images:  imagePaths,
-		Tags:    tags,
+		Title:              title,
+		Content:            content,
+		Images:             imagePaths,
+		ImageTokens:        imageTokens,
+		Tags:               tags,
+		NormalizeImages:    normalizeImages,
+		Visibility:         visibility,
+		Poll:               poll,
+		VerifyAfterPublish: verify,
+		PublishPermissions: permissions,
 	}
 
 	// 执行发布
-	result, err := s.xiaohongshuService.PublishContent(ctx, req)
+	result, err := s.platform.PublishContent(ctx, req)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -152,6 +174,10 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 		}
 	}
 
+	if result.Verification != nil {
+		return jsonToolResult("内容发布", result)
+	}
+
 	resultText := fmt.Sprintf("内容发布成功: %+v", result)
 	return &MCPToolResult{
 		Content: []MCPContent{{
@@ -169,6 +195,8 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 	content, _ := args["content"].(string)
 	videoPath, _ := args["video"].(string)
 	tagsInterface, _ := args["tags"].([]interface{})
+	verify, _ := args["verify_after_publish"].(bool)
+	permissions, _ := args["permissions"].(xiaohongshu.PublishPermissions)
 
 	var tags []string
 	for _, tag := range tagsInterface {
@@ -191,14 +219,16 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 
 	// 构建发布请求
 	req := &PublishVideoRequest{
-		Title:   title,
-		Content: content,
-		Video:   videoPath,
-		Tags:    tags,
+		Title:              title,
+		Content:            content,
+		Video:              videoPath,
+		Tags:               tags,
+		VerifyAfterPublish: verify,
+		PublishPermissions: permissions,
 	}
 
 	// 执行发布
-	result, err := s.xiaohongshuService.PublishVideo(ctx, req)
+	result, err := s.platform.PublishVideo(ctx, req)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -209,6 +239,10 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 		}
 	}
 
+	if result.Verification != nil {
+		return jsonToolResult("视频发布", result)
+	}
+
 	resultText := fmt.Sprintf("视频发布成功: %+v", result)
 	return &MCPToolResult{
 		Content: []MCPContent{{
@@ -219,10 +253,13 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 }
 
 // handleListFeeds 处理获取Feeds列表
-func (s *AppServer) handleListFeeds(ctx context.Context) *MCPToolResult {
+func (s *AppServer) handleListFeeds(ctx context.Context, args ListFeedsArgs) *MCPToolResult {
 	logrus.Info("MCP: 获取Feeds列表")
 
-	result, err := s.xiaohongshuService.ListFeeds(ctx)
+	result, err := s.platform.ListFeeds(ctx, PaginateRequest{
+		AutoPaginate: args.AutoPaginate,
+		MaxItems:     args.MaxItems,
+	})
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -233,24 +270,7 @@ func (s *AppServer) handleListFeeds(ctx context.Context) *MCPToolResult {
 		}
 	}
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: fmt.Sprintf("获取Feeds列表成功，但序列化失败: %v", err),
-			}},
-			IsError: true,
-		}
-	}
-
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
-	}
+	return jsonToolResult("获取Feeds列表", result)
 }
 
 // handleSearchFeeds 处理搜索Feeds
@@ -278,35 +298,27 @@ func (s *AppServer) handleSearchFeeds(ctx context.Context, args SearchFeedsArgs)
 		Location:    args.Filters.Location,
 	}
 
-	result, err := s.xiaohongshuService.SearchFeeds(ctx, args.Keyword, filter)
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "搜索Feeds失败: " + err.Error(),
-			}},
-			IsError: true,
-		}
+	paginate := PaginateRequest{
+		AutoPaginate: args.AutoPaginate,
+		MaxItems:     args.MaxItems,
 	}
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err := xiaohongshu.ValidateFeedFields(args.Fields); err != nil {
+		return errorToolResult("搜索Feeds失败: " + err.Error())
+	}
+
+	result, err := s.platform.SearchFeeds(ctx, args.Keyword, paginate, resetFiltersOrDefault(args.ResetFilters), filter)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: fmt.Sprintf("搜索Feeds成功，但序列化失败: %v", err),
+				Text: "搜索Feeds失败: " + err.Error(),
 			}},
 			IsError: true,
 		}
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
-	}
+	return jsonToolResult("搜索Feeds", result.Project(args.Fields))
 }
 
 // handleGetFeedDetail 处理获取Feed详情
@@ -336,37 +348,30 @@ func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any
 		}
 	}
 
-	logrus.Infof("MCP: 获取Feed详情 - Feed ID: %s", feedID)
-
-	result, err := s.xiaohongshuService.GetFeedDetail(ctx, feedID, xsecToken)
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: "获取Feed详情失败: " + err.Error(),
-			}},
-			IsError: true,
+	fieldsInterface, _ := args["fields"].([]interface{})
+	var fields []string
+	for _, field := range fieldsInterface {
+		if fieldStr, ok := field.(string); ok {
+			fields = append(fields, fieldStr)
 		}
 	}
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	includeEngagement, _ := args["include_engagement"].(bool)
+
+	logrus.Infof("MCP: 获取Feed详情 - Feed ID: %s", feedID)
+
+	result, err := s.platform.GetFeedDetail(ctx, feedID, xsecToken, fields, includeEngagement)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: fmt.Sprintf("获取Feed详情成功，但序列化失败: %v", err),
+				Text: "获取Feed详情失败: " + err.Error(),
 			}},
 			IsError: true,
 		}
 	}
 
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
-	}
+	return jsonToolResult("获取Feed详情", result)
 }
 
 // handleUserProfile 获取用户主页
@@ -398,7 +403,7 @@ func (s *AppServer) handleUserProfile(ctx context.Context, args map[string]any)
 
 	logrus.Infof("MCP: 获取用户主页 - User ID: %s", userID)
 
-	result, err := s.xiaohongshuService.UserProfile(ctx, userID, xsecToken)
+	result, err := s.platform.UserProfile(ctx, userID, xsecToken)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -409,24 +414,7 @@ func (s *AppServer) handleUserProfile(ctx context.Context, args map[string]any)
 		}
 	}
 
-	// 格式化输出，转换为JSON字符串
-	jsonData, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return &MCPToolResult{
-			Content: []MCPContent{{
-				Type: "text",
-				Text: fmt.Sprintf("获取用户主页，但序列化失败: %v", err),
-			}},
-			IsError: true,
-		}
-	}
-
-	return &MCPToolResult{
-		Content: []MCPContent{{
-			Type: "text",
-			Text: string(jsonData),
-		}},
-	}
+	return jsonToolResult("获取用户主页", result)
 }
 
 // handleLikeFeed 处理点赞/取消点赞
@@ -445,9 +433,9 @@ func (s *AppServer) handleLikeFeed(ctx context.Context, args map[string]interfac
 	var err error
 
 	if unlike {
-		res, err = s.xiaohongshuService.UnlikeFeed(ctx, feedID, xsecToken)
+		res, err = s.platform.UnlikeFeed(ctx, feedID, xsecToken)
 	} else {
-		res, err = s.xiaohongshuService.LikeFeed(ctx, feedID, xsecToken)
+		res, err = s.platform.LikeFeed(ctx, feedID, xsecToken)
 	}
 
 	if err != nil {
@@ -481,9 +469,9 @@ func (s *AppServer) handleFavoriteFeed(ctx context.Context, args map[string]inte
 	var err error
 
 	if unfavorite {
-		res, err = s.xiaohongshuService.UnfavoriteFeed(ctx, feedID, xsecToken)
+		res, err = s.platform.UnfavoriteFeed(ctx, feedID, xsecToken)
 	} else {
-		res, err = s.xiaohongshuService.FavoriteFeed(ctx, feedID, xsecToken)
+		res, err = s.platform.FavoriteFeed(ctx, feedID, xsecToken)
 	}
 
 	if err != nil {
@@ -542,7 +530,7 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 	logrus.Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d", feedID, len(content))
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, feedID, xsecToken, content)
+	result, err := s.platform.PostCommentToFeed(ctx, feedID, xsecToken, content)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -562,3 +550,787 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 		}},
 	}
 }
+
+// jsonToolResult 将结果序列化为 JSON 文本，action 用于拼接序列化失败时的提示
+func jsonToolResult(action string, data any) *MCPToolResult {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("%s成功，但序列化失败: %v", action, err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+		StructuredContent: data,
+	}
+}
+
+// errorToolResult 构造错误结果
+func errorToolResult(text string) *MCPToolResult {
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: text,
+		}},
+		IsError: true,
+	}
+}
+
+// handleGetEditorConfig 获取发布编辑器默认配置
+func (s *AppServer) handleGetEditorConfig(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取发布编辑器配置")
+
+	result, err := s.platform.GetEditorConfig(ctx)
+	if err != nil {
+		return errorToolResult("获取发布编辑器配置失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取发布编辑器配置", result)
+}
+
+// handleGetEarnings 获取创作者收益信息
+func (s *AppServer) handleGetEarnings(ctx context.Context, args EarningsArgs) *MCPToolResult {
+	period := args.Period
+	if period == "" {
+		period = xiaohongshu.EarningsPeriod7d
+	}
+	logrus.Infof("MCP: 获取收益信息 - 周期: %s", period)
+
+	if err := xiaohongshu.ValidateEarningsPeriod(period); err != nil {
+		return errorToolResult("获取收益信息失败: " + err.Error())
+	}
+
+	result, err := s.platform.GetEarnings(ctx, period)
+	if err != nil {
+		return errorToolResult("获取收益信息失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取收益信息", result)
+}
+
+// handleGetViewHistory 获取浏览记录
+func (s *AppServer) handleGetViewHistory(ctx context.Context, args ViewHistoryArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取浏览记录 - cursor: %q", args.Cursor)
+
+	result, err := s.platform.GetViewHistory(ctx, args.Cursor)
+	if err != nil {
+		return errorToolResult("获取浏览记录失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取浏览记录", result)
+}
+
+// handleGetBlockedUsers 获取黑名单
+func (s *AppServer) handleGetBlockedUsers(ctx context.Context, args ModerationListArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取黑名单 - cursor: %q", args.Cursor)
+
+	result, err := s.platform.GetBlockedUsers(ctx, args.Cursor)
+	if err != nil {
+		return errorToolResult("获取黑名单失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取黑名单", result)
+}
+
+// handleGetMutedKeywords 获取屏蔽词
+func (s *AppServer) handleGetMutedKeywords(ctx context.Context, args ModerationListArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取屏蔽词 - cursor: %q", args.Cursor)
+
+	result, err := s.platform.GetMutedKeywords(ctx, args.Cursor)
+	if err != nil {
+		return errorToolResult("获取屏蔽词失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取屏蔽词", result)
+}
+
+// handleGetBestPostingTimes 获取推荐发布时间
+func (s *AppServer) handleGetBestPostingTimes(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取推荐发布时间")
+
+	result, err := s.platform.GetBestPostingTimes(ctx)
+	if err != nil {
+		return errorToolResult("获取推荐发布时间失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取推荐发布时间", result)
+}
+
+// handleGetAutoReply 获取私信自动回复设置
+func (s *AppServer) handleGetAutoReply(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取私信自动回复设置")
+
+	result, err := s.platform.GetAutoReply(ctx)
+	if err != nil {
+		return errorToolResult("获取自动回复设置失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取自动回复设置", result)
+}
+
+// handleSetAutoReply 修改私信自动回复设置
+func (s *AppServer) handleSetAutoReply(ctx context.Context, args SetAutoReplyArgs) *MCPToolResult {
+	logrus.Infof("MCP: 修改私信自动回复设置 - enabled: %v", args.Enabled)
+
+	if err := xiaohongshu.ValidateAutoReply(args.Text, args.Enabled); err != nil {
+		return errorToolResult("修改自动回复设置失败: " + err.Error())
+	}
+
+	result, err := s.platform.SetAutoReply(ctx, args.Text, args.Enabled)
+	if err != nil {
+		return errorToolResult("修改自动回复设置失败: " + err.Error())
+	}
+
+	return jsonToolResult("修改自动回复设置", result)
+}
+
+// handleGetMyProfile 获取当前账号资料
+func (s *AppServer) handleGetMyProfile(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取当前账号资料")
+
+	result, err := s.platform.GetMyProfile(ctx)
+	if err != nil {
+		return errorToolResult("获取我的主页失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取我的主页", result)
+}
+
+// handleGetAccountRegion 获取当前账号的地区与语言
+func (s *AppServer) handleGetAccountRegion(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取账号地区与语言")
+
+	result, err := s.platform.GetAccountRegion(ctx)
+	if err != nil {
+		return errorToolResult("获取账号地区失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取账号地区", result)
+}
+
+// handleGetAccountAggregateStats 汇总当前账号全部笔记的互动数据
+func (s *AppServer) handleGetAccountAggregateStats(ctx context.Context, args AccountAggregateStatsArgs, progress xiaohongshu.ProgressFunc) *MCPToolResult {
+	logrus.Infof("MCP: 账号汇总统计 - max_notes: %d, refresh: %v", args.MaxNotes, args.Refresh)
+
+	if args.MaxNotes < 0 {
+		return errorToolResult("账号汇总统计失败: max_notes 不能为负数")
+	}
+
+	result, err := s.platform.GetAccountAggregateStats(ctx, args.MaxNotes, args.Refresh, progress)
+	if err != nil {
+		return errorToolResult("账号汇总统计失败: " + err.Error())
+	}
+
+	return jsonToolResult("账号汇总统计", result)
+}
+
+// handleUpdateProfile 修改当前账号资料
+func (s *AppServer) handleUpdateProfile(ctx context.Context, args UpdateProfileArgs) *MCPToolResult {
+	logrus.Info("MCP: 修改当前账号资料")
+
+	update := xiaohongshu.ProfileUpdate{Nickname: args.Nickname, Bio: args.Bio, AvatarPath: args.AvatarPath}
+	if err := xiaohongshu.ValidateProfileUpdate(update); err != nil {
+		return errorToolResult("修改资料失败: " + err.Error())
+	}
+
+	result, err := s.platform.UpdateProfile(ctx, update)
+	if err != nil {
+		return errorToolResult("修改资料失败: " + err.Error())
+	}
+
+	return jsonToolResult("修改资料", result)
+}
+
+// handleGetNotificationSettings 获取通知设置
+func (s *AppServer) handleGetNotificationSettings(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取通知设置")
+
+	result, err := s.platform.GetNotificationSettings(ctx)
+	if err != nil {
+		return errorToolResult("获取通知设置失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取通知设置", result)
+}
+
+// handleSetNotificationSettings 修改通知设置
+func (s *AppServer) handleSetNotificationSettings(ctx context.Context, args SetNotificationSettingsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 修改通知设置 - %v", args.Categories)
+
+	if err := xiaohongshu.ValidateNotificationSettings(args.Categories); err != nil {
+		return errorToolResult("修改通知设置失败: " + err.Error())
+	}
+
+	result, err := s.platform.SetNotificationSettings(ctx, args.Categories)
+	if err != nil {
+		return errorToolResult("修改通知设置失败: " + err.Error())
+	}
+
+	return jsonToolResult("修改通知设置", result)
+}
+
+// handleTestProxy 检测代理是否可以访问小红书
+func (s *AppServer) handleTestProxy(ctx context.Context, args TestProxyArgs) *MCPToolResult {
+	if args.ProxyURL == "" {
+		return errorToolResult("代理检测失败: 缺少proxy_url参数")
+	}
+
+	result, err := s.platform.TestProxy(ctx, args.ProxyURL)
+	if err != nil {
+		return errorToolResult("代理检测失败: " + err.Error())
+	}
+
+	return jsonToolResult("代理检测", result)
+}
+
+// draftSystemPrompt 起草时发送给客户端 LLM 的系统提示词
+const draftSystemPrompt = `你是小红书内容创作助手。根据用户要求起草或改写一篇图文笔记。
+要求：标题不超过20个中文字；正文不包含以#开头的话题标签；话题标签单独放在 tags 中，不超过10个。
+只输出 JSON，格式为：{"title": "...", "content": "...", "tags": ["...", "..."]}`
+
+// DraftAssistResponse AI 辅助起草结果
+type DraftAssistResponse struct {
+	Model   string          `json:"model,omitempty"`
+	Preview *PublishPreview `json:"preview"`
+}
+
+// handleDraftAssist 通过 sampling 请求客户端 LLM 起草内容，并生成发布预览
+func (s *AppServer) handleDraftAssist(ctx context.Context, session *mcp.ServerSession, args DraftAssistArgs) *MCPToolResult {
+	logrus.Info("MCP: AI 辅助起草")
+
+	if strings.TrimSpace(args.Instruction) == "" {
+		return errorToolResult("AI 辅助起草失败: 缺少instruction参数")
+	}
+
+	if session == nil {
+		return errorToolResult("AI 辅助起草失败: 当前会话不可用")
+	}
+	if params := session.InitializeParams(); params == nil || params.Capabilities == nil || params.Capabilities.Sampling == nil {
+		return errorToolResult("AI 辅助起草失败: 客户端未声明 sampling 能力")
+	}
+
+	maxTokens := args.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1024
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString("要求: " + args.Instruction + "\n")
+	if args.Title != "" {
+		prompt.WriteString("已有标题: " + args.Title + "\n")
+	}
+	if args.Content != "" {
+		prompt.WriteString("已有正文: " + args.Content + "\n")
+	}
+	if len(args.Tags) > 0 {
+		prompt.WriteString("已有标签: " + strings.Join(args.Tags, ", ") + "\n")
+	}
+
+	result, err := session.CreateMessage(ctx, &mcp.CreateMessageParams{
+		SystemPrompt: draftSystemPrompt,
+		MaxTokens:    maxTokens,
+		Messages: []*mcp.SamplingMessage{{
+			Role:    "user",
+			Content: &mcp.TextContent{Text: prompt.String()},
+		}},
+	})
+	if err != nil {
+		return errorToolResult("AI 辅助起草失败: " + err.Error())
+	}
+
+	text, ok := result.Content.(*mcp.TextContent)
+	if !ok || strings.TrimSpace(text.Text) == "" {
+		return errorToolResult("AI 辅助起草失败: 客户端未返回文本内容")
+	}
+
+	title, content, tags := parseDraft(text.Text)
+	if title == "" {
+		title = args.Title
+	}
+	if len(tags) == 0 {
+		tags = args.Tags
+	}
+
+	return jsonToolResult("AI 辅助起草", &DraftAssistResponse{
+		Model:   result.Model,
+		Preview: s.platform.PreviewPublish(title, content, tags),
+	})
+}
+
+// parseDraft 解析 LLM 返回的 JSON 草稿；无法解析时将全文作为正文
+func parseDraft(text string) (title, content string, tags []string) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start >= 0 && end > start {
+		var draft struct {
+			Title   string   `json:"title"`
+			Content string   `json:"content"`
+			Tags    []string `json:"tags"`
+		}
+		if err := json.Unmarshal([]byte(text[start:end+1]), &draft); err == nil && draft.Content != "" {
+			return strings.TrimSpace(draft.Title), strings.TrimSpace(draft.Content), draft.Tags
+		}
+	}
+
+	return "", strings.TrimSpace(text), nil
+}
+
+// handleGetNoteType 批量检测笔记类型
+func (s *AppServer) handleGetNoteType(ctx context.Context, args NoteTypeArgs) *MCPToolResult {
+	logrus.Infof("MCP: 检测笔记类型 - 数量: %d", len(args.Notes))
+
+	for _, note := range args.Notes {
+		if note.FeedID == "" || note.XsecToken == "" {
+			return errorToolResult("检测笔记类型失败: 每篇笔记都需要feed_id和xsec_token参数")
+		}
+	}
+
+	result, err := s.platform.GetNoteTypes(ctx, args.Notes)
+	if err != nil {
+		return errorToolResult("检测笔记类型失败: " + err.Error())
+	}
+
+	return jsonToolResult("检测笔记类型", result)
+}
+
+// handleCheckMutual 批量检查关注关系
+func (s *AppServer) handleCheckMutual(ctx context.Context, args CheckMutualArgs) *MCPToolResult {
+	logrus.Infof("MCP: 检查关注关系 - 数量: %d", len(args.Users))
+
+	if err := xiaohongshu.ValidateUserRefs(args.Users); err != nil {
+		return errorToolResult("检查关注关系失败: " + err.Error())
+	}
+
+	result, err := s.platform.CheckMutual(ctx, args.Users)
+	if err != nil {
+		return errorToolResult("检查关注关系失败: " + err.Error())
+	}
+
+	return jsonToolResult("检查关注关系", result)
+}
+
+// handleGetNoteComments 获取笔记评论
+func (s *AppServer) handleGetNoteComments(ctx context.Context, args NoteCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取笔记评论 - Feed ID: %s, 排序: %s, 深度: %d", args.FeedID, args.Sort, args.MaxDepth)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取笔记评论失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取笔记评论失败: 缺少xsec_token参数")
+	}
+	if err := xiaohongshu.ValidateCommentSort(args.Sort); err != nil {
+		return errorToolResult("获取笔记评论失败: " + err.Error())
+	}
+	if err := xiaohongshu.ValidateCommentDepth(args.MaxDepth); err != nil {
+		return errorToolResult("获取笔记评论失败: " + err.Error())
+	}
+
+	result, err := s.platform.GetNoteComments(ctx, args.FeedID, args.XsecToken, args.Sort, args.MaxDepth)
+	if err != nil {
+		return errorToolResult("获取笔记评论失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取笔记评论", result)
+}
+
+// handleGetVideoComments 获取视频弹幕
+func (s *AppServer) handleGetVideoComments(ctx context.Context, args VideoCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取视频弹幕 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取视频弹幕失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取视频弹幕失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetVideoComments(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("获取视频弹幕失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取视频弹幕", result)
+}
+
+// handleGetNoteCollaborators 获取笔记合作信息
+func (s *AppServer) handleGetNoteCollaborators(ctx context.Context, args NoteCollaboratorsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取笔记合作信息 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取笔记合作信息失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取笔记合作信息失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetNoteCollaborators(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("获取笔记合作信息失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取笔记合作信息", result)
+}
+
+// handleGetNoteReposts 获取复用了相同图片/内容的笔记
+func (s *AppServer) handleGetNoteReposts(ctx context.Context, args NoteRepostsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取相同图片笔记 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取相同图片笔记失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取相同图片笔记失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetNoteReposts(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("获取相同图片笔记失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取相同图片笔记", result)
+}
+
+// handleGetVideoCover 获取视频笔记封面
+func (s *AppServer) handleGetVideoCover(ctx context.Context, args VideoCoverArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取视频封面 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取视频封面失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取视频封面失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetVideoCover(ctx, args.FeedID, args.XsecToken, args.Download)
+	if err != nil {
+		return errorToolResult("获取视频封面失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取视频封面", result)
+}
+
+// handleGetNoteMusic 获取笔记背景音乐
+func (s *AppServer) handleGetNoteMusic(ctx context.Context, args NoteMusicArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取笔记音乐 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取笔记音乐失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取笔记音乐失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetNoteMusic(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("获取笔记音乐失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取笔记音乐", result)
+}
+
+// handleGetNoteThread 获取笔记与完整评论树
+func (s *AppServer) handleGetNoteThread(ctx context.Context, args NoteThreadArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取笔记评论串 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取笔记评论串失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取笔记评论串失败: 缺少xsec_token参数")
+	}
+	if err := xiaohongshu.ValidateThreadComments(args.MaxComments); err != nil {
+		return errorToolResult("获取笔记评论串失败: " + err.Error())
+	}
+
+	result, err := s.platform.GetNoteThread(ctx, args.FeedID, args.XsecToken, args.MaxComments)
+	if err != nil {
+		return errorToolResult("获取笔记评论串失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取笔记评论串", result)
+}
+
+// handleArchiveNoteScreenshot 截取笔记长图归档
+func (s *AppServer) handleArchiveNoteScreenshot(ctx context.Context, args NoteScreenshotArgs) *MCPToolResult {
+	logrus.Infof("MCP: 笔记长截图 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("笔记长截图失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("笔记长截图失败: 缺少xsec_token参数")
+	}
+	if err := xiaohongshu.ValidateThreadComments(args.MaxComments); err != nil {
+		return errorToolResult("笔记长截图失败: " + err.Error())
+	}
+
+	result, err := s.platform.ArchiveNoteScreenshot(ctx, args.FeedID, args.XsecToken, args.MaxComments)
+	if err != nil {
+		return errorToolResult("笔记长截图失败: " + err.Error())
+	}
+
+	return jsonToolResult("笔记长截图", result)
+}
+
+// handleCheckNoteDiscoverability 检测笔记能否被其他人搜到
+func (s *AppServer) handleCheckNoteDiscoverability(ctx context.Context, args NoteDiscoverabilityArgs) *MCPToolResult {
+	logrus.Infof("MCP: 检测笔记可发现性 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("检测笔记可发现性失败: 缺少feed_id参数")
+	}
+	keywords, err := xiaohongshu.NormalizeDiscoverabilityKeywords(args.Keywords)
+	if err != nil {
+		return errorToolResult("检测笔记可发现性失败: " + err.Error())
+	}
+	if len(keywords) == 0 && args.XsecToken == "" {
+		return errorToolResult("检测笔记可发现性失败: 未指定keywords时需要xsec_token参数")
+	}
+
+	result, err := s.platform.CheckNoteDiscoverability(ctx, args.FeedID, args.XsecToken, keywords)
+	if err != nil {
+		return errorToolResult("检测笔记可发现性失败: " + err.Error())
+	}
+
+	return jsonToolResult("检测笔记可发现性", result)
+}
+
+// handleGetSearchFilters 读取搜索筛选状态
+func (s *AppServer) handleGetSearchFilters(ctx context.Context, args SearchFiltersArgs) *MCPToolResult {
+	logrus.Infof("MCP: 读取搜索筛选 - %s", args.Keyword)
+
+	keyword := strings.TrimSpace(args.Keyword)
+	if keyword == "" {
+		return errorToolResult("获取搜索筛选失败: 缺少keyword参数")
+	}
+
+	result, err := s.platform.GetSearchFilters(ctx, keyword)
+	if err != nil {
+		return errorToolResult("获取搜索筛选失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取搜索筛选", result)
+}
+
+// handleCheckKeyword 检测关键词是否被限制搜索
+func (s *AppServer) handleCheckKeyword(ctx context.Context, args CheckKeywordArgs) *MCPToolResult {
+	logrus.Infof("MCP: 检测关键词 - %s", args.Keyword)
+
+	keyword := strings.TrimSpace(args.Keyword)
+	if keyword == "" {
+		return errorToolResult("检测关键词失败: 缺少keyword参数")
+	}
+
+	result, err := s.platform.CheckKeyword(ctx, keyword)
+	if err != nil {
+		return errorToolResult("检测关键词失败: " + err.Error())
+	}
+
+	return jsonToolResult("检测关键词", result)
+}
+
+// handleGetMyComments 列出当前账号在笔记下的评论
+func (s *AppServer) handleGetMyComments(ctx context.Context, args MyCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取我的评论 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取我的评论失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取我的评论失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetMyComments(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("获取我的评论失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取我的评论", result)
+}
+
+// handleDeleteComment 删除当前账号的一条评论
+func (s *AppServer) handleDeleteComment(ctx context.Context, args DeleteCommentArgs) *MCPToolResult {
+	logrus.Infof("MCP: 删除评论 - Feed ID: %s, Comment ID: %s", args.FeedID, args.CommentID)
+
+	ref := xiaohongshu.CommentRef{FeedID: args.FeedID, XsecToken: args.XsecToken, CommentID: args.CommentID}
+	if err := xiaohongshu.ValidateCommentRefs([]xiaohongshu.CommentRef{ref}); err != nil {
+		return errorToolResult("删除评论失败: " + err.Error())
+	}
+
+	result, err := s.platform.DeleteComment(ctx, ref)
+	if err != nil {
+		return errorToolResult("删除评论失败: " + err.Error())
+	}
+
+	return jsonToolResult("删除评论", result)
+}
+
+// handleBatchDeleteComments 批量删除当前账号的评论
+func (s *AppServer) handleBatchDeleteComments(ctx context.Context, args BatchDeleteCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 批量删除评论 - 数量: %d", len(args.Comments))
+
+	if err := xiaohongshu.ValidateCommentRefs(args.Comments); err != nil {
+		return errorToolResult("批量删除评论失败: " + err.Error())
+	}
+
+	result, err := s.platform.BatchDeleteComments(ctx, args.Comments)
+	if err != nil {
+		return errorToolResult("批量删除评论失败: " + err.Error())
+	}
+
+	return jsonToolResult("批量删除评论", result)
+}
+
+// handleGetNoteTaggedUsers 获取笔记中标记的用户
+func (s *AppServer) handleGetNoteTaggedUsers(ctx context.Context, args NoteTaggedUsersArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取笔记标记用户 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("获取笔记标记用户失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("获取笔记标记用户失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetNoteTaggedUsers(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("获取笔记标记用户失败: " + err.Error())
+	}
+
+	return jsonToolResult("获取笔记标记用户", result)
+}
+
+// handleIsMyNote 检查笔记是否属于当前账号
+func (s *AppServer) handleIsMyNote(ctx context.Context, args IsMyNoteArgs) *MCPToolResult {
+	logrus.Infof("MCP: 检查笔记归属 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("检查笔记归属失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("检查笔记归属失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.IsMyNote(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("检查笔记归属失败: " + err.Error())
+	}
+
+	return jsonToolResult("检查笔记归属", result)
+}
+
+// handleGetNoteSettings 读取笔记的评论与互动权限
+func (s *AppServer) handleGetNoteSettings(ctx context.Context, args NoteSettingsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 读取笔记互动权限 - Feed ID: %s", args.FeedID)
+
+	if args.FeedID == "" {
+		return errorToolResult("读取笔记互动权限失败: 缺少feed_id参数")
+	}
+	if args.XsecToken == "" {
+		return errorToolResult("读取笔记互动权限失败: 缺少xsec_token参数")
+	}
+
+	result, err := s.platform.GetNoteSettings(ctx, args.FeedID, args.XsecToken)
+	if err != nil {
+		return errorToolResult("读取笔记互动权限失败: " + err.Error())
+	}
+
+	return jsonToolResult("读取笔记互动权限", result)
+}
+
+// handleContinueResult 取回截断结果的剩余部分，仍过长时由 withPanicRecovery 再次截断
+func (s *AppServer) handleContinueResult(args ContinueResultArgs) *MCPToolResult {
+	if args.Token == "" {
+		return errorToolResult("获取剩余结果失败: 缺少token参数")
+	}
+
+	text, err := resultBuffers.Take(args.Token)
+	if err != nil {
+		return errorToolResult("获取剩余结果失败: " + err.Error())
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: text,
+		}},
+	}
+}
+
+// handleUploadImages 预上传图片
+func (s *AppServer) handleUploadImages(ctx context.Context, args UploadImagesArgs) *MCPToolResult {
+	logrus.Infof("MCP: 预上传图片 - 数量: %d", len(args.Images))
+
+	if len(args.Images) == 0 {
+		return errorToolResult("预上传图片失败: 缺少images参数")
+	}
+
+	result, err := s.platform.UploadImages(ctx, args.Images)
+	if err != nil {
+		return errorToolResult("预上传图片失败: " + err.Error())
+	}
+
+	return jsonToolResult("预上传图片", result)
+}
+
+// handleSavePublishTemplate 保存发布模板
+func (s *AppServer) handleSavePublishTemplate(ctx context.Context, args SavePublishTemplateArgs) *MCPToolResult {
+	logrus.Infof("MCP: 保存发布模板 - 名称: %s", args.Name)
+
+	result, err := s.platform.SavePublishTemplate(args.Name, args.Spec.toSpec())
+	if err != nil {
+		return errorToolResult("保存发布模板失败: " + err.Error())
+	}
+
+	return jsonToolResult("保存发布模板", result)
+}
+
+// handlePublishFromTemplate 按模板发布
+func (s *AppServer) handlePublishFromTemplate(ctx context.Context, args PublishFromTemplateArgs) *MCPToolResult {
+	logrus.Infof("MCP: 按模板发布 - 名称: %s", args.Name)
+
+	overrides := templates.Overrides{
+		Spec: args.Overrides.toSpec(),
+		Vars: args.Overrides.Vars,
+	}
+
+	result, err := s.platform.PublishFromTemplate(ctx, args.Name, overrides)
+	if err != nil {
+		return errorToolResult("按模板发布失败: " + err.Error())
+	}
+
+	return jsonToolResult("按模板发布", result)
+}
+
+// handleGetEnums 获取所有枚举字段的取值
+func (s *AppServer) handleGetEnums(ctx context.Context) *MCPToolResult {
+	return jsonToolResult("获取枚举取值", enumsResponse())
+}
+
+// handleGetConfig 获取生效配置（已脱敏）
+func (s *AppServer) handleGetConfig(ctx context.Context) *MCPToolResult {
+	return jsonToolResult("获取生效配置", s.config.Redacted())
+}
+
+// handlePlanCrawl 估算抓取计划
+func (s *AppServer) handlePlanCrawl(ctx context.Context, args PlanCrawlArgs) *MCPToolResult {
+	plan, err := xiaohongshu.PlanCrawl(strings.TrimSpace(args.Scope), args.MaxItems, args.IncludeDetails)
+	if err != nil {
+		return errorToolResult("估算抓取计划失败: " + err.Error())
+	}
+	return jsonToolResult("估算抓取计划", plan)
+}
+
+// handleGetServerState 获取服务运行状态
+func (s *AppServer) handleGetServerState(ctx context.Context) *MCPToolResult {
+	return jsonToolResult("获取服务运行状态", serverState.snapshot())
+}
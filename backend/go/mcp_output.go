@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/outputschema"
+)
+
+// OutputShapeErrorCode 工具返回的数据不符合输出 Schema 时的错误码，通常说明小红书页面结构已变化
+const OutputShapeErrorCode = "output_shape_error"
+
+// toolOutputSchemas 各工具的输出 Schema，注册工具时填充，之后只读
+var toolOutputSchemas = map[string]*outputschema.Schema{}
+
+// outputSchema 记录工具的输出 Schema，返回值用于 mcp.Tool.OutputSchema，供客户端了解返回结构。
+// 结果过长被截断时返回 TruncatedOutput，因此声明的 Schema 同时包含这一结构。
+func outputSchema(tool string, s *outputschema.Schema) *jsonschema.Schema {
+	toolOutputSchemas[tool] = s
+	return outputschema.AnyOf(s, truncatedOutputSchema).JSONSchema()
+}
+
+// validateToolOutput 按工具的输出 Schema 校验结构化结果，不符合时替换为 output_shape_error 错误结果
+func validateToolOutput(tool string, result *mcp.CallToolResult) *mcp.CallToolResult {
+	schema, ok := toolOutputSchemas[tool]
+	if !ok || result == nil || result.IsError || result.StructuredContent == nil {
+		return result
+	}
+
+	if err := schema.Validate(result.StructuredContent); err != nil {
+		logrus.WithField("tool", tool).Errorf("%s: %v", OutputShapeErrorCode, err)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("%s: 工具 %s 返回的数据不符合输出 schema，可能是小红书页面结构已变化: %v",
+						OutputShapeErrorCode, tool, err),
+				},
+			},
+			IsError: true,
+		}
+	}
+
+	return result
+}
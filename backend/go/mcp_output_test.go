@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/outputschema"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+func TestFeedDetailOutputSchema(t *testing.T) {
+	schema := outputschema.AnyOf(outputschema.MustFor[FullFeedDetailOutput](), outputschema.MustFor[ProjectedFeedDetailOutput]())
+	detail := &xiaohongshu.FeedDetailResponse{
+		Note:   xiaohongshu.FeedDetail{NoteID: "abc", Title: "周末去哪儿"},
+		Source: xiaohongshu.DetailSourceState,
+	}
+
+	full := &FeedDetailResponse{FeedID: "abc", Data: detail}
+	if err := schema.Validate(full); err != nil {
+		t.Errorf("full detail rejected: %v", err)
+	}
+
+	projected := &FeedDetailResponse{FeedID: "abc", Data: xiaohongshu.ProjectFeedDetail(detail, []string{"title", "likes"})}
+	if err := schema.Validate(projected); err != nil {
+		t.Errorf("projected detail rejected: %v", err)
+	}
+
+	for name, data := range map[string]any{
+		"缺少笔记":   map[string]any{"comments": map[string]any{"list": []any{}}, "source": "state"},
+		"未知字段":   map[string]any{"title": "a", "unknown": 1},
+		"字段类型错误": map[string]any{"likes": 12},
+		"不是对象":   "abc",
+	} {
+		if err := schema.Validate(&FeedDetailResponse{FeedID: "abc", Data: data}); err == nil {
+			t.Errorf("%s: invalid data accepted", name)
+		}
+	}
+}
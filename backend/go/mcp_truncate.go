@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/outputschema"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/resultbuffer"
+)
+
+// maxResponseBytesMetaKey 客户端可在 tools/call 的 _meta 中指定本次调用的最大字节数，覆盖 -max-response-bytes
+const maxResponseBytesMetaKey = "max_response_bytes"
+
+// resultBuffers 截断结果的剩余部分，由 continue_result 取回
+var resultBuffers = resultbuffer.NewStore(configs.ResultBufferTTL)
+
+// responseLimit 返回本次调用的最大字节数，0 表示不截断
+func responseLimit(req *mcp.CallToolRequest) int {
+	limit := configs.GetMaxResponseBytes()
+	if req == nil || req.Params == nil {
+		return limit
+	}
+
+	// JSON 数字解码为 float64
+	if v, ok := req.Params.GetMeta()[maxResponseBytesMetaKey].(float64); ok && v >= 0 {
+		limit = int(v)
+	}
+	return limit
+}
+
+// TruncatedOutput 结果被截断时代替原结构化内容返回，只保留原结果顶层的分页与数量字段，
+// 完整结果需凭 continuation_token 调用 continue_result 取回
+type TruncatedOutput struct {
+	Truncated         bool           `json:"truncated"`
+	ContinuationToken string         `json:"continuation_token"`
+	RemainingBytes    int            `json:"remaining_bytes"`
+	Pagination        map[string]any `json:"pagination"`
+}
+
+// truncatedOutputSchema 声明了输出 Schema 的工具都可能返回该结构
+var truncatedOutputSchema = outputschema.MustFor[TruncatedOutput]()
+
+// truncateToolOutput 结果文本超过 maxBytes 时截断，使序列化后的整个结果不超过 maxBytes 字节（至少保留一个字符），
+// 剩余部分暂存并附上续取令牌。声明了输出 Schema 的工具按 MCP 规范必须返回结构化内容，
+// 截断后改为返回 TruncatedOutput 摘要；其他工具不再携带。错误结果和含图片的结果不截断。
+func truncateToolOutput(tool string, result *mcp.CallToolResult, maxBytes int) *mcp.CallToolResult {
+	if maxBytes <= 0 || result == nil || result.IsError {
+		return result
+	}
+
+	texts := make([]string, 0, len(result.Content))
+	for _, c := range result.Content {
+		text, ok := c.(*mcp.TextContent)
+		if !ok {
+			return result
+		}
+		texts = append(texts, text.Text)
+	}
+
+	text := strings.Join(texts, "\n")
+	if len(text) <= maxBytes {
+		return result
+	}
+
+	var pagination map[string]any
+	_, hasSchema := toolOutputSchemas[tool]
+	if hasSchema {
+		pagination = paginationMeta(result.StructuredContent)
+	}
+	build := func(head, token string, expiresAt time.Time, remaining int) *mcp.CallToolResult {
+		truncated := &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: head},
+				&mcp.TextContent{
+					Text: fmt.Sprintf("[结果已截断，剩余 %d 字节。调用 continue_result 并传入 token=%s 获取后续内容，令牌在 %s 前有效]",
+						remaining, token, expiresAt.Format(time.RFC3339)),
+				},
+			},
+			Meta: mcp.Meta{
+				"truncated":          true,
+				"continuation_token": token,
+				"remaining_bytes":    remaining,
+			},
+		}
+		if hasSchema {
+			truncated.StructuredContent = &TruncatedOutput{
+				Truncated:         true,
+				ContinuationToken: token,
+				RemainingBytes:    remaining,
+				Pagination:        pagination,
+			}
+		}
+		return truncated
+	}
+
+	// 先用同样长度的占位令牌计算提示和元数据占用的字节数，剩余的留给头部
+	placeholder := strings.Repeat("0", resultbuffer.TokenLength)
+	overhead := marshalledSize(build("", placeholder, time.Now().Add(configs.ResultBufferTTL), len(text)))
+	head, rest := resultbuffer.SplitEscaped(text, maxBytes-overhead)
+
+	token, expiresAt := resultBuffers.Put(rest)
+	return build(head, token, expiresAt, len(rest))
+}
+
+// marshalledSize 结果序列化为 JSON 后的字节数
+func marshalledSize(result *mcp.CallToolResult) int {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/outputschema"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+func largeFeedsResult(t *testing.T, n int) *mcp.CallToolResult {
+	t.Helper()
+
+	data := &FeedsListResponse{Count: n, ScrolledItems: n, ReturnedItems: n}
+	for i := 0; i < n; i++ {
+		data.Feeds = append(data.Feeds, xiaohongshu.Feed{
+			ID:        fmt.Sprintf("feed%04d", i),
+			XsecToken: "token",
+			NoteCard:  xiaohongshu.NoteCard{DisplayTitle: fmt.Sprintf("第 %d 篇 \"笔记\" <标题>", i)},
+		})
+	}
+	text, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: string(text)}},
+		StructuredContent: data,
+	}
+}
+
+func TestTruncateToolOutputStaysUnderLimit(t *testing.T) {
+	const tool = "test_list_feeds"
+	schema := outputschema.MustFor[FeedsListResponse]()
+	toolOutputSchemas[tool] = schema
+	defer delete(toolOutputSchemas, tool)
+
+	for _, limit := range []int{2048, 8192, 32768} {
+		t.Run(fmt.Sprint(limit), func(t *testing.T) {
+			result := largeFeedsResult(t, 500)
+			original := result.Content[0].(*mcp.TextContent).Text
+
+			truncated := truncateToolOutput(tool, result, limit)
+			raw, err := json.Marshal(truncated)
+			if err != nil {
+				t.Fatalf("marshal truncated: %v", err)
+			}
+			if len(raw) > limit {
+				t.Errorf("marshalled result is %d bytes, limit %d", len(raw), limit)
+			}
+
+			out, ok := truncated.StructuredContent.(*TruncatedOutput)
+			if !ok {
+				t.Fatalf("structured content = %T, want *TruncatedOutput", truncated.StructuredContent)
+			}
+			if out.Pagination["count"] != float64(500) || out.Pagination["returned_items"] != float64(500) {
+				t.Errorf("pagination = %v", out.Pagination)
+			}
+			if err := outputschema.AnyOf(schema, truncatedOutputSchema).Validate(out); err != nil {
+				t.Errorf("truncated output does not match declared schema: %v", err)
+			}
+
+			head := truncated.Content[0].(*mcp.TextContent).Text
+			rest, err := resultBuffers.Take(out.ContinuationToken)
+			if err != nil {
+				t.Fatalf("take rest: %v", err)
+			}
+			if head+rest != original || len(rest) != out.RemainingBytes {
+				t.Errorf("head and rest do not add up to the original text")
+			}
+		})
+	}
+}
+
+func TestTruncateToolOutputWithoutSchema(t *testing.T) {
+	result := largeFeedsResult(t, 50)
+
+	truncated := truncateToolOutput("test_no_schema", result, 1024)
+	if truncated.StructuredContent != nil {
+		t.Errorf("structured content kept for a tool without schema: %T", truncated.StructuredContent)
+	}
+
+	// 限制小于提示本身时也至少返回一个字符，续取总能向前推进
+	tiny := truncateToolOutput("test_no_schema", largeFeedsResult(t, 1), 10)
+	if head := tiny.Content[0].(*mcp.TextContent).Text; head == "" {
+		t.Error("empty head")
+	}
+
+	small := &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}
+	if truncateToolOutput("test_no_schema", small, 1024) != small {
+		t.Error("short result was truncated")
+	}
+}
@@ -0,0 +1,82 @@
+package downloader
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Result 单个文件的下载结果
+type Result struct {
+	URL      string `json:"url"`
+	Path     string `json:"path,omitempty"` // 成功时的本地文件路径
+	Attempts int    `json:"attempts"`       // 实际请求次数，含重试
+	Error    string `json:"error,omitempty"`
+}
+
+// transientError 可重试的下载错误：网络错误、读取中断、408/429/5xx
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+func transient(err error) error {
+	return &transientError{err: err}
+}
+
+func isTransient(err error) bool {
+	var t *transientError
+	return errors.As(err, &t)
+}
+
+func isTransientStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+// DownloadAll 按并发上限下载全部 URL，返回与输入顺序一致的逐个结果；单个文件失败不影响其他文件
+func (d *ImageDownloader) DownloadAll(urls []string) []Result {
+	results := make([]Result, len(urls))
+
+	concurrency := max(d.concurrency, 1)
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, u string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			path, attempts, err := d.downloadWithRetry(u)
+			results[i] = Result{URL: u, Path: path, Attempts: attempts}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, u)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// downloadWithRetry 下载单个文件，瞬时错误按指数退避重试，返回实际请求次数
+func (d *ImageDownloader) downloadWithRetry(u string) (string, int, error) {
+	backoff := d.retryBackoff
+	for attempt := 1; ; attempt++ {
+		path, err := d.downloadOnce(u)
+		if err == nil || !isTransient(err) || attempt > d.retries {
+			return path, attempt, err
+		}
+
+		logrus.Warnf("下载 %s 失败（第 %d 次），%s 后重试: %v", u, attempt, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,93 @@
+package downloader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 最小的 PNG 文件头，足以被识别为图片
+var pngData = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0, 0, 0, 0x0d, 'I', 'H', 'D', 'R'}
+
+func newTestDownloader(t *testing.T, concurrency, retries int) *ImageDownloader {
+	d := NewImageDownloader(t.TempDir())
+	d.concurrency = concurrency
+	d.retries = retries
+	d.retryBackoff = time.Millisecond
+	return d
+}
+
+func TestDownloadAllRetriesTransientErrors(t *testing.T) {
+	var flaky atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky.png":
+			if flaky.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write(pngData)
+		case "/ok.png":
+			w.Write(pngData)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestDownloader(t, 2, 2)
+	results := d.DownloadAll([]string{srv.URL + "/ok.png", srv.URL + "/flaky.png", srv.URL + "/missing.png"})
+
+	if r := results[0]; r.Error != "" || r.Path == "" || r.Attempts != 1 {
+		t.Errorf("ok.png = %+v", r)
+	}
+	if r := results[1]; r.Error != "" || r.Attempts != 2 {
+		t.Errorf("flaky.png = %+v, want success after 2 attempts", r)
+	}
+	// 404 不是瞬时错误，不重试
+	if r := results[2]; r.Error == "" || r.Attempts != 1 {
+		t.Errorf("missing.png = %+v, want failure after 1 attempt", r)
+	}
+
+	paths, err := d.DownloadImages([]string{srv.URL + "/ok.png", srv.URL + "/missing.png"})
+	if err == nil || len(paths) != 1 {
+		t.Errorf("DownloadImages = %v, %v; want 1 path and an error", paths, err)
+	}
+}
+
+func TestDownloadAllRespectsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write(pngData)
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 8)
+	for i := range urls {
+		urls[i] = srv.URL + "/img" + string(rune('a'+i)) + ".png"
+	}
+
+	results := newTestDownloader(t, 3, 0).DownloadAll(urls)
+	for i, r := range results {
+		if r.URL != urls[i] || r.Error != "" {
+			t.Errorf("results[%d] = %+v", i, r)
+		}
+	}
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak)
+	}
+}
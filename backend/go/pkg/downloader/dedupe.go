@@ -0,0 +1,61 @@
+package downloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Duplicate 与前面某张图片内容相同的图片
+type Duplicate struct {
+	Index       int    `json:"index"`        // 在图片列表中的位置，从 1 开始
+	Path        string `json:"path"`         // 重复的图片
+	DuplicateOf int    `json:"duplicate_of"` // 首次出现的位置，从 1 开始
+	FirstPath   string `json:"first_path"`   // 首次出现的图片
+	SHA256      string `json:"sha256"`
+}
+
+// FindDuplicates 按文件内容的 SHA-256 查找重复图片，返回去重后的列表（保留首次出现的图片）和重复项
+func FindDuplicates(paths []string) ([]string, []Duplicate, error) {
+	unique := make([]string, 0, len(paths))
+	var duplicates []Duplicate
+	first := make(map[string]int, len(paths))
+
+	for i, path := range paths {
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if j, ok := first[sum]; ok {
+			duplicates = append(duplicates, Duplicate{
+				Index:       i + 1,
+				Path:        path,
+				DuplicateOf: j + 1,
+				FirstPath:   paths[j],
+				SHA256:      sum,
+			})
+			continue
+		}
+		first[sum] = i
+		unique = append(unique, path)
+	}
+
+	return unique, duplicates, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("读取图片失败: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("读取图片失败 %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
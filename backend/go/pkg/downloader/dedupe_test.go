@@ -0,0 +1,43 @@
+package downloader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	a := write("a.jpg", "image-a")
+	b := write("b.jpg", "image-b")
+	copyOfA := write("a-copy.jpg", "image-a")
+
+	unique, duplicates, err := FindDuplicates([]string{a, b, copyOfA, a})
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+	if len(unique) != 2 || unique[0] != a || unique[1] != b {
+		t.Errorf("unique = %v, want [%s %s]", unique, a, b)
+	}
+	if len(duplicates) != 2 {
+		t.Fatalf("duplicates = %+v, want 2", duplicates)
+	}
+	if d := duplicates[0]; d.Index != 3 || d.DuplicateOf != 1 || d.Path != copyOfA || d.FirstPath != a {
+		t.Errorf("duplicates[0] = %+v", d)
+	}
+	if d := duplicates[1]; d.Index != 4 || d.DuplicateOf != 1 {
+		t.Errorf("duplicates[1] = %+v", d)
+	}
+
+	if _, _, err := FindDuplicates([]string{filepath.Join(dir, "missing.jpg")}); err == nil {
+		t.Error("missing file should fail")
+	}
+}
@@ -13,12 +13,17 @@ import (
 
 	"github.com/h2non/filetype"
 	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
 // ImageDownloader 图片下载器
 type ImageDownloader struct {
 	savePath   string
 	httpClient *http.Client
+
+	concurrency  int           // 批量下载时同时下载的文件数
+	retries      int           // 瞬时错误的重试次数
+	retryBackoff time.Duration // 第一次重试前的等待时间，之后每次翻倍
 }
 
 // NewImageDownloader 创建图片下载器
@@ -33,12 +38,21 @@ func NewImageDownloader(savePath string) *ImageDownloader {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		concurrency:  configs.GetDownloadConcurrency(),
+		retries:      configs.GetDownloadRetries(),
+		retryBackoff: 500 * time.Millisecond,
 	}
 }
 
-// DownloadImage 下载图片
+// DownloadImage 下载图片，网络错误和 408/429/5xx 按 -download-retries 重试
 // 返回本地文件路径
 func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
+	path, _, err := d.downloadWithRetry(imageURL)
+	return path, err
+}
+
+// downloadOnce 下载一次图片，可重试的错误包装为 transientError
+func (d *ImageDownloader) downloadOnce(imageURL string) (string, error) {
 	// 验证URL格式
 	if !d.isValidImageURL(imageURL) {
 		return "", errors.New("invalid image URL format")
@@ -47,18 +61,22 @@ func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
 	// 下载图片数据
 	resp, err := d.httpClient.Get(imageURL)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to download image")
+		return "", transient(errors.Wrap(err, "failed to download image"))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("download failed with status: %d", resp.StatusCode)
+		err := fmt.Errorf("download failed with status: %d", resp.StatusCode)
+		if isTransientStatus(resp.StatusCode) {
+			return "", transient(err)
+		}
+		return "", err
 	}
 
 	// 读取图片数据
 	imageData, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to read image data")
+		return "", transient(errors.Wrap(err, "failed to read image data"))
 	}
 
 	// 检测图片格式
@@ -88,18 +106,17 @@ func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
 	return filePath, nil
 }
 
-// DownloadImages 批量下载图片
+// DownloadImages 批量并发下载图片，返回的路径与成功下载的 URL 顺序一致
 func (d *ImageDownloader) DownloadImages(imageURLs []string) ([]string, error) {
 	var localPaths []string
 	var errs []error
 
-	for _, imageURL := range imageURLs {
-		localPath, err := d.DownloadImage(imageURL)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("failed to download %s: %w", imageURL, err))
+	for _, result := range d.DownloadAll(imageURLs) {
+		if result.Error != "" {
+			errs = append(errs, fmt.Errorf("failed to download %s (%d attempts): %s", result.URL, result.Attempts, result.Error))
 			continue
 		}
-		localPaths = append(localPaths, localPath)
+		localPaths = append(localPaths, result.Path)
 	}
 
 	if len(errs) > 0 {
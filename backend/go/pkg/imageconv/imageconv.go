@@ -0,0 +1,205 @@
+package imageconv
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/h2non/filetype"
+	"github.com/pkg/errors"
+
+	// 注册 Go 可直接解码的格式
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// JPEGQuality 转换输出的 JPEG 质量
+const JPEGQuality = 92
+
+// 小红书可直接上传的格式，其余图片格式需要转换
+var supportedTypes = map[string]bool{
+	"jpg": true,
+	"png": true,
+}
+
+// 由 Go 解码的格式；HEIC/HEIF 需要调用系统工具
+var goDecodableTypes = map[string]bool{
+	"webp": true,
+	"tif":  true,
+	"bmp":  true,
+}
+
+// Conversion 单个文件的转换记录
+type Conversion struct {
+	Source string `json:"source"`
+	Output string `json:"output"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// Converter 把小红书不支持的图片格式（HEIC/WebP/TIFF/BMP）转换为 JPEG
+type Converter struct {
+	outDir string
+}
+
+// NewConverter 创建转换器，转换后的文件写入 outDir
+func NewConverter(outDir string) (*Converter, error) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create convert dir")
+	}
+	return &Converter{outDir: outDir}, nil
+}
+
+// Sweep 删除输出目录中超过 maxAge 未修改的转换结果。转换结果只在发布过程中使用，
+// 同一源文件再次转换时会重新写入，因此可以安全清理旧文件。
+func (c *Converter) Sweep(maxAge time.Duration) {
+	entries, err := os.ReadDir(c.outDir)
+	if err != nil {
+		return
+	}
+
+	deadline := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		if info.ModTime().Before(deadline) {
+			_ = os.Remove(filepath.Join(c.outDir, entry.Name()))
+		}
+	}
+}
+
+// NormalizeAll 依次检查图片格式，必要时转换为 JPEG。
+// 返回与输入顺序一致的路径列表以及实际发生的转换记录。
+func (c *Converter) NormalizeAll(paths []string) ([]string, []Conversion, error) {
+	result := make([]string, 0, len(paths))
+	var conversions []Conversion
+
+	for _, path := range paths {
+		out, conv, err := c.Normalize(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, out)
+		if conv != nil {
+			conversions = append(conversions, *conv)
+		}
+	}
+
+	return result, conversions, nil
+}
+
+// Normalize 转换单个文件；已是支持的格式时原样返回且 Conversion 为 nil
+func (c *Converter) Normalize(path string) (string, *Conversion, error) {
+	kind, err := filetype.MatchFile(path)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to detect file type: %s", path)
+	}
+
+	ext := kind.Extension
+	if kind.MIME.Type != "image" || supportedTypes[ext] {
+		return path, nil, nil
+	}
+
+	output := filepath.Join(c.outDir, outputName(path))
+
+	switch {
+	case goDecodableTypes[ext]:
+		err = convertWithGo(path, output, ext)
+	case ext == "heif" || ext == "heic" || ext == "avif":
+		err = convertWithSystemTool(path, output)
+	default:
+		return path, nil, nil
+	}
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "转换图片 %s (%s) 为 JPEG 失败", path, ext)
+	}
+
+	return output, &Conversion{Source: path, Output: output, From: ext, To: "jpg"}, nil
+}
+
+// convertWithGo 使用 Go 解码器转换。Go 解码器不处理方向，TIFF/WebP 携带 EXIF 方向时
+// 按方向旋转像素后输出，JPEG 不写入 EXIF，避免被再次旋转。
+func convertWithGo(src, dst, ext string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "decode failed")
+	}
+	img = applyOrientation(img, readOrientation(data, ext))
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if err := jpeg.Encode(out, img, &jpeg.Options{Quality: JPEGQuality}); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return errors.Wrap(err, "encode failed")
+	}
+
+	return out.Close()
+}
+
+// convertWithSystemTool 调用系统工具转换 HEIC/HEIF，这些工具会按 EXIF/irot 信息应用方向
+func convertWithSystemTool(src, dst string) error {
+	quality := fmt.Sprintf("%d", JPEGQuality)
+
+	candidates := [][]string{
+		{"heif-convert", "-q", quality, src, dst},
+		{"magick", src, "-auto-orient", "-quality", quality, dst},
+		{"convert", src, "-auto-orient", "-quality", quality, dst},
+	}
+	if runtime.GOOS == "darwin" {
+		// macOS 自带 sips
+		candidates = append([][]string{
+			{"sips", "-s", "format", "jpeg", "-s", "formatOptions", quality, src, "--out", dst},
+		}, candidates...)
+	}
+
+	var tried []string
+	var lastOutput string
+	for _, args := range candidates {
+		bin, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		tried = append(tried, args[0])
+
+		output, err := exec.Command(bin, args[1:]...).CombinedOutput()
+		if err == nil {
+			if _, statErr := os.Stat(dst); statErr == nil {
+				return nil
+			}
+		}
+		lastOutput = strings.TrimSpace(string(output))
+		os.Remove(dst)
+	}
+
+	if len(tried) == 0 {
+		return errors.New("未找到可用的 HEIC 转换工具，请安装 libheif (heif-convert) 或 ImageMagick")
+	}
+	return errors.Errorf("HEIC 转换失败，已尝试: %s, 输出: %s", strings.Join(tried, ", "), lastOutput)
+}
+
+// outputName 根据源路径生成稳定的输出文件名
+func outputName(path string) string {
+	hash := sha256.Sum256([]byte(path))
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return fmt.Sprintf("%s_%x.jpg", base, hash[:6])
+}
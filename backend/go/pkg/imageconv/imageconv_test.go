@@ -0,0 +1,231 @@
+package imageconv
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/h2non/filetype"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+)
+
+func writeTestImage(t *testing.T, path string, encode func(*os.File, image.Image) error) {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 30), G: uint8(y * 60), B: 128, A: 255})
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNormalizeConvertsUnsupportedFormats(t *testing.T) {
+	dir := t.TempDir()
+	converter, err := NewConverter(filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("NewConverter failed: %v", err)
+	}
+
+	tiffPath := filepath.Join(dir, "photo.tiff")
+	writeTestImage(t, tiffPath, func(f *os.File, img image.Image) error { return tiff.Encode(f, img, nil) })
+
+	bmpPath := filepath.Join(dir, "scan.bmp")
+	writeTestImage(t, bmpPath, func(f *os.File, img image.Image) error { return bmp.Encode(f, img) })
+
+	pngPath := filepath.Join(dir, "shot.png")
+	writeTestImage(t, pngPath, func(f *os.File, img image.Image) error { return png.Encode(f, img) })
+
+	paths, conversions, err := converter.NormalizeAll([]string{tiffPath, pngPath, bmpPath})
+	if err != nil {
+		t.Fatalf("NormalizeAll failed: %v", err)
+	}
+
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 paths, got %d", len(paths))
+	}
+	if paths[1] != pngPath {
+		t.Errorf("png should be kept as is, got %s", paths[1])
+	}
+	if len(conversions) != 2 {
+		t.Fatalf("expected 2 conversions, got %d", len(conversions))
+	}
+	if conversions[0].From != "tif" || conversions[1].From != "bmp" {
+		t.Errorf("unexpected source formats: %s, %s", conversions[0].From, conversions[1].From)
+	}
+
+	for _, path := range []string{paths[0], paths[2]} {
+		kind, err := filetype.MatchFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind.Extension != "jpg" {
+			t.Errorf("%s should be jpeg, got %s", path, kind.Extension)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := jpeg.DecodeConfig(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("decode converted jpeg failed: %v", err)
+		}
+		if cfg.Width != 8 || cfg.Height != 4 {
+			t.Errorf("size changed after conversion: %dx%d", cfg.Width, cfg.Height)
+		}
+	}
+}
+
+func TestNormalizeKeepsNonImageFiles(t *testing.T) {
+	dir := t.TempDir()
+	converter, err := NewConverter(filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "note.txt")
+	if err := os.WriteFile(path, []byte("not an image"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, conv, err := converter.Normalize(path)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if out != path || conv != nil {
+		t.Errorf("non-image file should be returned unchanged")
+	}
+}
+
+// tiffWithOrientation 构造只包含方向标签的 TIFF 头
+func tiffWithOrientation(order binary.ByteOrder, orientation uint16) []byte {
+	b := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(b, "II")
+	} else {
+		copy(b, "MM")
+	}
+	order.PutUint16(b[2:], 42)
+	order.PutUint32(b[4:], 8)
+	order.PutUint16(b[8:], 1)
+	order.PutUint16(b[10:], exifOrientationTag)
+	order.PutUint16(b[12:], 3) // SHORT
+	order.PutUint32(b[14:], 1)
+	order.PutUint16(b[18:], orientation)
+	return b
+}
+
+func TestReadOrientation(t *testing.T) {
+	if got := readOrientation(tiffWithOrientation(binary.LittleEndian, 6), "tif"); got != 6 {
+		t.Errorf("little-endian tiff orientation = %d, expected 6", got)
+	}
+	if got := readOrientation(tiffWithOrientation(binary.BigEndian, 8), "tif"); got != 8 {
+		t.Errorf("big-endian tiff orientation = %d, expected 8", got)
+	}
+	if got := readOrientation(tiffWithOrientation(binary.LittleEndian, 9), "tif"); got != 1 {
+		t.Errorf("invalid orientation should be ignored, got %d", got)
+	}
+
+	exif := append([]byte("Exif\x00\x00"), tiffWithOrientation(binary.BigEndian, 3)...)
+	webp := []byte("RIFF\x00\x00\x00\x00WEBP")
+	// 奇数长度的块后有 1 字节填充
+	webp = append(webp, []byte("VP8X\x03\x00\x00\x00abc\x00")...)
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(exif)))
+	webp = append(webp, "EXIF"...)
+	webp = append(webp, size...)
+	webp = append(webp, exif...)
+	if got := readOrientation(webp, "webp"); got != 3 {
+		t.Errorf("webp orientation = %d, expected 3", got)
+	}
+
+	if got := readOrientation([]byte("RIFF\x00\x00\x00\x00WEBPVP8 \xff\xff\x00\x00"), "webp"); got != 1 {
+		t.Errorf("truncated webp should default to 1, got %d", got)
+	}
+	if got := readOrientation(tiffWithOrientation(binary.LittleEndian, 6), "bmp"); got != 1 {
+		t.Errorf("bmp has no orientation, got %d", got)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 3x2 图片，左上角为红色
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	red := color.RGBA{R: 255, A: 255}
+	img.Set(0, 0, red)
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		redX, redY  int
+	}{
+		{1, 3, 2, 0, 0},
+		{2, 3, 2, 2, 0},
+		{3, 3, 2, 2, 1},
+		{4, 3, 2, 0, 1},
+		{5, 2, 3, 0, 0},
+		{6, 2, 3, 1, 0},
+		{7, 2, 3, 1, 2},
+		{8, 2, 3, 0, 2},
+	}
+
+	for _, test := range tests {
+		out := applyOrientation(img, test.orientation)
+		b := out.Bounds()
+		if b.Dx() != test.width || b.Dy() != test.height {
+			t.Errorf("orientation %d: size %dx%d, expected %dx%d", test.orientation, b.Dx(), b.Dy(), test.width, test.height)
+			continue
+		}
+		if out.At(test.redX, test.redY) != color.Color(red) {
+			t.Errorf("orientation %d: top-left pixel should move to (%d,%d)", test.orientation, test.redX, test.redY)
+		}
+	}
+}
+
+func TestSweepRemovesOldOutputs(t *testing.T) {
+	dir := t.TempDir()
+	converter, err := NewConverter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPath := filepath.Join(dir, "old.jpg")
+	newPath := filepath.Join(dir, "new.jpg")
+	for _, path := range []string{oldPath, newPath} {
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(oldPath, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	converter.Sweep(time.Hour)
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old output should be removed")
+	}
+	if _, err := os.Stat(newPath); err != nil {
+		t.Errorf("recent output should be kept: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package imageconv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// exifOrientationTag EXIF/TIFF 中的方向标签
+const exifOrientationTag = 0x0112
+
+// readOrientation 读取 TIFF 或 WebP 文件携带的 EXIF 方向（1-8），没有方向信息时返回 1
+func readOrientation(data []byte, ext string) int {
+	switch ext {
+	case "tif":
+		return tiffOrientation(data)
+	case "webp":
+		return webpOrientation(data)
+	}
+	return 1
+}
+
+// tiffOrientation 从 TIFF 结构（TIFF 文件本身或 EXIF 数据）的第一个 IFD 中读取方向标签
+func tiffOrientation(b []byte) int {
+	if len(b) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(b[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(b[2:4]) != 42 {
+		return 1
+	}
+
+	ifd := int64(order.Uint32(b[4:8]))
+	if ifd < 8 || ifd+2 > int64(len(b)) {
+		return 1
+	}
+	entries := int64(order.Uint16(b[ifd:]))
+	for i := int64(0); i < entries; i++ {
+		off := ifd + 2 + i*12
+		if off+12 > int64(len(b)) {
+			break
+		}
+		if order.Uint16(b[off:]) != exifOrientationTag {
+			continue
+		}
+		// 方向为 SHORT 类型，值直接存放在条目的值字段中
+		if v := int(order.Uint16(b[off+8:])); v >= 1 && v <= 8 {
+			return v
+		}
+		return 1
+	}
+	return 1
+}
+
+// webpOrientation 在 WebP 的 RIFF 块中查找 EXIF 块并读取方向
+func webpOrientation(b []byte) int {
+	if len(b) < 12 || string(b[:4]) != "RIFF" || string(b[8:12]) != "WEBP" {
+		return 1
+	}
+	for off := int64(12); off+8 <= int64(len(b)); {
+		size := int64(binary.LittleEndian.Uint32(b[off+4:]))
+		start, end := off+8, off+8+size
+		if end > int64(len(b)) {
+			return 1
+		}
+		if string(b[off:off+4]) == "EXIF" {
+			return tiffOrientation(bytes.TrimPrefix(b[start:end], []byte("Exif\x00\x00")))
+		}
+		// 块按偶数字节对齐
+		off = end + size%2
+	}
+	return 1
+}
+
+// applyOrientation 按 EXIF 方向旋转或翻转图片，使输出的像素方向与显示方向一致
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	// 输出坐标 (x, y) 对应的源坐标
+	source := map[int]func(x, y int) (int, int){
+		2: func(x, y int) (int, int) { return w - 1 - x, y },
+		3: func(x, y int) (int, int) { return w - 1 - x, h - 1 - y },
+		4: func(x, y int) (int, int) { return x, h - 1 - y },
+		5: func(x, y int) (int, int) { return y, x },
+		6: func(x, y int) (int, int) { return y, h - 1 - x },
+		7: func(x, y int) (int, int) { return w - 1 - y, h - 1 - x },
+		8: func(x, y int) (int, int) { return w - 1 - y, x },
+	}[orientation]
+
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := source(x, y)
+			dst.SetRGBA(x, y, src.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
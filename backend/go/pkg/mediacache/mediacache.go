@@ -0,0 +1,158 @@
+package mediacache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrTokenExpired 令牌已过期或对应文件已被清理
+var ErrTokenExpired = errors.New("素材令牌已过期，请重新调用 upload_images 上传")
+
+// ErrInvalidToken 令牌格式错误
+var ErrInvalidToken = errors.New("无效的素材令牌")
+
+// tokenPattern 令牌格式：32 位内容哈希、可选的小写扩展名、过期时间的 Unix 秒数
+var tokenPattern = regexp.MustCompile(`^([0-9a-f]{32}(?:\.[a-z0-9]+)?)\.([0-9]+)$`)
+
+// extPattern 缓存文件保留的扩展名，不符合时不保留扩展名，保证生成的令牌都能通过校验
+var extPattern = regexp.MustCompile(`^\.[a-z0-9]+$`)
+
+// Item 已缓存的素材
+type Item struct {
+	Token     string    `json:"token"`
+	Source    string    `json:"source"`
+	Size      int64     `json:"size"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Store 按内容哈希缓存素材文件。令牌由内容哈希和过期时间组成，
+// 服务重启后未过期的令牌仍然有效。
+type Store struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewStore 创建素材缓存
+func NewStore(dir string, ttl time.Duration) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create media dir")
+	}
+	return &Store{dir: dir, ttl: ttl}, nil
+}
+
+// Put 把本地文件复制到缓存目录并返回令牌；相同内容只保存一份
+func (s *Store) Put(path string) (*Item, error) {
+	s.sweep()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	hash := fmt.Sprintf("%x", hasher.Sum(nil))[:32]
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if !extPattern.MatchString(ext) {
+		ext = ""
+	}
+	target := filepath.Join(s.dir, hash+ext)
+
+	if _, err := os.Stat(target); err != nil {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := copyToFile(f, target); err != nil {
+			return nil, err
+		}
+	}
+
+	// 刷新修改时间，避免仍在使用的文件被清理
+	now := time.Now()
+	_ = os.Chtimes(target, now, now)
+
+	expiresAt := now.Add(s.ttl)
+	return &Item{
+		Token:     fmt.Sprintf("%s%s.%d", hash, ext, expiresAt.Unix()),
+		Source:    path,
+		Size:      size,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Resolve 把令牌解析为缓存中的本地文件路径，只接受 Put 生成的令牌格式
+func (s *Store) Resolve(token string) (string, error) {
+	m := tokenPattern.FindStringSubmatch(token)
+	if m == nil {
+		return "", ErrInvalidToken
+	}
+
+	name := m[1]
+	expiresAt, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if time.Now().Unix() > expiresAt {
+		return "", ErrTokenExpired
+	}
+
+	path := filepath.Join(s.dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return "", ErrTokenExpired
+	}
+
+	return path, nil
+}
+
+// sweep 清理超过有效期未被使用的缓存文件
+func (s *Store) sweep() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+
+	deadline := time.Now().Add(-s.ttl)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		if info.ModTime().Before(deadline) {
+			_ = os.Remove(filepath.Join(s.dir, entry.Name()))
+		}
+	}
+}
+
+func copyToFile(r io.Reader, target string) error {
+	tmp := target + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return errors.Wrap(err, "failed to create media file")
+	}
+
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return errors.Wrap(err, "failed to write media file")
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, target)
+}
@@ -0,0 +1,79 @@
+package mediacache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStorePutAndResolve(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "media"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	src := filepath.Join(dir, "a.JPG")
+	if err := os.WriteFile(src, []byte("fake image data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := store.Put(src)
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if item.Size != int64(len("fake image data")) {
+		t.Errorf("Size = %d, expected %d", item.Size, len("fake image data"))
+	}
+
+	path, err := store.Resolve(item.Token)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if filepath.Ext(path) != ".jpg" {
+		t.Errorf("cached file should keep lower-case extension, got %s", path)
+	}
+
+	// 相同内容只保存一份
+	again, err := store.Put(src)
+	if err != nil {
+		t.Fatalf("second Put failed: %v", err)
+	}
+	againPath, _ := store.Resolve(again.Token)
+	if againPath != path {
+		t.Errorf("same content should map to same file, got %s and %s", path, againPath)
+	}
+}
+
+func TestStoreResolveErrors(t *testing.T) {
+	store, err := NewStore(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	hash := "0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		token    string
+		expected error
+	}{
+		{"", ErrInvalidToken},
+		{"no-expiry", ErrInvalidToken},
+		{"abc.jpg.notanumber", ErrInvalidToken},
+		{"abc.jpg.9999999999", ErrInvalidToken},
+		{"../etc/passwd.9999999999", ErrInvalidToken},
+		{"..", ErrInvalidToken},
+		{"...9999999999", ErrInvalidToken},
+		{hash + "/../x.9999999999", ErrInvalidToken},
+		{hash + ".JPG.9999999999", ErrInvalidToken},
+		{hash + ".jpg.1", ErrTokenExpired},
+		{hash + ".jpg.9999999999", ErrTokenExpired},
+		{hash + ".9999999999", ErrTokenExpired},
+	}
+
+	for _, test := range tests {
+		if _, err := store.Resolve(test.token); err != test.expected {
+			t.Errorf("Resolve(%q) error = %v, expected %v", test.token, err, test.expected)
+		}
+	}
+}
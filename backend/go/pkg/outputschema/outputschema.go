@@ -0,0 +1,210 @@
+// Package outputschema 根据 Go 结果类型生成 MCP 工具的输出 JSON Schema，并在返回前校验数据。
+package outputschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// identifierKeys 这些字段为空字符串时视为页面数据异常（如平台改版后取不到 ID）
+var identifierKeys = map[string]bool{
+	"id":      true,
+	"feed_id": true,
+}
+
+// Schema 工具的输出 Schema
+type Schema struct {
+	schema   *jsonschema.Schema
+	resolved *jsonschema.Resolved
+}
+
+// For 由结果类型 T 推导输出 Schema：结构体字段按 json tag 生成，非 omitempty 字段为必填，
+// 不允许未声明的字段；Go 的 nil 切片和 nil map 会序列化为 null，因此数组和 map 允许为 null。
+// 自引用的类型（如带子评论的评论）放入 $defs 并通过 $ref 引用。
+func For[T any]() (*Schema, error) {
+	root := reflect.TypeFor[T]()
+	opts := &jsonschema.ForOptions{
+		IgnoreInvalidTypes: true,
+		TypeSchemas:        make(map[reflect.Type]*jsonschema.Schema),
+	}
+
+	recursive := recursiveTypes(root)
+	for _, t := range recursive {
+		opts.TypeSchemas[t] = &jsonschema.Schema{Ref: "#/$defs/" + t.Name()}
+	}
+
+	s, err := jsonschema.ForType(root, opts)
+	if err != nil {
+		return nil, err
+	}
+	adjust(s)
+
+	for _, t := range recursive {
+		// 用字段相同的匿名结构体生成定义本身，否则会直接得到指向自己的 $ref
+		def, err := jsonschema.ForType(unnamedStruct(t), opts)
+		if err != nil {
+			return nil, err
+		}
+		adjust(def)
+		if s.Defs == nil {
+			s.Defs = make(map[string]*jsonschema.Schema)
+		}
+		s.Defs[t.Name()] = def
+	}
+
+	return newSchema(s)
+}
+
+// MustFor 同 For，推导失败时 panic，用于注册工具时
+func MustFor[T any]() *Schema {
+	s, err := For[T]()
+	if err != nil {
+		panic(fmt.Sprintf("outputschema: %v", err))
+	}
+	return s
+}
+
+// AnyOf 结果满足其中任一 Schema 即可，用于按参数返回不同结构的工具。
+// 各 Schema 的 $defs 移到外层，使其中 #/$defs/... 的引用仍能解析。
+func AnyOf(schemas ...*Schema) *Schema {
+	s := &jsonschema.Schema{Type: "object"}
+	for _, item := range schemas {
+		sub := item.schema
+		if len(sub.Defs) > 0 {
+			sub = sub.CloneSchemas()
+			if s.Defs == nil {
+				s.Defs = make(map[string]*jsonschema.Schema)
+			}
+			for name, def := range sub.Defs {
+				s.Defs[name] = def
+			}
+			sub.Defs = nil
+		}
+		s.AnyOf = append(s.AnyOf, sub)
+	}
+
+	result, err := newSchema(s)
+	if err != nil {
+		panic(fmt.Sprintf("outputschema: %v", err))
+	}
+	return result
+}
+
+func newSchema(s *jsonschema.Schema) (*Schema, error) {
+	resolved, err := s.Resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: s, resolved: resolved}, nil
+}
+
+// JSONSchema 返回可以放入 mcp.Tool.OutputSchema 的 Schema
+func (s *Schema) JSONSchema() *jsonschema.Schema {
+	return s.schema
+}
+
+// Validate 按 JSON 序列化后的形态校验 data
+func (s *Schema) Validate(data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+	return s.resolved.Validate(value)
+}
+
+// recursiveTypes 找出 root 中直接或间接引用自身的结构体类型
+func recursiveTypes(root reflect.Type) []reflect.Type {
+	var result []reflect.Type
+	found := make(map[reflect.Type]bool)
+	visited := make(map[reflect.Type]bool)
+	onPath := make(map[reflect.Type]bool)
+
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		if onPath[t] {
+			if !found[t] && t.Name() != "" {
+				found[t] = true
+				result = append(result, t)
+			}
+			return
+		}
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		onPath[t] = true
+		defer delete(onPath, t)
+
+		for _, field := range reflect.VisibleFields(t) {
+			if field.IsExported() {
+				walk(field.Type)
+			}
+		}
+	}
+	walk(root)
+
+	return result
+}
+
+// unnamedStruct 返回与 t 字段相同的匿名结构体类型
+func unnamedStruct(t reflect.Type) reflect.Type {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.IsExported() {
+			fields = append(fields, field)
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+// adjust 递归调整推导出的 Schema：数组与 map 允许 null，标识字段不允许为空字符串。
+// 顶层 Schema 必须是 object，因此 map 只在字段、数组元素和 map 的值中放宽。
+func adjust(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+
+	if s.Type == "array" {
+		s.Types = []string{"null", "array"}
+		s.Type = ""
+	}
+
+	for name, prop := range s.Properties {
+		if identifierKeys[name] && prop.Type == "string" {
+			prop.MinLength = jsonschema.Ptr(1)
+		}
+		nullableMap(prop)
+		adjust(prop)
+	}
+	nullableMap(s.Items)
+	adjust(s.Items)
+	nullableMap(s.AdditionalProperties)
+	adjust(s.AdditionalProperties)
+}
+
+// nullableMap 允许由 Go map 推导出的 Schema 为 null。
+// map 推导为没有 properties、以值类型为 additionalProperties 的 object；结构体的 additionalProperties 为 false。
+func nullableMap(s *jsonschema.Schema) {
+	if s == nil || s.Type != "object" || len(s.Properties) > 0 {
+		return
+	}
+	if s.AdditionalProperties == nil || s.AdditionalProperties.Not != nil {
+		return
+	}
+	s.Types = []string{"null", "object"}
+	s.Type = ""
+}
@@ -0,0 +1,139 @@
+package outputschema
+
+import (
+	"testing"
+	"time"
+)
+
+type item struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Likes int    `json:"likes,omitempty"`
+}
+
+type listResult struct {
+	Items     []item    `json:"items"`
+	Count     int       `json:"count"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Complete  *bool     `json:"complete,omitempty"`
+}
+
+type projectedResult struct {
+	Items []map[string]any `json:"items"`
+}
+
+func TestValidate(t *testing.T) {
+	s, err := For[listResult]()
+	if err != nil {
+		t.Fatalf("For failed: %v", err)
+	}
+	if s.JSONSchema().Type != "object" {
+		t.Fatalf("schema type = %q, want object", s.JSONSchema().Type)
+	}
+
+	valid := listResult{Items: []item{{ID: "n1", Title: "标题"}}, Count: 1, FetchedAt: time.Now()}
+	if err := s.Validate(valid); err != nil {
+		t.Errorf("valid result rejected: %v", err)
+	}
+	if err := s.Validate(listResult{}); err != nil {
+		t.Errorf("empty result with nil slice rejected: %v", err)
+	}
+
+	if err := s.Validate(listResult{Items: []item{{Title: "没有ID"}}}); err == nil {
+		t.Error("empty id accepted")
+	}
+	if err := s.Validate(map[string]any{"items": []any{}, "count": "1", "fetched_at": ""}); err == nil {
+		t.Error("wrong type accepted")
+	}
+	if err := s.Validate(map[string]any{"items": []any{}, "fetched_at": ""}); err == nil {
+		t.Error("missing required field accepted")
+	}
+	if err := s.Validate(map[string]any{"items": []any{}, "count": 0, "fetched_at": "", "extra": true}); err == nil {
+		t.Error("undeclared field accepted")
+	}
+}
+
+func TestAnyOf(t *testing.T) {
+	s := AnyOf(MustFor[listResult](), MustFor[projectedResult]())
+
+	if err := s.Validate(projectedResult{Items: []map[string]any{{"title": "a"}}}); err != nil {
+		t.Errorf("projected result rejected: %v", err)
+	}
+	if err := s.Validate(listResult{Count: 0}); err != nil {
+		t.Errorf("full result rejected: %v", err)
+	}
+	if err := s.Validate(map[string]any{"unknown": 1}); err == nil {
+		t.Error("unrelated shape accepted")
+	}
+}
+
+type comment struct {
+	ID          string    `json:"id"`
+	Content     string    `json:"content"`
+	SubComments []comment `json:"subComments"`
+}
+
+type commentsResult struct {
+	Comments []comment `json:"comments"`
+}
+
+func TestRecursiveType(t *testing.T) {
+	s, err := For[commentsResult]()
+	if err != nil {
+		t.Fatalf("For failed: %v", err)
+	}
+
+	valid := commentsResult{Comments: []comment{{ID: "c1", Content: "a", SubComments: []comment{{ID: "c2", Content: "b"}}}}}
+	if err := s.Validate(valid); err != nil {
+		t.Errorf("valid nested comments rejected: %v", err)
+	}
+
+	invalid := commentsResult{Comments: []comment{{ID: "c1", SubComments: []comment{{Content: "没有ID"}}}}}
+	if err := s.Validate(invalid); err == nil {
+		t.Error("nested comment with empty id accepted")
+	}
+}
+
+func TestAnyOfRecursiveType(t *testing.T) {
+	inner := MustFor[commentsResult]()
+	s := AnyOf(inner, MustFor[listResult]())
+
+	valid := commentsResult{Comments: []comment{{ID: "c1", SubComments: []comment{{ID: "c2"}}}}}
+	if err := s.Validate(valid); err != nil {
+		t.Errorf("nested comments rejected: %v", err)
+	}
+	if err := s.Validate(commentsResult{Comments: []comment{{ID: "c1", SubComments: []comment{{}}}}}); err == nil {
+		t.Error("nested comment with empty id accepted")
+	}
+	if len(inner.JSONSchema().Defs) == 0 {
+		t.Error("AnyOf modified the original schema")
+	}
+}
+
+type settingsResult struct {
+	Reason     string            `json:"reason,omitempty"`
+	Categories map[string]bool   `json:"categories"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Detail     item              `json:"detail"`
+}
+
+func TestNilMap(t *testing.T) {
+	s, err := For[settingsResult]()
+	if err != nil {
+		t.Fatalf("For failed: %v", err)
+	}
+
+	if err := s.Validate(settingsResult{Reason: "x", Detail: item{ID: "n1"}}); err != nil {
+		t.Errorf("nil map rejected: %v", err)
+	}
+	if err := s.Validate(settingsResult{Categories: map[string]bool{"likes": true}, Detail: item{ID: "n1"}}); err != nil {
+		t.Errorf("valid map rejected: %v", err)
+	}
+	if err := s.Validate(map[string]any{"categories": map[string]any{"likes": "yes"}, "detail": map[string]any{"id": "n1", "title": ""}}); err == nil {
+		t.Error("map with wrong value type accepted")
+	}
+	// 结构体字段仍然不允许为 null
+	if err := s.Validate(map[string]any{"categories": nil, "detail": nil}); err == nil {
+		t.Error("null struct accepted")
+	}
+}
@@ -0,0 +1,160 @@
+// Package resultbuffer 在内存中暂存被截断的工具结果剩余部分，客户端凭令牌分段取回。
+package resultbuffer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// ErrTokenExpired 令牌不存在、已过期或剩余内容已被取走
+var ErrTokenExpired = errors.New("续取令牌无效或已过期，请重新调用原工具")
+
+// DefaultMaxEntries 默认最多暂存的结果数，超出时丢弃最早过期的
+const DefaultMaxEntries = 100
+
+// TokenLength 令牌的长度（十六进制字符数）
+const TokenLength = 32
+
+type entry struct {
+	text      string
+	expiresAt time.Time
+}
+
+// Store 暂存截断后的剩余内容，每个令牌只能取一次，服务重启后失效
+type Store struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*entry
+	now        func() time.Time
+}
+
+// NewStore 创建暂存区，ttl 为剩余内容的保留时间
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:        ttl,
+		maxEntries: DefaultMaxEntries,
+		entries:    make(map[string]*entry),
+		now:        time.Now,
+	}
+}
+
+// Put 暂存 text 并返回令牌与过期时间
+func (s *Store) Put(text string) (string, time.Time) {
+	token := newToken()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweepLocked(now)
+	if len(s.entries) >= s.maxEntries {
+		s.evictOldestLocked()
+	}
+
+	expiresAt := now.Add(s.ttl)
+	s.entries[token] = &entry{text: text, expiresAt: expiresAt}
+	return token, expiresAt
+}
+
+// Take 取出令牌对应的内容并删除
+func (s *Store) Take(token string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[token]
+	if !ok {
+		return "", ErrTokenExpired
+	}
+	delete(s.entries, token)
+
+	if !s.now().Before(e.expiresAt) {
+		return "", ErrTokenExpired
+	}
+	return e.text, nil
+}
+
+// Len 当前暂存的结果数
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+func (s *Store) sweepLocked(now time.Time) {
+	for token, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, token)
+		}
+	}
+}
+
+func (s *Store) evictOldestLocked() {
+	var oldest string
+	for token, e := range s.entries {
+		if oldest == "" || e.expiresAt.Before(s.entries[oldest].expiresAt) {
+			oldest = token
+		}
+	}
+	delete(s.entries, oldest)
+}
+
+func newToken() string {
+	b := make([]byte, TokenLength/2)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.Wrap(err, "failed to generate token"))
+	}
+	return hex.EncodeToString(b)
+}
+
+// Split 把 text 切成不超过 maxBytes 字节的头部和剩余部分，不会切断多字节字符；
+// maxBytes 小于第一个字符的长度时头部为第一个字符
+func Split(text string, maxBytes int) (string, string) {
+	if maxBytes <= 0 || len(text) <= maxBytes {
+		return text, ""
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	// maxBytes 小于第一个字符时也至少返回一个字符，保证续取总能向前推进
+	if cut == 0 {
+		_, cut = utf8.DecodeRuneInString(text)
+	}
+	return text[:cut], text[cut:]
+}
+
+// SplitEscaped 同 Split，但按 text 作为 JSON 字符串转义后的长度计算：头部转义后不超过 maxBytes 字节，
+// 用于限制整个 JSON 响应的大小；maxBytes 不足一个字符（含小于等于 0）时头部为第一个字符
+func SplitEscaped(text string, maxBytes int) (string, string) {
+	size, cut := 0, 0
+	for cut < len(text) {
+		r, width := utf8.DecodeRuneInString(text[cut:])
+		n := escapedLen(r, width)
+		if cut > 0 && size+n > maxBytes {
+			break
+		}
+		size += n
+		cut += width
+	}
+	return text[:cut], text[cut:]
+}
+
+// escapedLen 字符经 encoding/json 转义后的字节数
+func escapedLen(r rune, width int) int {
+	switch {
+	case r == '"' || r == '\\' || r == '\n' || r == '\r' || r == '\t':
+		return 2
+	case r < 0x20 || r == '<' || r == '>' || r == '&' || r == '\u2028' || r == '\u2029':
+		return 6 // \u00XX
+	case r == utf8.RuneError && width == 1:
+		return 6 // 无效字节替换为 \ufffd
+	}
+	return width
+}
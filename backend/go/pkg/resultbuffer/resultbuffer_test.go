@@ -0,0 +1,117 @@
+package resultbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPutTake(t *testing.T) {
+	s := NewStore(time.Minute)
+
+	token, expiresAt := s.Put("剩余内容")
+	if token == "" {
+		t.Fatal("empty token")
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("expiresAt %v not in the future", expiresAt)
+	}
+
+	text, err := s.Take(token)
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+	if text != "剩余内容" {
+		t.Errorf("got %q", text)
+	}
+
+	if _, err := s.Take(token); err != ErrTokenExpired {
+		t.Errorf("second Take: got %v, want ErrTokenExpired", err)
+	}
+	if _, err := s.Take("unknown"); err != ErrTokenExpired {
+		t.Errorf("unknown token: got %v, want ErrTokenExpired", err)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Now()
+	s := NewStore(time.Minute)
+	s.now = func() time.Time { return now }
+
+	token, _ := s.Put("a")
+	now = now.Add(2 * time.Minute)
+	if _, err := s.Take(token); err != ErrTokenExpired {
+		t.Errorf("expired token: got %v, want ErrTokenExpired", err)
+	}
+
+	s.Put("b")
+	now = now.Add(2 * time.Minute)
+	s.Put("c")
+	if got := s.Len(); got != 1 {
+		t.Errorf("Len after sweep = %d, want 1", got)
+	}
+}
+
+func TestMaxEntries(t *testing.T) {
+	s := NewStore(time.Minute)
+	s.maxEntries = 2
+
+	first, _ := s.Put("1")
+	s.Put("2")
+	s.Put("3")
+
+	if got := s.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+	if _, err := s.Take(first); err != ErrTokenExpired {
+		t.Errorf("oldest entry not evicted: %v", err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxBytes int
+		head     string
+		rest     string
+	}{
+		{"hello", 0, "hello", ""},
+		{"hello", 10, "hello", ""},
+		{"hello", 3, "hel", "lo"},
+		{"你好世界", 4, "你", "好世界"}, // 每个汉字 3 字节，不切断字符
+		{"你好世界", 6, "你好", "世界"},
+		{"你好世界", 2, "你", "好世界"}, // 不足一个字符时至少返回一个字符
+		{"你好世界", 1, "你", "好世界"},
+	}
+
+	for _, tt := range tests {
+		head, rest := Split(tt.text, tt.maxBytes)
+		if head != tt.head || rest != tt.rest {
+			t.Errorf("Split(%q, %d) = %q, %q; want %q, %q", tt.text, tt.maxBytes, head, rest, tt.head, tt.rest)
+		}
+	}
+}
+
+func TestSplitEscaped(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxBytes int
+		head     string
+		rest     string
+	}{
+		{"hello", 10, "hello", ""},
+		{"hello", 3, "hel", "lo"},
+		{`a"b"c`, 3, `a"`, `b"c`}, // 引号转义后占 2 字节
+		{"a\nb", 2, "a", "\nb"},
+		{"<a>", 6, "<", "a>"}, // < 转义为 \u003c
+		{"你好世界", 6, "你好", "世界"},
+		{"你好世界", 0, "你", "好世界"}, // 不足一个字符时至少返回一个字符
+		{"你好世界", -10, "你", "好世界"},
+	}
+
+	for _, tt := range tests {
+		head, rest := SplitEscaped(tt.text, tt.maxBytes)
+		if head != tt.head || rest != tt.rest {
+			t.Errorf("SplitEscaped(%q, %d) = %q, %q; want %q, %q", tt.text, tt.maxBytes, head, rest, tt.head, tt.rest)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// lookupKeychain 通过系统命令读取钥匙串：macOS 使用 security，其他系统使用 libsecret 的 secret-tool
+func lookupKeychain(service, account string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	} else {
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup", "service", service, "account", account)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && isNotFound(exitErr.ExitCode(), stderr.String()) {
+			return "", ErrNotFound
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.Wrapf(err, "%s: %s", cmd.Path, msg)
+		}
+		return "", errors.Wrap(err, cmd.Path)
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// isNotFound 区分“条目不存在”与其他错误：security 返回 44，secret-tool 找不到时返回 1 且没有错误输出
+func isNotFound(code int, stderr string) bool {
+	if runtime.GOOS == "darwin" {
+		return code == 44
+	}
+	return code == 1 && strings.TrimSpace(stderr) == ""
+}
@@ -0,0 +1,101 @@
+// Package secrets 读取敏感配置：可以从系统钥匙串读取，避免出现在进程参数中。
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// 敏感配置的读取来源
+const (
+	SourceEnv      = "env"      // 只使用命令行参数与环境变量
+	SourceKeychain = "keychain" // 优先读取系统钥匙串，未保存时回退到命令行参数与环境变量
+)
+
+// DefaultService 钥匙串条目的默认服务名
+const DefaultService = "xiaohongshu-mcp"
+
+// 调用系统钥匙串命令的超时，钥匙串被锁定时系统可能弹窗等待用户解锁
+const lookupTimeout = 30 * time.Second
+
+// ErrNotFound 钥匙串中没有对应的条目
+var ErrNotFound = errors.New("钥匙串中没有该条目")
+
+// 值的实际来源，用于日志
+const (
+	FromKeychain = "keychain"
+	FromFlag     = "flag"
+	FromEnv      = "env"
+)
+
+// ParseSource 校验读取来源，为空时使用 env
+func ParseSource(source string) (string, error) {
+	switch s := strings.ToLower(strings.TrimSpace(source)); s {
+	case "":
+		return SourceEnv, nil
+	case SourceEnv, SourceKeychain:
+		return s, nil
+	default:
+		return "", fmt.Errorf("无效的读取来源 %q，可选: %s|%s", source, SourceEnv, SourceKeychain)
+	}
+}
+
+// Resolver 按读取来源解析敏感配置。
+// 钥匙串条目按 服务名 + 账户名 保存，账户名即配置名，如 event_webhook：
+//   - macOS:   security add-generic-password -s xiaohongshu-mcp -a event_webhook -w <值>
+//   - Linux:   secret-tool store --label=xiaohongshu-mcp service xiaohongshu-mcp account event_webhook
+//   - Windows: 凭据管理器中的普通凭据，地址为 xiaohongshu-mcp:event_webhook
+type Resolver struct {
+	source  string
+	service string
+	lookup  func(service, account string) (string, error)
+}
+
+// NewResolver 创建解析器，service 为空时使用 DefaultService
+func NewResolver(source, service string) *Resolver {
+	if service == "" {
+		service = DefaultService
+	}
+	return &Resolver{source: source, service: service, lookup: lookupKeychain}
+}
+
+// Source 读取来源
+func (r *Resolver) Source() string {
+	return r.source
+}
+
+// Service 钥匙串条目的服务名
+func (r *Resolver) Service() string {
+	return r.service
+}
+
+// Resolve 返回配置 name 的值及其来源。keychain 模式下先读钥匙串；
+// 没有条目或读取失败时依次回退到命令行参数 flagValue 和环境变量 envKey。都为空时返回空字符串。
+func (r *Resolver) Resolve(name, flagValue, envKey string) (string, string) {
+	if r.source == SourceKeychain {
+		value, err := r.lookup(r.service, name)
+		switch {
+		case err == nil && value != "":
+			return value, FromKeychain
+		case err == nil || errors.Is(err, ErrNotFound):
+			logrus.Debugf("钥匙串中没有 %s/%s，回退到命令行参数与环境变量", r.service, name)
+		default:
+			logrus.Warnf("读取钥匙串 %s/%s 失败，回退到命令行参数与环境变量: %v", r.service, name, err)
+		}
+	}
+
+	if flagValue != "" {
+		return flagValue, FromFlag
+	}
+	if envKey != "" {
+		if value := os.Getenv(envKey); value != "" {
+			return value, FromEnv
+		}
+	}
+	return "", ""
+}
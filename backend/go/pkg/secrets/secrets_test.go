@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSource(t *testing.T) {
+	for input, want := range map[string]string{"": SourceEnv, "env": SourceEnv, " Keychain ": SourceKeychain} {
+		got, err := ParseSource(input)
+		if err != nil || got != want {
+			t.Errorf("ParseSource(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseSource("vault"); err == nil {
+		t.Error("ParseSource(vault) should fail")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("TEST_SECRET_ENV", "from-env")
+
+	stored := map[string]string{"xiaohongshu-mcp/event_webhook": "from-keychain"}
+	lookup := func(service, account string) (string, error) {
+		if account == "broken" {
+			return "", errors.New("secret-tool: not installed")
+		}
+		if v, ok := stored[service+"/"+account]; ok {
+			return v, nil
+		}
+		return "", ErrNotFound
+	}
+
+	keychain := NewResolver(SourceKeychain, "")
+	keychain.lookup = lookup
+	env := NewResolver(SourceEnv, "")
+	env.lookup = func(string, string) (string, error) {
+		t.Fatal("env source must not read the keychain")
+		return "", nil
+	}
+
+	tests := []struct {
+		name      string
+		resolver  *Resolver
+		key       string
+		flagValue string
+		envKey    string
+		want      string
+		from      string
+	}{
+		{"keychain wins over flag", keychain, "event_webhook", "from-flag", "TEST_SECRET_ENV", "from-keychain", FromKeychain},
+		{"missing entry falls back to flag", keychain, "prepublish_webhook", "from-flag", "TEST_SECRET_ENV", "from-flag", FromFlag},
+		{"lookup error falls back to env", keychain, "broken", "", "TEST_SECRET_ENV", "from-env", FromEnv},
+		{"env source uses flag", env, "event_webhook", "from-flag", "TEST_SECRET_ENV", "from-flag", FromFlag},
+		{"env source uses env", env, "event_webhook", "", "TEST_SECRET_ENV", "from-env", FromEnv},
+		{"nothing set", env, "event_webhook", "", "TEST_SECRET_UNSET", "", ""},
+	}
+	for _, tt := range tests {
+		got, from := tt.resolver.Resolve(tt.key, tt.flagValue, tt.envKey)
+		if got != tt.want || from != tt.from {
+			t.Errorf("%s: got %q from %q, want %q from %q", tt.name, got, from, tt.want, tt.from)
+		}
+	}
+}
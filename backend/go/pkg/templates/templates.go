@@ -0,0 +1,183 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound 模板不存在
+var ErrNotFound = errors.New("发布模板不存在")
+
+// 模板名称只允许中英文、数字、下划线和短横线，避免写出数据目录
+var namePattern = regexp.MustCompile(`^[\p{Han}\w-]{1,64}$`)
+
+// 正文/标题中的占位符，如 {{date}}
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([\p{Han}\w-]+)\s*\}\}`)
+
+// Spec 可复用的发布内容，Title/Content 中可以包含 {{变量}} 占位符
+type Spec struct {
+	Title           string   `json:"title,omitempty"`
+	Content         string   `json:"content,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Images          []string `json:"images,omitempty"`
+	ImageTokens     []string `json:"image_tokens,omitempty"`
+	Visibility      string   `json:"visibility,omitempty"`
+	NormalizeImages bool     `json:"normalize_images,omitempty"`
+}
+
+// Overrides 按模板发布时的覆盖项：非空字段替换模板中的对应字段，Vars 用于填充占位符
+type Overrides struct {
+	Spec
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// Template 已保存的发布模板
+type Template struct {
+	Name      string    `json:"name"`
+	Spec      Spec      `json:"spec"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store 把模板以 JSON 文件形式保存在目录中，每个模板一个文件
+type Store struct {
+	dir string
+}
+
+// NewStore 创建模板存储
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create templates dir")
+	}
+	return &Store{dir: dir}, nil
+}
+
+// ValidateName 校验模板名称
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("无效的模板名称 %q：只允许中英文、数字、下划线和短横线，最长64个字符", name)
+	}
+	return nil
+}
+
+// Save 保存模板，同名模板会被覆盖
+func (s *Store) Save(name string, spec Spec) (*Template, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	tpl := &Template{Name: name, Spec: spec, UpdatedAt: time.Now()}
+
+	data, err := json.MarshalIndent(tpl, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// 先写临时文件再重命名，避免写到一半时读到损坏的模板
+	path := s.path(name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return nil, errors.Wrap(err, "failed to write template")
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, errors.Wrap(err, "failed to save template")
+	}
+
+	return tpl, nil
+}
+
+// Get 读取模板
+func (s *Store) Get(name string) (*Template, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.path(name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tpl Template
+	if err := json.Unmarshal(data, &tpl); err != nil {
+		return nil, errors.Wrapf(err, "模板 %s 已损坏", name)
+	}
+	return &tpl, nil
+}
+
+// List 返回所有模板名称
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// Apply 用 overrides 覆盖模板内容并填充占位符，有未填充的占位符时返回错误
+func (t *Template) Apply(overrides Overrides) (*Spec, error) {
+	spec := t.Spec
+
+	if overrides.Title != "" {
+		spec.Title = overrides.Title
+	}
+	if overrides.Content != "" {
+		spec.Content = overrides.Content
+	}
+	if len(overrides.Tags) > 0 {
+		spec.Tags = overrides.Tags
+	}
+	if len(overrides.Images) > 0 || len(overrides.ImageTokens) > 0 {
+		spec.Images = overrides.Images
+		spec.ImageTokens = overrides.ImageTokens
+	}
+	if overrides.Visibility != "" {
+		spec.Visibility = overrides.Visibility
+	}
+	if overrides.NormalizeImages {
+		spec.NormalizeImages = true
+	}
+
+	var missing []string
+	fill := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+			key := placeholderPattern.FindStringSubmatch(m)[1]
+			if v, ok := overrides.Vars[key]; ok {
+				return v
+			}
+			missing = append(missing, key)
+			return m
+		})
+	}
+	spec.Title = fill(spec.Title)
+	spec.Content = fill(spec.Content)
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("模板 %s 缺少变量: %s", t.Name, strings.Join(missing, ","))
+	}
+
+	return &spec, nil
+}
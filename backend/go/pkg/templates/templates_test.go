@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStoreSaveAndApply(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	spec := Spec{
+		Title:      "{{city}}周末探店",
+		Content:    "今天去了{{city}}的{{shop}}。\n人均：{{price}}",
+		Tags:       []string{"探店", "美食"},
+		Images:     []string{"/tmp/cover.jpg"},
+		Visibility: "仅自己可见",
+	}
+	if _, err := store.Save("weekly-探店", spec); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	tpl, err := store.Get("weekly-探店")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	result, err := tpl.Apply(Overrides{
+		Spec: Spec{Tags: []string{"杭州"}},
+		Vars: map[string]string{"city": "杭州", "shop": "面馆", "price": "30"},
+	})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.Title != "杭州周末探店" {
+		t.Errorf("Title = %q", result.Title)
+	}
+	if result.Content != "今天去了杭州的面馆。\n人均：30" {
+		t.Errorf("Content = %q", result.Content)
+	}
+	if len(result.Tags) != 1 || result.Tags[0] != "杭州" {
+		t.Errorf("Tags should be overridden, got %v", result.Tags)
+	}
+	if len(result.Images) != 1 || result.Visibility != "仅自己可见" {
+		t.Errorf("template fields should be kept, got %+v", result)
+	}
+
+	if _, err := tpl.Apply(Overrides{Vars: map[string]string{"city": "杭州"}}); err == nil {
+		t.Error("Apply should fail when variables are missing")
+	}
+
+	names, err := store.List()
+	if err != nil || len(names) != 1 {
+		t.Errorf("List = %v, %v", names, err)
+	}
+}
+
+func TestStoreRejectsInvalidNames(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"", "../evil", "a/b", "with space"} {
+		if _, err := store.Save(name, Spec{}); err == nil {
+			t.Errorf("Save(%q) should fail", name)
+		}
+	}
+
+	if _, err := store.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) = %v, expected ErrNotFound", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Package ttlcache 在内存中缓存计算代价较高的结果，过期后需重新计算，服务重启后失效。
+package ttlcache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache 按键缓存值，每个值在写入 ttl 后过期
+type Cache[V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]entry[V]
+	now     func() time.Time
+}
+
+// New 创建缓存，ttl 为值的有效期
+func New[V any](ttl time.Duration) *Cache[V] {
+	return &Cache[V]{
+		ttl:     ttl,
+		entries: make(map[string]entry[V]),
+		now:     time.Now,
+	}
+}
+
+// Get 返回未过期的值及其过期时间
+func (c *Cache[V]) Get(key string) (V, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, time.Time{}, false
+	}
+	return e.value, e.expiresAt, true
+}
+
+// Set 写入值并返回过期时间，已有的值被覆盖
+func (c *Cache[V]) Set(key string, value V) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	c.entries[key] = entry[V]{value: value, expiresAt: expiresAt}
+	return expiresAt
+}
+
+// Clear 删除所有缓存的值
+func (c *Cache[V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]entry[V])
+}
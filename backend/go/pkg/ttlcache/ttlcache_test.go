@@ -0,0 +1,62 @@
+package ttlcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSet(t *testing.T) {
+	c := New[int](time.Minute)
+
+	if _, _, ok := c.Get("a"); ok {
+		t.Fatal("empty cache returned a value")
+	}
+
+	expiresAt := c.Set("a", 1)
+	v, got, ok := c.Get("a")
+	if !ok || v != 1 {
+		t.Fatalf("Get: got %v %v", v, ok)
+	}
+	if !got.Equal(expiresAt) {
+		t.Errorf("expiresAt: got %v, want %v", got, expiresAt)
+	}
+
+	c.Set("a", 2)
+	if v, _, _ := c.Get("a"); v != 2 {
+		t.Errorf("overwrite: got %v", v)
+	}
+}
+
+func TestClear(t *testing.T) {
+	c := New[int](time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	c.Clear()
+	if _, _, ok := c.Get("a"); ok {
+		t.Error("value still cached after Clear")
+	}
+	if len(c.entries) != 0 {
+		t.Errorf("entries not removed: %d", len(c.entries))
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Now()
+	c := New[string](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", "x")
+	now = now.Add(59 * time.Second)
+	if _, _, ok := c.Get("a"); !ok {
+		t.Fatal("value expired early")
+	}
+
+	now = now.Add(time.Second)
+	if _, _, ok := c.Get("a"); ok {
+		t.Error("value not expired after ttl")
+	}
+	if len(c.entries) != 0 {
+		t.Errorf("expired entry not removed: %d", len(c.entries))
+	}
+}
@@ -0,0 +1,125 @@
+// Package webhook 调用外部 HTTP 回调：发布前审批与写操作事件通知。
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrRejected 审批回调拒绝了本次发布
+var ErrRejected = errors.New("发布被审批回调拒绝")
+
+// DefaultApprovalTimeout 审批回调的默认超时
+const DefaultApprovalTimeout = 10 * time.Second
+
+// 回调响应体最多读取的字节数
+const maxResponseBody = 64 << 10
+
+// ApprovalRequest 发给审批回调的请求体
+type ApprovalRequest struct {
+	Action      string    `json:"action"` // publish_image | publish_video
+	Spec        any       `json:"spec"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// approvalResponse 审批回调的响应体，可以为空；给出 approved 时以其为准
+type approvalResponse struct {
+	Approved *bool  `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// Approver 发布前把发布内容 POST 到审批地址，只有返回 200 且未声明 approved=false 时才放行。
+// 回调不可用（网络错误、超时、5xx）时按 FailOpen 决定放行还是拒绝；明确的拒绝不受 FailOpen 影响。
+type Approver struct {
+	URL      string
+	FailOpen bool
+	client   *http.Client
+}
+
+// NewApprover 创建审批回调，timeout 不大于 0 时使用 DefaultApprovalTimeout
+func NewApprover(url string, timeout time.Duration, failOpen bool) *Approver {
+	if timeout <= 0 {
+		timeout = DefaultApprovalTimeout
+	}
+	return &Approver{
+		URL:      url,
+		FailOpen: failOpen,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Approve 请求审批，放行时返回 nil；被拒绝时返回包装了 ErrRejected 的错误
+func (a *Approver) Approve(ctx context.Context, action string, spec any) error {
+	body, err := json.Marshal(ApprovalRequest{Action: action, Spec: spec, RequestedAt: time.Now()})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approval request")
+	}
+
+	approved, reason, err := a.post(ctx, body)
+	if err != nil {
+		if a.FailOpen {
+			logrus.Warnf("审批回调不可用，按 fail-open 放行: %v", err)
+			return nil
+		}
+		return errors.Wrapf(ErrRejected, "审批回调不可用（fail-closed）: %v", err)
+	}
+
+	if !approved {
+		if reason == "" {
+			reason = "未给出原因"
+		}
+		return errors.Wrap(ErrRejected, reason)
+	}
+
+	logrus.Infof("审批回调已放行: %s", action)
+	return nil
+}
+
+// post 调用回调并解析结果，返回的 error 表示回调不可用
+func (a *Approver) post(ctx context.Context, body []byte) (bool, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return false, "", err
+	}
+
+	var result approvalResponse
+	if len(bytes.TrimSpace(data)) > 0 {
+		// 响应体不是 JSON 时只看状态码
+		_ = json.Unmarshal(data, &result)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return false, "", fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		reason := result.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("审批回调返回状态码 %d", resp.StatusCode)
+		}
+		return false, reason, nil
+	}
+	if result.Approved != nil && !*result.Approved {
+		return false, result.Reason, nil
+	}
+	return true, "", nil
+}
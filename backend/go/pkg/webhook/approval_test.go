@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestApprove(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		failOpen bool
+		wantErr  bool
+		reason   string
+	}{
+		{name: "200 empty body", status: 200},
+		{name: "200 approved", status: 200, body: `{"approved": true}`},
+		{name: "200 rejected", status: 200, body: `{"approved": false, "reason": "需要人工审核"}`, wantErr: true, reason: "需要人工审核"},
+		{name: "403", status: 403, body: `{"reason": "不允许"}`, wantErr: true, reason: "不允许"},
+		{name: "403 ignores fail-open", status: 403, failOpen: true, wantErr: true, reason: "403"},
+		{name: "500 fail-closed", status: 500, wantErr: true, reason: "fail-closed"},
+		{name: "500 fail-open", status: 500, failOpen: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ApprovalRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode request: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := NewApprover(server.URL, time.Second, tt.failOpen).Approve(context.Background(), "publish_image", map[string]string{"title": "标题"})
+			if got.Action != "publish_image" {
+				t.Errorf("action = %q", got.Action)
+			}
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrRejected) {
+				t.Fatalf("got %v, want ErrRejected", err)
+			}
+			if !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("error %q does not contain %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestApproveUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	if err := NewApprover(server.URL, 50*time.Millisecond, false).Approve(context.Background(), "publish_video", nil); !errors.Is(err, ErrRejected) {
+		t.Errorf("fail-closed timeout: got %v, want ErrRejected", err)
+	}
+	if err := NewApprover(server.URL, 50*time.Millisecond, true).Approve(context.Background(), "publish_video", nil); err != nil {
+		t.Errorf("fail-open timeout: got %v, want nil", err)
+	}
+}
@@ -0,0 +1,323 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// 写操作事件
+const (
+	EventPublishImage            = "publish_image"
+	EventPublishVideo            = "publish_video"
+	EventPostComment             = "post_comment"
+	EventDeleteComment           = "delete_comment"
+	EventLike                    = "like"
+	EventUnlike                  = "unlike"
+	EventFavorite                = "favorite"
+	EventUnfavorite              = "unfavorite"
+	EventSetAutoReply            = "set_auto_reply"
+	EventSetNotificationSettings = "set_notification_settings"
+	EventUpdateProfile           = "update_profile"
+)
+
+var allEvents = []string{
+	EventPublishImage, EventPublishVideo, EventPostComment, EventDeleteComment,
+	EventLike, EventUnlike, EventFavorite, EventUnfavorite,
+	EventSetAutoReply, EventSetNotificationSettings, EventUpdateProfile,
+}
+
+const (
+	// DefaultEventRetries 事件投递失败后的重试次数
+	DefaultEventRetries = 3
+	// DefaultEventTimeout 单次投递的超时
+	DefaultEventTimeout = 10 * time.Second
+)
+
+// Event 写操作完成后发送的事件
+type Event struct {
+	Action    string    `json:"action"`
+	Platform  string    `json:"platform"`
+	Account   string    `json:"account"`
+	NoteID    string    `json:"note_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Result    any       `json:"result"`
+}
+
+// deadLetter 多次投递仍失败的事件，追加写入死信文件
+type deadLetter struct {
+	Event    Event     `json:"event"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// EventNames 返回所有可订阅的事件
+func EventNames() []string {
+	names := append([]string(nil), allEvents...)
+	sort.Strings(names)
+	return names
+}
+
+// ParseEvents 解析逗号分隔的事件列表，为空或 all 表示全部事件
+func ParseEvents(s string) ([]string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "all" {
+		return EventNames(), nil
+	}
+
+	var events []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !isEvent(name) {
+			return nil, fmt.Errorf("无效的事件 %q，可选值: %s", name, strings.Join(EventNames(), "|"))
+		}
+		events = append(events, name)
+	}
+	return events, nil
+}
+
+func isEvent(name string) bool {
+	for _, e := range allEvents {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Emitter 把写操作事件异步 POST 到回调地址，失败时按指数退避重试，
+// 仍然失败的事件写入死信文件（JSON Lines），不影响写操作本身的结果。
+type Emitter struct {
+	url            string
+	events         map[string]bool
+	deadLetterPath string
+	retries        int
+	backoff        time.Duration
+	client         *http.Client
+
+	wg sync.WaitGroup
+	mu sync.Mutex // 保护死信文件的写入
+
+	pendingMu sync.Mutex
+	seq       uint64
+	pending   map[uint64]*pendingEvent // 尚未投递成功或写入死信的事件
+}
+
+// pendingEvent 投递中的事件及已尝试的次数
+type pendingEvent struct {
+	event    Event
+	attempts int
+	lastErr  error
+}
+
+// NewEmitter 创建事件发送器，events 为订阅的事件
+func NewEmitter(url string, events []string, deadLetterPath string) *Emitter {
+	subscribed := make(map[string]bool, len(events))
+	for _, e := range events {
+		subscribed[e] = true
+	}
+	return &Emitter{
+		url:            url,
+		events:         subscribed,
+		deadLetterPath: deadLetterPath,
+		retries:        DefaultEventRetries,
+		backoff:        time.Second,
+		client:         &http.Client{Timeout: DefaultEventTimeout},
+		pending:        make(map[uint64]*pendingEvent),
+	}
+}
+
+// Enabled 是否订阅了该事件
+func (e *Emitter) Enabled(action string) bool {
+	return e != nil && e.events[action]
+}
+
+// Emit 异步发送事件，未订阅的事件直接忽略
+func (e *Emitter) Emit(event Event) {
+	if !e.Enabled(event.Action) {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	e.pendingMu.Lock()
+	e.seq++
+	id := e.seq
+	e.pending[id] = &pendingEvent{event: event}
+	e.pendingMu.Unlock()
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.deliver(id, event)
+	}()
+}
+
+// Wait 等待已发出的事件投递完成（含重试）
+func (e *Emitter) Wait() {
+	e.wg.Wait()
+}
+
+// Close 关闭服务时调用：最多等待 timeout 让已发出的事件投递完成（含重试），
+// 超时后仍未完成的事件直接写入死信文件，返回写入死信的事件数
+func (e *Emitter) Close(timeout time.Duration) int {
+	if e == nil {
+		return 0
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return 0
+	case <-time.After(timeout):
+	}
+
+	e.pendingMu.Lock()
+	ids := make([]uint64, 0, len(e.pending))
+	for id := range e.pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	letters := make([]deadLetter, 0, len(ids))
+	for _, id := range ids {
+		p := e.pending[id]
+		msg := "服务关闭前未完成投递"
+		if p.lastErr != nil {
+			msg += ": " + p.lastErr.Error()
+		}
+		letters = append(letters, deadLetter{Event: p.event, Error: msg, Attempts: p.attempts, FailedAt: time.Now()})
+		delete(e.pending, id)
+	}
+	e.pendingMu.Unlock()
+
+	for _, letter := range letters {
+		e.writeDeadLetter(letter)
+	}
+	return len(letters)
+}
+
+// record 记录一次投递尝试的结果
+func (e *Emitter) record(id uint64, err error) {
+	e.pendingMu.Lock()
+	defer e.pendingMu.Unlock()
+	if p, ok := e.pending[id]; ok {
+		p.attempts++
+		p.lastErr = err
+	}
+}
+
+// finish 投递结束时移除事件，返回 false 表示事件已在 Close 时写入死信
+func (e *Emitter) finish(id uint64) bool {
+	e.pendingMu.Lock()
+	defer e.pendingMu.Unlock()
+	if _, ok := e.pending[id]; !ok {
+		return false
+	}
+	delete(e.pending, id)
+	return true
+}
+
+// deliver 投递事件，失败时重试，重试耗尽后写入死信文件
+func (e *Emitter) deliver(id uint64, event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		e.finish(id)
+		logrus.Errorf("事件序列化失败: %s %v", event.Action, err)
+		return
+	}
+
+	attempts := e.retries + 1
+	backoff := e.backoff
+	for i := 1; i <= attempts; i++ {
+		if err = e.post(body); err == nil {
+			e.finish(id)
+			logrus.Debugf("事件已投递: %s", event.Action)
+			return
+		}
+		e.record(id, err)
+		logrus.Warnf("事件投递失败（第 %d/%d 次）: %s %v", i, attempts, event.Action, err)
+		if i < attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	if !e.finish(id) {
+		return
+	}
+	e.writeDeadLetter(deadLetter{Event: event, Error: err.Error(), Attempts: attempts, FailedAt: time.Now()})
+}
+
+func (e *Emitter) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *Emitter) writeDeadLetter(letter deadLetter) {
+	logrus.Errorf("事件多次投递失败，写入死信文件 %s: %s %s", e.deadLetterPath, letter.Event.Action, letter.Error)
+
+	line, err := json.Marshal(letter)
+	if err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := appendLine(e.deadLetterPath, line); err != nil {
+		logrus.Errorf("写入死信文件失败: %v", err)
+	}
+}
+
+func appendLine(path string, line []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create dead letter dir")
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to open dead letter file")
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseEvents(t *testing.T) {
+	all, err := ParseEvents("")
+	if err != nil || len(all) != len(allEvents) {
+		t.Fatalf("empty: got %v, %v", all, err)
+	}
+
+	events, err := ParseEvents(" publish_image, post_comment ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(events, ",") != "publish_image,post_comment" {
+		t.Errorf("got %v", events)
+	}
+
+	if _, err := ParseEvents("publish_image,delete_everything"); err == nil {
+		t.Error("unknown event accepted")
+	}
+}
+
+func TestEmitterDelivers(t *testing.T) {
+	var got Event
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 第一次失败，验证重试
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	deadLetters := filepath.Join(t.TempDir(), "dead.jsonl")
+	e := NewEmitter(server.URL, []string{EventPostComment}, deadLetters)
+	e.backoff = time.Millisecond
+
+	e.Emit(Event{Action: EventLike, NoteID: "ignored"})
+	e.Emit(Event{Action: EventPostComment, NoteID: "n1", Account: "me", Result: map[string]bool{"success": true}})
+	e.Wait()
+
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if got.Action != EventPostComment || got.NoteID != "n1" || got.Timestamp.IsZero() {
+		t.Errorf("unexpected event: %+v", got)
+	}
+	if _, err := os.Stat(deadLetters); !os.IsNotExist(err) {
+		t.Errorf("dead letter file should not exist: %v", err)
+	}
+}
+
+func TestEmitterDeadLetter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	deadLetters := filepath.Join(t.TempDir(), "events", "dead.jsonl")
+	e := NewEmitter(server.URL, []string{EventPublishImage}, deadLetters)
+	e.backoff = time.Millisecond
+	e.retries = 2
+
+	e.Emit(Event{Action: EventPublishImage, NoteID: "n1"})
+	e.Wait()
+
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+
+	data, err := os.ReadFile(deadLetters)
+	if err != nil {
+		t.Fatalf("read dead letters: %v", err)
+	}
+	var letter deadLetter
+	if err := json.Unmarshal(data, &letter); err != nil {
+		t.Fatalf("unmarshal dead letter: %v", err)
+	}
+	if letter.Event.NoteID != "n1" || letter.Attempts != 3 || !strings.Contains(letter.Error, "500") {
+		t.Errorf("unexpected dead letter: %+v", letter)
+	}
+}
+
+func TestEmitterCloseWritesPending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	deadLetters := filepath.Join(t.TempDir(), "dead.jsonl")
+	e := NewEmitter(server.URL, []string{EventLike}, deadLetters)
+	e.backoff = time.Hour
+
+	e.Emit(Event{Action: EventLike, NoteID: "n2"})
+	time.Sleep(100 * time.Millisecond)
+
+	if n := e.Close(50 * time.Millisecond); n != 1 {
+		t.Fatalf("Close() = %d, want 1", n)
+	}
+
+	data, err := os.ReadFile(deadLetters)
+	if err != nil {
+		t.Fatalf("read dead letters: %v", err)
+	}
+	var letter deadLetter
+	if err := json.Unmarshal(data, &letter); err != nil {
+		t.Fatalf("unmarshal dead letter: %v", err)
+	}
+	if letter.Event.NoteID != "n2" || letter.Attempts != 1 || !strings.Contains(letter.Error, "502") {
+		t.Errorf("unexpected dead letter: %+v", letter)
+	}
+}
+
+func TestEmitterCloseWaits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	deadLetters := filepath.Join(t.TempDir(), "dead.jsonl")
+	e := NewEmitter(server.URL, []string{EventLike}, deadLetters)
+	e.Emit(Event{Action: EventLike})
+
+	if n := e.Close(5 * time.Second); n != 0 {
+		t.Errorf("Close() = %d, want 0", n)
+	}
+	if _, err := os.Stat(deadLetters); !os.IsNotExist(err) {
+		t.Errorf("dead letter file should not exist: %v", err)
+	}
+
+	var nilEmitter *Emitter
+	if n := nilEmitter.Close(time.Second); n != 0 {
+		t.Errorf("nil Close() = %d, want 0", n)
+	}
+}
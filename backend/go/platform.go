@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// DefaultPlatform 未指定 -platform 时使用的平台
+const DefaultPlatform = "xiaohongshu"
+
+// Platform 内容平台的业务能力，MCP 工具与 HTTP API 只通过该接口访问平台。
+// 其他平台（如抖音、微博）实现该接口并通过 RegisterPlatform 注册后即可复用同一套工具；
+// 不支持的能力应返回明确的错误。
+type Platform interface {
+	// 登录与 cookies
+	CheckLoginStatus(ctx context.Context) (*LoginStatusResponse, error)
+	GetLoginQrcode(ctx context.Context) (*LoginQrcodeResponse, error)
+	GetCookiesInfo(ctx context.Context) (*CookiesInfo, error)
+	DeleteCookies(ctx context.Context) error
+
+	// 发布
+	PublishContent(ctx context.Context, req *PublishRequest) (*PublishResponse, error)
+	PublishVideo(ctx context.Context, req *PublishVideoRequest) (*PublishVideoResponse, error)
+	PreviewPublish(title, content string, tags []string) *PublishPreview
+	UploadImages(ctx context.Context, images []string) (*UploadImagesResponse, error)
+	GetEditorConfig(ctx context.Context) (*xiaohongshu.EditorConfig, error)
+	SavePublishTemplate(name string, spec templates.Spec) (*templates.Template, error)
+	ListPublishTemplates() ([]string, error)
+	PublishFromTemplate(ctx context.Context, name string, overrides templates.Overrides) (*PublishResponse, error)
+
+	// 浏览与搜索
+	ListFeeds(ctx context.Context, paginate PaginateRequest) (*FeedsListResponse, error)
+	SearchFeeds(ctx context.Context, keyword string, paginate PaginateRequest, resetFilters bool, filters ...xiaohongshu.FilterOption) (*FeedsListResponse, error)
+	GetSearchFilters(ctx context.Context, keyword string) (*xiaohongshu.SearchFilterState, error)
+	CheckKeyword(ctx context.Context, keyword string) (*xiaohongshu.KeywordCheck, error)
+	GetFeedDetail(ctx context.Context, feedID, xsecToken string, fields []string, includeEngagement bool) (*FeedDetailResponse, error)
+	GetNoteTypes(ctx context.Context, refs []xiaohongshu.NoteRef) (*NoteTypesResponse, error)
+	GetNoteCollaborators(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteCollaboration, error)
+	GetNoteTaggedUsers(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteTaggedUsers, error)
+	GetNoteReposts(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteReposts, error)
+	IsMyNote(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteOwnership, error)
+	GetNoteSettings(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteSettings, error)
+	GetVideoCover(ctx context.Context, feedID, xsecToken string, download bool) (*xiaohongshu.VideoCover, error)
+	GetNoteMusic(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteMusic, error)
+	CheckNoteDiscoverability(ctx context.Context, feedID, xsecToken string, keywords []string) (*xiaohongshu.NoteDiscoverability, error)
+	ExportNotes(ctx context.Context, req *ExportNotesRequest, emit func(xiaohongshu.Feed) error) (*ExportSummary, error)
+	UserProfile(ctx context.Context, userID, xsecToken string) (*UserProfileResponse, error)
+	GetMyProfile(ctx context.Context) (*UserProfileResponse, error)
+	CheckMutual(ctx context.Context, refs []xiaohongshu.UserRef) (*MutualStatusResponse, error)
+	UpdateProfile(ctx context.Context, update xiaohongshu.ProfileUpdate) (*xiaohongshu.ProfileUpdateResult, error)
+	GetViewHistory(ctx context.Context, cursor string) (*xiaohongshu.ViewHistory, error)
+	GetBlockedUsers(ctx context.Context, cursor string) (*xiaohongshu.BlockedUsers, error)
+	GetMutedKeywords(ctx context.Context, cursor string) (*xiaohongshu.MutedKeywords, error)
+	GetAccountRegion(ctx context.Context) (*xiaohongshu.AccountRegion, error)
+	GetAccountAggregateStats(ctx context.Context, maxNotes int, refresh bool, progress xiaohongshu.ProgressFunc) (*xiaohongshu.AccountAggregateStats, error)
+
+	// 评论与互动
+	GetNoteComments(ctx context.Context, feedID, xsecToken, sort string, maxDepth int) (*NoteCommentsResponse, error)
+	StreamNoteComments(ctx context.Context, feedID, xsecToken string, interval time.Duration, emit func([]xiaohongshu.Comment) error) error
+	GetNoteThread(ctx context.Context, feedID, xsecToken string, maxComments int) (*xiaohongshu.NoteThread, error)
+	ArchiveNoteScreenshot(ctx context.Context, feedID, xsecToken string, maxComments int) (*xiaohongshu.NoteScreenshot, error)
+	GetVideoComments(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.VideoCommentsResult, error)
+	PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string) (*PostCommentResponse, error)
+	GetMyComments(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.MyComments, error)
+	DeleteComment(ctx context.Context, ref xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteResult, error)
+	BatchDeleteComments(ctx context.Context, refs []xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteSummary, error)
+	LikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error)
+	UnlikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error)
+	FavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error)
+	UnfavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error)
+
+	// 账号与环境
+	GetEarnings(ctx context.Context, period string) (*xiaohongshu.Earnings, error)
+	GetBestPostingTimes(ctx context.Context) (*xiaohongshu.PostingTimes, error)
+	GetAutoReply(ctx context.Context) (*xiaohongshu.AutoReplySettings, error)
+	SetAutoReply(ctx context.Context, text string, enabled bool) (*xiaohongshu.AutoReplySettings, error)
+	GetNotificationSettings(ctx context.Context) (*xiaohongshu.NotificationSettings, error)
+	SetNotificationSettings(ctx context.Context, settings map[string]bool) (*xiaohongshu.NotificationSettings, error)
+	TestProxy(ctx context.Context, proxyURL string) (*ProxyTestResponse, error)
+}
+
+var _ Platform = (*XiaohongshuService)(nil)
+
+// platforms 已注册的平台，值为创建实例的函数
+var platforms = map[string]func() Platform{
+	DefaultPlatform: func() Platform { return NewXiaohongshuService() },
+}
+
+// RegisterPlatform 注册平台实现，需在 main 解析 -platform 之前调用（如在 init 中）
+func RegisterPlatform(name string, factory func() Platform) {
+	if _, exists := platforms[name]; exists {
+		panic(fmt.Sprintf("platform %q already registered", name))
+	}
+	platforms[name] = factory
+}
+
+// PlatformNames 返回已注册的平台名称
+func PlatformNames() []string {
+	names := make([]string, 0, len(platforms))
+	for name := range platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewPlatform 按名称创建平台实例
+func NewPlatform(name string) (Platform, error) {
+	factory, ok := platforms[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown platform %q, available: %s", name, strings.Join(PlatformNames(), "|"))
+	}
+	return factory(), nil
+}
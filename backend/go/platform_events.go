@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/webhook"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// eventPlatform 在写操作成功后发送事件，其余方法直接交给被包装的平台
+type eventPlatform struct {
+	Platform
+	name    string
+	emitter *webhook.Emitter
+}
+
+// withEvents 为平台加上写操作事件通知
+func withEvents(p Platform, name string, emitter *webhook.Emitter) Platform {
+	return &eventPlatform{Platform: p, name: name, emitter: emitter}
+}
+
+func (p *eventPlatform) emit(action, noteID string, result any) {
+	p.emitter.Emit(webhook.Event{
+		Action:   action,
+		Platform: p.name,
+		Account:  configs.Username,
+		NoteID:   noteID,
+		Result:   result,
+	})
+}
+
+func (p *eventPlatform) PublishContent(ctx context.Context, req *PublishRequest) (*PublishResponse, error) {
+	result, err := p.Platform.PublishContent(ctx, req)
+	if err == nil {
+		p.emit(webhook.EventPublishImage, result.PostID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) PublishVideo(ctx context.Context, req *PublishVideoRequest) (*PublishVideoResponse, error) {
+	result, err := p.Platform.PublishVideo(ctx, req)
+	if err == nil {
+		p.emit(webhook.EventPublishVideo, result.PostID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) PublishFromTemplate(ctx context.Context, name string, overrides templates.Overrides) (*PublishResponse, error) {
+	result, err := p.Platform.PublishFromTemplate(ctx, name, overrides)
+	if err == nil {
+		p.emit(webhook.EventPublishImage, result.PostID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string) (*PostCommentResponse, error) {
+	result, err := p.Platform.PostCommentToFeed(ctx, feedID, xsecToken, content)
+	if err == nil {
+		p.emit(webhook.EventPostComment, feedID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) LikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	result, err := p.Platform.LikeFeed(ctx, feedID, xsecToken)
+	if err == nil {
+		p.emit(webhook.EventLike, feedID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) UnlikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	result, err := p.Platform.UnlikeFeed(ctx, feedID, xsecToken)
+	if err == nil {
+		p.emit(webhook.EventUnlike, feedID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) FavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	result, err := p.Platform.FavoriteFeed(ctx, feedID, xsecToken)
+	if err == nil {
+		p.emit(webhook.EventFavorite, feedID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) UnfavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	result, err := p.Platform.UnfavoriteFeed(ctx, feedID, xsecToken)
+	if err == nil {
+		p.emit(webhook.EventUnfavorite, feedID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) SetAutoReply(ctx context.Context, text string, enabled bool) (*xiaohongshu.AutoReplySettings, error) {
+	result, err := p.Platform.SetAutoReply(ctx, text, enabled)
+	if err == nil {
+		p.emit(webhook.EventSetAutoReply, "", result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) SetNotificationSettings(ctx context.Context, settings map[string]bool) (*xiaohongshu.NotificationSettings, error) {
+	result, err := p.Platform.SetNotificationSettings(ctx, settings)
+	if err == nil {
+		p.emit(webhook.EventSetNotificationSettings, "", result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) UpdateProfile(ctx context.Context, update xiaohongshu.ProfileUpdate) (*xiaohongshu.ProfileUpdateResult, error) {
+	result, err := p.Platform.UpdateProfile(ctx, update)
+	if err == nil {
+		p.emit(webhook.EventUpdateProfile, "", result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) DeleteComment(ctx context.Context, ref xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteResult, error) {
+	result, err := p.Platform.DeleteComment(ctx, ref)
+	if err == nil {
+		p.emit(webhook.EventDeleteComment, ref.FeedID, result)
+	}
+	return result, err
+}
+
+func (p *eventPlatform) BatchDeleteComments(ctx context.Context, refs []xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteSummary, error) {
+	summary, err := p.Platform.BatchDeleteComments(ctx, refs)
+	if err == nil {
+		for _, result := range summary.Results {
+			if result.Deleted {
+				p.emit(webhook.EventDeleteComment, result.FeedID, result)
+			}
+		}
+	}
+	return summary, err
+}
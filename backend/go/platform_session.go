@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// sessionCheckPlatform 在每次写操作前重新检查登录状态，登录失效时直接返回 ErrSessionExpired，
+// 避免在发布等多步操作进行到一半时才发现会话过期。读操作直接交给被包装的平台。
+type sessionCheckPlatform struct {
+	Platform
+}
+
+// withSessionCheck 为平台加上写操作前的登录检查
+func withSessionCheck(p Platform) Platform {
+	return &sessionCheckPlatform{Platform: p}
+}
+
+// requireSession 检查登录状态，未登录或检查失败时返回错误
+func (p *sessionCheckPlatform) requireSession(ctx context.Context, action string) error {
+	status, err := p.Platform.CheckLoginStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("%s前检查登录状态失败: %w", action, err)
+	}
+	if !status.IsLoggedIn {
+		logrus.Warnf("%s前发现登录已失效，已中止", action)
+		return fmt.Errorf("%w（%s未执行）", errors.ErrSessionExpired, action)
+	}
+	return nil
+}
+
+func (p *sessionCheckPlatform) PublishContent(ctx context.Context, req *PublishRequest) (*PublishResponse, error) {
+	if err := p.requireSession(ctx, "发布"); err != nil {
+		return nil, err
+	}
+	return p.Platform.PublishContent(ctx, req)
+}
+
+func (p *sessionCheckPlatform) PublishVideo(ctx context.Context, req *PublishVideoRequest) (*PublishVideoResponse, error) {
+	if err := p.requireSession(ctx, "发布视频"); err != nil {
+		return nil, err
+	}
+	return p.Platform.PublishVideo(ctx, req)
+}
+
+func (p *sessionCheckPlatform) PublishFromTemplate(ctx context.Context, name string, overrides templates.Overrides) (*PublishResponse, error) {
+	if err := p.requireSession(ctx, "按模板发布"); err != nil {
+		return nil, err
+	}
+	return p.Platform.PublishFromTemplate(ctx, name, overrides)
+}
+
+func (p *sessionCheckPlatform) PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string) (*PostCommentResponse, error) {
+	if err := p.requireSession(ctx, "发表评论"); err != nil {
+		return nil, err
+	}
+	return p.Platform.PostCommentToFeed(ctx, feedID, xsecToken, content)
+}
+
+func (p *sessionCheckPlatform) LikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	if err := p.requireSession(ctx, "点赞"); err != nil {
+		return nil, err
+	}
+	return p.Platform.LikeFeed(ctx, feedID, xsecToken)
+}
+
+func (p *sessionCheckPlatform) UnlikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	if err := p.requireSession(ctx, "取消点赞"); err != nil {
+		return nil, err
+	}
+	return p.Platform.UnlikeFeed(ctx, feedID, xsecToken)
+}
+
+func (p *sessionCheckPlatform) FavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	if err := p.requireSession(ctx, "收藏"); err != nil {
+		return nil, err
+	}
+	return p.Platform.FavoriteFeed(ctx, feedID, xsecToken)
+}
+
+func (p *sessionCheckPlatform) UnfavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
+	if err := p.requireSession(ctx, "取消收藏"); err != nil {
+		return nil, err
+	}
+	return p.Platform.UnfavoriteFeed(ctx, feedID, xsecToken)
+}
+
+func (p *sessionCheckPlatform) UpdateProfile(ctx context.Context, update xiaohongshu.ProfileUpdate) (*xiaohongshu.ProfileUpdateResult, error) {
+	if err := p.requireSession(ctx, "修改资料"); err != nil {
+		return nil, err
+	}
+	return p.Platform.UpdateProfile(ctx, update)
+}
+
+func (p *sessionCheckPlatform) SetAutoReply(ctx context.Context, text string, enabled bool) (*xiaohongshu.AutoReplySettings, error) {
+	if err := p.requireSession(ctx, "修改自动回复"); err != nil {
+		return nil, err
+	}
+	return p.Platform.SetAutoReply(ctx, text, enabled)
+}
+
+func (p *sessionCheckPlatform) SetNotificationSettings(ctx context.Context, settings map[string]bool) (*xiaohongshu.NotificationSettings, error) {
+	if err := p.requireSession(ctx, "修改通知设置"); err != nil {
+		return nil, err
+	}
+	return p.Platform.SetNotificationSettings(ctx, settings)
+}
+
+func (p *sessionCheckPlatform) DeleteComment(ctx context.Context, ref xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteResult, error) {
+	if err := p.requireSession(ctx, "删除评论"); err != nil {
+		return nil, err
+	}
+	return p.Platform.DeleteComment(ctx, ref)
+}
+
+func (p *sessionCheckPlatform) BatchDeleteComments(ctx context.Context, refs []xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteSummary, error) {
+	if err := p.requireSession(ctx, "批量删除评论"); err != nil {
+		return nil, err
+	}
+	return p.Platform.BatchDeleteComments(ctx, refs)
+}
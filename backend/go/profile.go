@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/sirupsen/logrus"
+)
+
+// startProfileServer 在 addr 上提供 net/http/pprof，与 MCP/HTTP API 使用不同端口。
+// pprof 会暴露堆栈和内存内容，因此只允许监听回环地址。返回实际监听地址。
+func startProfileServer(addr string) (string, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("无效的地址 %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", fmt.Errorf("pprof 只允许监听回环地址（如 127.0.0.1:6060），当前为 %q", addr)
+	}
+
+	// 不注册到 http.DefaultServeMux，避免其他服务意外暴露 pprof
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			logrus.Errorf("pprof 服务退出: %v", err)
+		}
+	}()
+
+	return listener.Addr().String(), nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+)
+
+// PublishQueueState 发布队列状态
+type PublishQueueState struct {
+	Concurrency int   `json:"concurrency"` // 同时执行的发布数上限
+	Active      int   `json:"active"`      // 正在执行的发布数
+	Waiting     int   `json:"waiting"`     // 排队等待的发布数（队列深度）
+	Completed   int64 `json:"completed"`   // 已结束的发布数（含失败）
+}
+
+// publishQueue 限制整个服务同时执行的发布数，超出的发布按到达顺序排队
+type publishQueue struct {
+	slots chan struct{}
+
+	mu        sync.Mutex
+	waiting   int
+	active    int
+	completed int64
+}
+
+func newPublishQueue(concurrency int) *publishQueue {
+	return &publishQueue{slots: make(chan struct{}, concurrency)}
+}
+
+// acquire 等待一个发布名额，返回结束时调用的 release；ctx 取消时放弃排队
+func (q *publishQueue) acquire(ctx context.Context, action string) (func(), error) {
+	q.mu.Lock()
+	q.waiting++
+	if q.active >= cap(q.slots) {
+		logrus.Infof("%s排队中：正在执行 %d 个发布，等待 %d 个", action, q.active, q.waiting)
+	}
+	q.mu.Unlock()
+
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		q.mu.Lock()
+		q.waiting--
+		q.mu.Unlock()
+		return nil, fmt.Errorf("等待发布队列时取消（%s未执行）: %w", action, ctx.Err())
+	}
+
+	q.mu.Lock()
+	q.waiting--
+	q.active++
+	q.mu.Unlock()
+
+	return func() {
+		q.mu.Lock()
+		q.active--
+		q.completed++
+		q.mu.Unlock()
+		<-q.slots
+	}, nil
+}
+
+func (q *publishQueue) state() *PublishQueueState {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return &PublishQueueState{
+		Concurrency: cap(q.slots),
+		Active:      q.active,
+		Waiting:     q.waiting,
+		Completed:   q.completed,
+	}
+}
+
+// queuedPlatform 发布前先在全局发布队列中排队，其余方法直接交给被包装的平台
+type queuedPlatform struct {
+	Platform
+	queue *publishQueue
+}
+
+// withPublishQueue 为平台加上全局发布队列
+func withPublishQueue(p Platform, queue *publishQueue) Platform {
+	return &queuedPlatform{Platform: p, queue: queue}
+}
+
+func (p *queuedPlatform) PublishContent(ctx context.Context, req *PublishRequest) (*PublishResponse, error) {
+	release, err := p.queue.acquire(ctx, "发布")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.Platform.PublishContent(ctx, req)
+}
+
+func (p *queuedPlatform) PublishVideo(ctx context.Context, req *PublishVideoRequest) (*PublishVideoResponse, error) {
+	release, err := p.queue.acquire(ctx, "发布视频")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.Platform.PublishVideo(ctx, req)
+}
+
+func (p *queuedPlatform) PublishFromTemplate(ctx context.Context, name string, overrides templates.Overrides) (*PublishResponse, error) {
+	release, err := p.queue.acquire(ctx, "按模板发布")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.Platform.PublishFromTemplate(ctx, name, overrides)
+}
@@ -7,12 +7,21 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// setupRoutes 设置路由配置
+// setupRoutes 设置路由配置（单端口模式：MCP 与 HTTP API 共用一个 router）
 func setupRoutes(appServer *AppServer) *gin.Engine {
+	router := newRouter()
+	registerMCPRoutes(router, appServer)
+	registerAPIRoutes(router, appServer)
+	return router
+}
+
+// newRouter 创建带通用中间件的 router
+func newRouter() *gin.Engine {
 	// 设置 Gin 模式
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
+	router.Use(requestTimingMiddleware())
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 
@@ -20,9 +29,11 @@ func setupRoutes(appServer *AppServer) *gin.Engine {
 	router.Use(errorHandlingMiddleware())
 	router.Use(corsMiddleware())
 
-	// 健康检查
-	router.GET("/health", healthHandler)
+	return router
+}
 
+// registerMCPRoutes 注册 MCP 端点
+func registerMCPRoutes(router *gin.Engine, appServer *AppServer) {
 	// MCP 端点 - 使用官方 SDK 的 Streamable HTTP Handler
 	mcpHandler := mcp.NewStreamableHTTPHandler(
 		func(r *http.Request) *mcp.Server {
@@ -34,24 +45,72 @@ func setupRoutes(appServer *AppServer) *gin.Engine {
 	)
 	router.Any("/mcp", gin.WrapH(mcpHandler))
 	router.Any("/mcp/*path", gin.WrapH(mcpHandler))
+}
+
+// registerAPIRoutes 注册健康检查与 HTTP API
+func registerAPIRoutes(router *gin.Engine, appServer *AppServer) {
+	// 健康检查
+	router.GET("/health", healthHandler)
 
 	// API 路由组
 	api := router.Group("/api/v1")
+	api.Use(serverState.httpMiddleware())
 	{
 		api.GET("/login/status", appServer.checkLoginStatusHandler)
 		api.GET("/login/qrcode", appServer.getLoginQrcodeHandler)
 		api.GET("/login/cookies/info", appServer.getCookiesInfoHandler)
 		api.DELETE("/login/cookies", appServer.deleteCookiesHandler)
 		api.POST("/publish", appServer.publishHandler)
+		api.POST("/publish/upload_images", appServer.uploadImagesHandler)
+		api.GET("/publish/templates", appServer.listPublishTemplatesHandler)
+		api.POST("/publish/templates", appServer.savePublishTemplateHandler)
+		api.POST("/publish/templates/:name/publish", appServer.publishFromTemplateHandler)
 		api.POST("/publish_video", appServer.publishVideoHandler)
 		api.GET("/feeds/list", appServer.listFeedsHandler)
 		api.GET("/feeds/search", appServer.searchFeedsHandler)
 		api.POST("/feeds/search", appServer.searchFeedsHandler)
+		api.GET("/feeds/search/check", appServer.checkKeywordHandler)
+		api.GET("/feeds/search/filters", appServer.searchFiltersHandler)
 		api.POST("/feeds/detail", appServer.getFeedDetailHandler)
 		api.POST("/user/profile", appServer.userProfileHandler)
 		api.POST("/feeds/comment", appServer.postCommentHandler)
 		api.GET("/user/me", appServer.myProfileHandler)
+		api.POST("/user/me", appServer.updateProfileHandler)
+		api.POST("/user/mutual", appServer.checkMutualHandler)
+		api.GET("/publish/editor_config", appServer.editorConfigHandler)
+		api.POST("/feeds/type", appServer.noteTypeHandler)
+		api.POST("/feeds/comments", appServer.noteCommentsHandler)
+		api.POST("/feeds/thread", appServer.noteThreadHandler)
+		api.POST("/feeds/archive_screenshot", appServer.noteScreenshotHandler)
+		api.POST("/feeds/my_comments", appServer.myCommentsHandler)
+		api.POST("/feeds/comments/delete", appServer.deleteCommentHandler)
+		api.POST("/feeds/comments/batch_delete", appServer.batchDeleteCommentsHandler)
+		api.POST("/feeds/video_comments", appServer.videoCommentsHandler)
+		api.POST("/feeds/collaborators", appServer.noteCollaboratorsHandler)
+		api.POST("/feeds/tagged_users", appServer.noteTaggedUsersHandler)
+		api.POST("/feeds/reposts", appServer.noteRepostsHandler)
+		api.POST("/feeds/is_mine", appServer.isMyNoteHandler)
+		api.POST("/feeds/settings", appServer.noteSettingsHandler)
+		api.POST("/feeds/video_cover", appServer.videoCoverHandler)
+		api.POST("/feeds/music", appServer.noteMusicHandler)
+		api.POST("/feeds/discoverability", appServer.noteDiscoverabilityHandler)
+		api.GET("/notes/:id/comments/stream", appServer.commentStreamHandler)
+		api.GET("/export/notes", appServer.exportNotesHandler)
+		api.GET("/crawl/plan", appServer.planCrawlHandler)
+		api.GET("/server/state", appServer.serverStateHandler)
+		api.GET("/server/config", appServer.configHandler)
+		api.GET("/enums", appServer.enumsHandler)
+		api.GET("/creator/earnings", appServer.earningsHandler)
+		api.GET("/creator/posting_times", appServer.bestPostingTimesHandler)
+		api.GET("/account/view_history", appServer.viewHistoryHandler)
+		api.GET("/account/region", appServer.accountRegionHandler)
+		api.GET("/account/aggregate_stats", appServer.accountAggregateStatsHandler)
+		api.GET("/account/blocked_users", appServer.blockedUsersHandler)
+		api.GET("/account/muted_keywords", appServer.mutedKeywordsHandler)
+		api.GET("/account/auto_reply", appServer.getAutoReplyHandler)
+		api.POST("/account/auto_reply", appServer.setAutoReplyHandler)
+		api.GET("/account/notification_settings", appServer.getNotificationSettingsHandler)
+		api.POST("/account/notification_settings", appServer.setNotificationSettingsHandler)
+		api.POST("/proxy/test", appServer.proxyTestHandler)
 	}
-
-	return router
 }
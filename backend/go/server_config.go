@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redactedValue 替换敏感信息的占位符
+const redactedValue = "***"
+
+// 视为敏感信息的 URL 查询参数（小写比较）
+var secretQueryKeys = []string{"token", "key", "secret", "sign", "signature", "password", "passwd", "auth", "credential"}
+
+// ServerConfig 服务启动时解析出的生效配置（命令行参数、环境变量与默认值合并后）
+type ServerConfig struct {
+	Platform string `json:"platform"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	APIAddr  string `json:"api_addr,omitempty"`
+	Desktop  bool   `json:"desktop"`
+	DataDir  string `json:"data_dir"`
+
+	Headless              bool   `json:"headless"`
+	HeadfulFallback       bool   `json:"headful_fallback"`
+	BinPath               string `json:"bin_path,omitempty"`
+	BrowserLaunchAttempts int    `json:"browser_launch_attempts"`
+	BrowserLaunchBackoff  string `json:"browser_launch_backoff"`
+
+	UIVariant        string `json:"ui_variant"`
+	AutoDismissGates bool   `json:"auto_dismiss_gates"`
+	PageInterval     string `json:"page_interval"`
+
+	MaxResponseBytes int    `json:"max_response_bytes"`
+	ResponseEnvelope string `json:"response_envelope"`
+
+	PrePublishWebhook  string `json:"prepublish_webhook,omitempty"`
+	PrePublishTimeout  string `json:"prepublish_timeout"`
+	PrePublishFailOpen bool   `json:"prepublish_fail_open"`
+
+	EventWebhook       string   `json:"event_webhook,omitempty"`
+	EventWebhookEvents []string `json:"event_webhook_events,omitempty"`
+
+	CheckSessionBeforeWrite bool   `json:"check_session_before_write"`
+	PublishConcurrency      int    `json:"publish_concurrency"` // 0 表示不限制
+	DuplicateImages         string `json:"duplicate_images"`    // dedupe | error
+	DownloadConcurrency     int    `json:"download_concurrency"`
+	DownloadRetries         int    `json:"download_retries"`
+	UploadTimeout           string `json:"upload_timeout"` // 0s 表示按素材类型的默认值
+	UploadRetries           int    `json:"upload_retries"`
+	SecretSource            string `json:"secret_source"` // env | keychain
+	SecretService           string `json:"secret_service"`
+	ProfileAddr             string `json:"profile_addr,omitempty"`
+}
+
+// Redacted 返回隐去敏感信息的副本：回调地址中的密码与令牌类查询参数被替换为 ***
+func (c ServerConfig) Redacted() ServerConfig {
+	c.PrePublishWebhook = redactURL(c.PrePublishWebhook)
+	c.EventWebhook = redactURL(c.EventWebhook)
+	c.EventWebhookEvents = append([]string(nil), c.EventWebhookEvents...)
+	return c
+}
+
+// redactURL 隐去 URL 中的密码和令牌类查询参数，无法解析时整体隐去
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redactedValue
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+		} else {
+			// 只有用户名时通常就是令牌本身
+			u.User = url.User(redactedValue)
+		}
+	}
+
+	query := u.Query()
+	changed := false
+	for name := range query {
+		if isSecretQueryKey(name) {
+			query.Set(name, redactedValue)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = query.Encode()
+	}
+
+	// 保留 *** 的可读性，不做百分号编码
+	return strings.ReplaceAll(u.String(), url.QueryEscape(redactedValue), redactedValue)
+}
+
+// isSecretQueryKey 查询参数名是否包含敏感关键字，如 access_token、api_key
+func isSecretQueryKey(name string) bool {
+	name = strings.ToLower(name)
+	for _, key := range secretQueryKeys {
+		if strings.Contains(name, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// ToolState 单个 MCP 工具的运行状态
+type ToolState struct {
+	Name           string    `json:"name"`
+	InFlight       int       `json:"in_flight"`
+	Calls          int64     `json:"calls"`
+	Errors         int64     `json:"errors"`
+	LastCalledAt   time.Time `json:"last_called_at,omitempty"`
+	LastDurationMs int64     `json:"last_duration_ms"`
+}
+
+// UnavailableState 本服务尚未实现的状态项，available 恒为 false，reason 说明原因，
+// 避免客户端误以为这类状态已被覆盖
+type UnavailableState struct {
+	Available bool   `json:"available"`
+	Reason    string `json:"reason"`
+}
+
+// 本服务没有按工具限流、熔断和按账号暂停的机制，对应状态项报告为不可用
+var (
+	rateLimitsUnavailable      = UnavailableState{Reason: "本服务没有按工具限流，没有令牌桶状态可报告"}
+	circuitBreakersUnavailable = UnavailableState{Reason: "本服务没有熔断器，失败的调用不会被自动拦截"}
+	accountPausesUnavailable   = UnavailableState{Reason: "本服务只使用一个登录账号，没有按账号暂停的机制"}
+)
+
+// ServerStateResponse 服务运行状态
+type ServerStateResponse struct {
+	StartedAt     time.Time   `json:"started_at"`
+	UptimeSeconds int64       `json:"uptime_seconds"`
+	InFlight      int         `json:"in_flight"` // 正在执行的 MCP 工具调用与 HTTP API 请求总数
+	ToolInFlight  int         `json:"tool_in_flight"`
+	HTTPInFlight  int         `json:"http_in_flight"`
+	Headless      bool        `json:"headless"` // 当前是否无头模式，-headful-fallback 切换后为 false
+	Tools         []ToolState `json:"tools"`
+
+	UIVariant xiaohongshu.UIVariantState `json:"ui_variant"`
+
+	// HeadfulFallback 自动切换到有界面模式的状态，未开启 -headful-fallback 时为空
+	HeadfulFallback *HeadfulFallbackState `json:"headful_fallback,omitempty"`
+
+	// PublishQueue 全局发布队列，未开启 -publish-concurrency 时为空
+	PublishQueue *PublishQueueState `json:"publish_queue,omitempty"`
+
+	RateLimits      UnavailableState `json:"rate_limits"`
+	CircuitBreakers UnavailableState `json:"circuit_breakers"`
+	AccountPauses   UnavailableState `json:"account_pauses"`
+}
+
+// stateTracker 记录工具调用与 HTTP 请求的运行状态，只保存计数，开销很小
+type stateTracker struct {
+	mu           sync.Mutex
+	startedAt    time.Time
+	tools        map[string]*ToolState
+	httpInFlight int
+	publishQueue *publishQueue
+}
+
+var serverState = newStateTracker()
+
+func newStateTracker() *stateTracker {
+	return &stateTracker{
+		startedAt: time.Now(),
+		tools:     make(map[string]*ToolState),
+	}
+}
+
+// toolStarted 记录工具开始执行，返回结束时调用的函数
+func (t *stateTracker) toolStarted(name string) func(isError bool) {
+	start := time.Now()
+
+	t.mu.Lock()
+	state, ok := t.tools[name]
+	if !ok {
+		state = &ToolState{Name: name}
+		t.tools[name] = state
+	}
+	state.InFlight++
+	state.Calls++
+	state.LastCalledAt = start
+	t.mu.Unlock()
+
+	return func(isError bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		state.InFlight--
+		state.LastDurationMs = time.Since(start).Milliseconds()
+		if isError {
+			state.Errors++
+		}
+	}
+}
+
+// setPublishQueue 在状态中报告发布队列的深度
+func (t *stateTracker) setPublishQueue(q *publishQueue) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.publishQueue = q
+}
+
+// httpMiddleware 统计正在处理的 HTTP API 请求数
+func (t *stateTracker) httpMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t.mu.Lock()
+		t.httpInFlight++
+		t.mu.Unlock()
+
+		defer func() {
+			t.mu.Lock()
+			t.httpInFlight--
+			t.mu.Unlock()
+		}()
+
+		c.Next()
+	}
+}
+
+// snapshot 返回当前状态的副本
+func (t *stateTracker) snapshot() *ServerStateResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	response := &ServerStateResponse{
+		StartedAt:       t.startedAt,
+		UptimeSeconds:   int64(time.Since(t.startedAt).Seconds()),
+		HTTPInFlight:    t.httpInFlight,
+		Headless:        configs.IsHeadless(),
+		UIVariant:       xiaohongshu.CurrentUIVariant(),
+		HeadfulFallback: headfulFallbackState(),
+		RateLimits:      rateLimitsUnavailable,
+		CircuitBreakers: circuitBreakersUnavailable,
+		AccountPauses:   accountPausesUnavailable,
+	}
+	if t.publishQueue != nil {
+		response.PublishQueue = t.publishQueue.state()
+	}
+
+	for _, state := range t.tools {
+		response.Tools = append(response.Tools, *state)
+		response.ToolInFlight += state.InFlight
+	}
+	sort.Slice(response.Tools, func(i, j int) bool {
+		return response.Tools[i].Name < response.Tools[j].Name
+	})
+	response.InFlight = response.ToolInFlight + response.HTTPInFlight
+
+	return response
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -14,7 +16,13 @@ import (
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
 	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/imageconv"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/mediacache"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/ttlcache"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/webhook"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
@@ -26,12 +34,34 @@ func NewXiaohongshuService() *XiaohongshuService {
 	return &XiaohongshuService{}
 }
 
-// PublishRequest 发布请求
+// PublishRequest 发布请求，images 与 image_tokens 至少提供一项
 type PublishRequest struct {
-	Title   string   `json:"title" binding:"required"`
-	Content string   `json:"content" binding:"required"`
-	Images  []string `json:"images" binding:"required,min=1"`
-	Tags    []string `json:"tags,omitempty"`
+	Title       string   `json:"title" binding:"required"`
+	Content     string   `json:"content" binding:"required"`
+	Images      []string `json:"images,omitempty"`
+	ImageTokens []string `json:"image_tokens,omitempty"` // upload_images 返回的素材令牌
+	Tags        []string `json:"tags,omitempty"`
+
+	// NormalizeImages 为 true 时把 HEIC/WebP/TIFF 等小红书不支持的格式转换为 JPEG
+	NormalizeImages bool `json:"normalize_images,omitempty"`
+
+	// Visibility 可见范围: public|private|friends，也接受中文名称（如 仅自己可见）；为空使用平台默认
+	Visibility string `json:"visibility,omitempty"`
+
+	// Poll 投票贴纸，发布前确认投票已出现在编辑区
+	Poll *xiaohongshu.Poll `json:"poll,omitempty"`
+
+	// VerifyAfterPublish 为 true 时发布成功后从个人主页找到线上笔记并读取详情
+	VerifyAfterPublish bool `json:"verify_after_publish,omitempty"`
+
+	// 评论等权限开关；指定 comments_enabled 时发布后总会读取线上笔记确认
+	xiaohongshu.PublishPermissions
+}
+
+// UploadImagesResponse 预上传图片响应
+type UploadImagesResponse struct {
+	Items []mediacache.Item `json:"items"`
+	Count int               `json:"count"`
 }
 
 // LoginStatusResponse 登录状态响应
@@ -57,11 +87,36 @@ type CookiesInfo struct {
 
 // PublishResponse 发布响应
 type PublishResponse struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Images  int    `json:"images"`
-	Status  string `json:"status"`
-	PostID  string `json:"post_id,omitempty"`
+	Title           string                 `json:"title"`
+	Content         string                 `json:"content"`
+	Images          int                    `json:"images"`
+	Status          string                 `json:"status"` // published
+	StatusLabel     string                 `json:"status_label"`
+	Visibility      string                 `json:"visibility,omitempty"` // public | private | friends，未指定时为空
+	VisibilityLabel string                 `json:"visibility_label,omitempty"`
+	PostID          string                 `json:"post_id,omitempty"`
+	ConvertedImages []imageconv.Conversion `json:"converted_images,omitempty"`
+	DuplicateImages []downloader.Duplicate `json:"duplicate_images,omitempty"` // 已去掉的重复图片
+	PollAdded       bool                   `json:"poll_added,omitempty"`
+	// Permissions 发布时设置的权限开关，未指定时为空
+	Permissions *xiaohongshu.PublishPermissions `json:"permissions,omitempty"`
+	// Upload 上传图片阶段的尝试与重试次数
+	Upload *xiaohongshu.UploadReport `json:"upload,omitempty"`
+
+	// Verification 仅 verify_after_publish 或指定 comments_enabled 时返回：发布后读取到的线上笔记
+	Verification *xiaohongshu.PublishVerification `json:"verification,omitempty"`
+}
+
+// PublishPreview 发布预览（仅做发布前校验，不打开浏览器）
+type PublishPreview struct {
+	Title         string   `json:"title"`
+	Content       string   `json:"content"`
+	Tags          []string `json:"tags,omitempty"`
+	TitleWidth    int      `json:"title_width"`
+	TitleMaxWidth int      `json:"title_max_width"`
+	Valid         bool     `json:"valid"`
+	Problems      []string `json:"problems,omitempty"`
+	Warnings      []string `json:"warnings,omitempty"`
 }
 
 // PublishVideoRequest 发布视频请求（仅支持本地单个视频文件）
@@ -70,21 +125,102 @@ type PublishVideoRequest struct {
 	Content string   `json:"content" binding:"required"`
 	Video   string   `json:"video" binding:"required"`
 	Tags    []string `json:"tags,omitempty"`
+
+	// Poll 视频笔记不支持投票，提供时直接返回 ErrPollUnsupported
+	Poll *xiaohongshu.Poll `json:"poll,omitempty"`
+
+	// VerifyAfterPublish 为 true 时发布成功后从个人主页找到线上笔记并读取详情
+	VerifyAfterPublish bool `json:"verify_after_publish,omitempty"`
+
+	// 评论、合拍等权限开关；指定 comments_enabled 时发布后总会读取线上笔记确认
+	xiaohongshu.PublishPermissions
 }
 
 // PublishVideoResponse 发布视频响应
 type PublishVideoResponse struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Video   string `json:"video"`
-	Status  string `json:"status"`
-	PostID  string `json:"post_id,omitempty"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Video       string `json:"video"`
+	Status      string `json:"status"` // published
+	StatusLabel string `json:"status_label"`
+	PostID      string `json:"post_id,omitempty"`
+	// Permissions 发布时设置的权限开关，未指定时为空
+	Permissions *xiaohongshu.PublishPermissions `json:"permissions,omitempty"`
+	// Upload 上传视频阶段的尝试与重试次数
+	Upload *xiaohongshu.UploadReport `json:"upload,omitempty"`
+
+	// Verification 仅 verify_after_publish 或指定 comments_enabled 时返回：发布后读取到的线上笔记
+	Verification *xiaohongshu.PublishVerification `json:"verification,omitempty"`
 }
 
 // FeedsListResponse Feeds列表响应
 type FeedsListResponse struct {
-	Feeds []xiaohongshu.Feed `json:"feeds"`
-	Count int                `json:"count"`
+	Feeds    []xiaohongshu.Feed `json:"feeds"`
+	Count    int                `json:"count"`
+	Complete *bool              `json:"complete,omitempty"` // 仅自动翻页时返回：是否已加载到底
+
+	ScrolledItems int  `json:"scrolled_items"` // 页面加载出的不同笔记数，多于 returned_items 表示被 max_items 截断
+	ReturnedItems int  `json:"returned_items"` // 实际返回的笔记数
+	EndReached    bool `json:"end_reached"`    // 页面出现了“到底了”等结束标记，平台不再提供更多内容
+}
+
+// PaginateRequest 自动翻页参数
+type PaginateRequest struct {
+	AutoPaginate bool `json:"auto_paginate,omitempty" form:"auto_paginate"`
+	MaxItems     int  `json:"max_items,omitempty" form:"max_items"`
+}
+
+// toOption 转换为 xiaohongshu.PaginateOption，max_items 受硬上限约束
+func (p PaginateRequest) toOption() xiaohongshu.PaginateOption {
+	return xiaohongshu.PaginateOption{
+		AutoPaginate: p.AutoPaginate,
+		MaxItems:     configs.ClampMaxItems(p.MaxItems),
+		Interval:     configs.GetPageInterval(),
+	}
+}
+
+// ProjectedFeedsListResponse 按 fields 投影后的 Feeds 列表响应
+type ProjectedFeedsListResponse struct {
+	Feeds    []map[string]any `json:"feeds"`
+	Count    int              `json:"count"`
+	Complete *bool            `json:"complete,omitempty"`
+
+	ScrolledItems int  `json:"scrolled_items"`
+	ReturnedItems int  `json:"returned_items"`
+	EndReached    bool `json:"end_reached"`
+}
+
+// Project 只保留 fields 指定的字段，fields 为空时返回完整响应
+func (r *FeedsListResponse) Project(fields []string) any {
+	if len(fields) == 0 {
+		return r
+	}
+	return &ProjectedFeedsListResponse{
+		Feeds:    xiaohongshu.ProjectFeeds(r.Feeds, fields),
+		Count:    r.Count,
+		Complete: r.Complete,
+
+		ScrolledItems: r.ScrolledItems,
+		ReturnedItems: r.ReturnedItems,
+		EndReached:    r.EndReached,
+	}
+}
+
+// newFeedsListResponse 根据翻页结果构建 Feeds 列表响应
+func newFeedsListResponse(result *xiaohongshu.PaginateResult, autoPaginate bool) *FeedsListResponse {
+	response := &FeedsListResponse{
+		Feeds: result.Feeds,
+		Count: len(result.Feeds),
+
+		ScrolledItems: result.ScrolledItems,
+		ReturnedItems: len(result.Feeds),
+		EndReached:    result.EndReached,
+	}
+	if autoPaginate {
+		complete := result.Complete
+		response.Complete = &complete
+	}
+	return response
 }
 
 // UserProfileResponse 用户主页响应
@@ -97,6 +233,9 @@ type UserProfileResponse struct {
 // DeleteCookies 删除 cookies 文件，用于登录重置
 func (s *XiaohongshuService) DeleteCookies(ctx context.Context) error {
 	cookiePath := cookies.GetCookiesFilePath()
+	// 缓存的统计属于之前登录的账号
+	accountStatsCache.Clear()
+
 	cookieLoader := cookies.NewLoadCookie(cookiePath)
 	return cookieLoader.DeleteCookies()
 }
@@ -126,15 +265,12 @@ func (s *XiaohongshuService) GetCookiesInfo(ctx context.Context) (*CookiesInfo,
 
 // CheckLoginStatus 检查登录状态
 func (s *XiaohongshuService) CheckLoginStatus(ctx context.Context) (*LoginStatusResponse, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	loginAction := xiaohongshu.NewLogin(page)
-
-	isLoggedIn, err := loginAction.CheckLoginStatus(ctx)
+	var isLoggedIn bool
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		isLoggedIn, err = xiaohongshu.NewLogin(page).CheckLoginStatus(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +285,8 @@ func (s *XiaohongshuService) CheckLoginStatus(ctx context.Context) (*LoginStatus
 
 // GetLoginQrcode 获取登录的扫码二维码
 func (s *XiaohongshuService) GetLoginQrcode(ctx context.Context) (*LoginQrcodeResponse, error) {
+	// 扫码等待期间浏览器需要保持打开，不能用 withBrowserPage，只记录结果供 -headful-fallback 判断
+	headless := configs.IsHeadless()
 	b := newBrowser()
 	page := b.NewPage()
 
@@ -160,6 +298,9 @@ func (s *XiaohongshuService) GetLoginQrcode(ctx context.Context) (*LoginQrcodeRe
 	loginAction := xiaohongshu.NewLogin(page)
 
 	img, loggedIn, err := loginAction.FetchQrcodeImage(ctx)
+	if reason := recordBrowserResult(headless, detectHeadlessBlock(page, err), err); reason != "" {
+		logrus.Warnf("无头浏览器疑似被拦截（%s），已切换到有界面模式，请重新获取二维码: %v", reason, err)
+	}
 	if err != nil || loggedIn {
 		defer deferFunc()
 	}
@@ -200,67 +341,343 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, req *PublishReq
 	// 验证标题长度
 	// 小红书限制：最大40个单位长度
 	// 中文/日文/韩文占2个单位，英文/数字占1个单位
-	if titleWidth := runewidth.StringWidth(req.Title); titleWidth > 40 {
+	if titleWidth := runewidth.StringWidth(req.Title); titleWidth > xiaohongshu.EditorTitleMaxWidth {
 		return nil, fmt.Errorf("标题长度超过限制")
 	}
 
+	if len(req.Images) == 0 && len(req.ImageTokens) == 0 {
+		return nil, fmt.Errorf("图片不能为空，请提供 images 或 image_tokens")
+	}
+
+	if req.Poll != nil {
+		if err := xiaohongshu.ValidatePoll(*req.Poll); err != nil {
+			return nil, err
+		}
+	}
+
+	visibility, err := xiaohongshu.ParseVisibility(req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := xiaohongshu.ValidatePublishPermissions(xiaohongshu.NoteTypeImage, req.PublishPermissions); err != nil {
+		return nil, err
+	}
+
+	if err := approvePublish(ctx, "publish_image", req); err != nil {
+		return nil, err
+	}
+
+	var imagePaths []string
+
 	// 处理图片：下载URL图片或使用本地路径
-	imagePaths, err := s.processImages(req.Images)
+	if len(req.Images) > 0 {
+		paths, err := s.processImages(req.Images)
+		if err != nil {
+			return nil, err
+		}
+		imagePaths = append(imagePaths, paths...)
+	}
+
+	// 解析预上传素材令牌，复用已缓存的文件
+	if len(req.ImageTokens) > 0 {
+		paths, err := s.resolveImageTokens(req.ImageTokens)
+		if err != nil {
+			return nil, err
+		}
+		imagePaths = append(imagePaths, paths...)
+	}
+
+	// 上传前按内容去掉重复的图片，或按 -duplicate-images=error 拒绝发布
+	imagePaths, duplicates, err := s.checkDuplicateImages(imagePaths)
 	if err != nil {
 		return nil, err
 	}
+	if len(imagePaths) > xiaohongshu.EditorMaxImages {
+		return nil, fmt.Errorf("图片数量 %d 超过限制，最多 %d 张", len(imagePaths), xiaohongshu.EditorMaxImages)
+	}
+
+	// 按需把不支持的图片格式转换为 JPEG
+	var conversions []imageconv.Conversion
+	if req.NormalizeImages {
+		paths, converted, err := s.normalizeImages(imagePaths)
+		if err != nil {
+			return nil, err
+		}
+		imagePaths = paths
+		conversions = converted
+	}
 
 	// 构建发布内容
 	content := xiaohongshu.PublishImageContent{
-		Title:      req.Title,
-		Content:    req.Content,
-		Tags:       req.Tags,
-		ImagePaths: imagePaths,
+		Title:       req.Title,
+		Content:     req.Content,
+		Tags:        req.Tags,
+		ImagePaths:  imagePaths,
+		Poll:        req.Poll,
+		Permissions: req.PublishPermissions,
+	}
+	if visibility != "" {
+		// 发布页按中文名称选择可见范围
+		content.Visibility = xiaohongshu.EnumLabel(xiaohongshu.EnumVisibility, visibility)
 	}
 
 	// 执行发布
-	if err := s.publishContent(ctx, content); err != nil {
+	upload, err := s.publishContent(ctx, content)
+	if err != nil {
 		logrus.Errorf("发布内容失败: title=%s %v", content.Title, err)
 		return nil, err
 	}
 
 	response := &PublishResponse{
-		Title:   req.Title,
-		Content: req.Content,
-		Images:  len(imagePaths),
-		Status:  "发布完成",
+		Title:           req.Title,
+		Content:         req.Content,
+		Images:          len(imagePaths),
+		Status:          xiaohongshu.PublishStatusPublished,
+		StatusLabel:     xiaohongshu.EnumLabel(xiaohongshu.EnumPublishStatus, xiaohongshu.PublishStatusPublished),
+		ConvertedImages: conversions,
+		DuplicateImages: duplicates,
+		PollAdded:       req.Poll != nil,
+		Upload:          upload,
+	}
+	if visibility != "" {
+		response.Visibility = visibility
+		response.VisibilityLabel = content.Visibility
+	}
+	if !req.PublishPermissions.IsEmpty() {
+		response.Permissions = &req.PublishPermissions
+	}
+	if req.VerifyAfterPublish || req.CommentsEnabled != nil {
+		response.Verification = verifyPublished(ctx, req.Title, req.Content, req.CommentsEnabled)
+		response.PostID = response.Verification.FeedID
+	}
+
+	return response, nil
+}
+
+// verifyPublished 发布成功后读取线上笔记，commentsEnabled 不为 nil 时同时确认评论权限。
+// 验证失败只记录在结果中，不影响发布结果。
+func verifyPublished(ctx context.Context, title, content string, commentsEnabled *bool) *xiaohongshu.PublishVerification {
+	var result *xiaohongshu.PublishVerification
+	err := withBrowserPage(func(page *rod.Page) error {
+		result = xiaohongshu.NewPublishVerifyAction(page).VerifyPublished(ctx, title, content, commentsEnabled)
+		return nil
+	})
+	if err != nil {
+		return &xiaohongshu.PublishVerification{Reason: fmt.Sprintf("打开浏览器验证发布结果失败: %v", err)}
+	}
+	if !result.Verified {
+		logrus.Warnf("发布验证未通过: title=%s %s", title, result.Reason)
+	}
+	return result
+}
+
+// SavePublishTemplate 保存发布模板，同名模板会被覆盖
+func (s *XiaohongshuService) SavePublishTemplate(name string, spec templates.Spec) (*templates.Template, error) {
+	store, err := templates.NewStore(configs.GetTemplatesPath())
+	if err != nil {
+		return nil, err
+	}
+	return store.Save(name, spec)
+}
+
+// ListPublishTemplates 列出已保存的发布模板
+func (s *XiaohongshuService) ListPublishTemplates() ([]string, error) {
+	store, err := templates.NewStore(configs.GetTemplatesPath())
+	if err != nil {
+		return nil, err
+	}
+	return store.List()
+}
+
+// PublishFromTemplate 读取模板并应用覆盖项后按正常发布流程发布
+func (s *XiaohongshuService) PublishFromTemplate(ctx context.Context, name string, overrides templates.Overrides) (*PublishResponse, error) {
+	store, err := templates.NewStore(configs.GetTemplatesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := tpl.Apply(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("按模板发布: template=%s, title=%s", name, spec.Title)
+
+	return s.PublishContent(ctx, &PublishRequest{
+		Title:           spec.Title,
+		Content:         spec.Content,
+		Images:          spec.Images,
+		ImageTokens:     spec.ImageTokens,
+		Tags:            spec.Tags,
+		NormalizeImages: spec.NormalizeImages,
+		Visibility:      spec.Visibility,
+	})
+}
+
+// PreviewPublish 按发布时的校验规则检查标题、正文和标签，返回发布预览
+func (s *XiaohongshuService) PreviewPublish(title, content string, tags []string) *PublishPreview {
+	preview := &PublishPreview{
+		Title:         title,
+		Content:       content,
+		Tags:          tags,
+		TitleWidth:    runewidth.StringWidth(title),
+		TitleMaxWidth: xiaohongshu.EditorTitleMaxWidth,
+	}
+
+	if strings.TrimSpace(title) == "" {
+		preview.Problems = append(preview.Problems, "标题不能为空")
+	}
+	if preview.TitleWidth > xiaohongshu.EditorTitleMaxWidth {
+		preview.Problems = append(preview.Problems, "标题长度超过限制")
+	}
+	if strings.TrimSpace(content) == "" {
+		preview.Problems = append(preview.Problems, "正文不能为空")
+	}
+	if len(tags) > xiaohongshu.EditorMaxTags {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("标签数量超过%d，发布时将只保留前%d个", xiaohongshu.EditorMaxTags, xiaohongshu.EditorMaxTags))
+	}
+
+	preview.Valid = len(preview.Problems) == 0
+	return preview
+}
+
+// UploadImages 预上传图片：下载 URL 图片并把所有图片缓存到数据目录，
+// 返回的令牌可在多次发布中通过 image_tokens 复用，避免重复下载和处理。
+func (s *XiaohongshuService) UploadImages(ctx context.Context, images []string) (*UploadImagesResponse, error) {
+	imagePaths, err := s.processImages(images)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := newMediaStore()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadImagesResponse{}
+	for _, path := range imagePaths {
+		item, err := store.Put(path)
+		if err != nil {
+			return nil, err
+		}
+		response.Items = append(response.Items, *item)
 	}
+	response.Count = len(response.Items)
 
 	return response, nil
 }
 
+// resolveImageTokens 把素材令牌解析为本地文件路径
+func (s *XiaohongshuService) resolveImageTokens(tokens []string) ([]string, error) {
+	store, err := newMediaStore()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		path, err := store.Resolve(token)
+		if err != nil {
+			return nil, fmt.Errorf("素材令牌 %s 不可用: %w", token, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+func newMediaStore() (*mediacache.Store, error) {
+	return mediacache.NewStore(configs.GetMediaPath(), configs.MediaTokenTTL)
+}
+
+// normalizeImages 把 HEIC/WebP/TIFF 等格式转换为 JPEG，返回转换后的路径及转换记录
+func (s *XiaohongshuService) normalizeImages(paths []string) ([]string, []imageconv.Conversion, error) {
+	converter, err := imageconv.NewConverter(configs.GetConvertedImagesPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	converter.Sweep(configs.ConvertedImagesTTL)
+
+	result, conversions, err := converter.NormalizeAll(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, conv := range conversions {
+		logrus.Infof("图片格式已转换: %s (%s) -> %s", conv.Source, conv.From, conv.Output)
+	}
+
+	return result, conversions, nil
+}
+
+// checkDuplicateImages 查找内容相同的图片：默认去重并记录警告，-duplicate-images=error 时返回 ErrDuplicateImages
+func (s *XiaohongshuService) checkDuplicateImages(paths []string) ([]string, []downloader.Duplicate, error) {
+	unique, duplicates, err := downloader.FindDuplicates(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(duplicates) == 0 {
+		return paths, nil, nil
+	}
+
+	descs := make([]string, 0, len(duplicates))
+	for _, d := range duplicates {
+		descs = append(descs, fmt.Sprintf("第 %d 张与第 %d 张相同", d.Index, d.DuplicateOf))
+	}
+	if configs.GetDuplicateImages() == configs.DuplicateImagesError {
+		return nil, nil, fmt.Errorf("%w: %s", errors.ErrDuplicateImages, strings.Join(descs, "，"))
+	}
+
+	logrus.Warnf("已去掉 %d 张重复图片: %s", len(duplicates), strings.Join(descs, "，"))
+	return unique, duplicates, nil
+}
+
 // processImages 处理图片列表，支持URL下载和本地路径
 func (s *XiaohongshuService) processImages(images []string) ([]string, error) {
 	processor := downloader.NewImageProcessor()
 	return processor.ProcessImages(images)
 }
 
-// publishContent 执行内容发布
-func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohongshu.PublishImageContent) error {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
+// approvePublish 配置了 -prepublish-webhook 时，发布前把发布内容交给审批回调，未放行则中止发布
+func approvePublish(ctx context.Context, action string, spec any) error {
+	url := configs.PrePublishWebhook()
+	if url == "" {
+		return nil
+	}
 
-	action, err := xiaohongshu.NewPublishImageAction(page)
-	if err != nil {
+	approver := webhook.NewApprover(url, configs.PrePublishTimeout(), configs.PrePublishFailOpen())
+	if err := approver.Approve(ctx, action, spec); err != nil {
+		logrus.Warnf("发布未通过审批: %s %v", action, err)
 		return err
 	}
+	return nil
+}
 
-	// 执行发布
-	return action.Publish(ctx, content)
+// publishContent 执行内容发布
+func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohongshu.PublishImageContent) (*xiaohongshu.UploadReport, error) {
+	var upload *xiaohongshu.UploadReport
+	err := withBrowserPageOnce(func(page *rod.Page) error {
+		action, err := xiaohongshu.NewPublishImageAction(page)
+		if err != nil {
+			return err
+		}
+
+		// 执行发布
+		upload, err = action.Publish(ctx, content)
+		return err
+	})
+	return upload, err
 }
 
 // PublishVideo 发布视频（本地文件）
 func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideoRequest) (*PublishVideoResponse, error) {
 	// 标题长度校验
-	if titleWidth := runewidth.StringWidth(req.Title); titleWidth > 40 {
+	if titleWidth := runewidth.StringWidth(req.Title); titleWidth > xiaohongshu.EditorTitleMaxWidth {
 		return nil, fmt.Errorf("标题长度超过限制")
 	}
 
@@ -268,132 +685,212 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 	if req.Video == "" {
 		return nil, fmt.Errorf("必须提供本地视频文件")
 	}
+	if req.Poll != nil {
+		return nil, fmt.Errorf("%w: 视频笔记暂不支持投票，请改用图文发布", errors.ErrPollUnsupported)
+	}
 	if _, err := os.Stat(req.Video); err != nil {
 		return nil, fmt.Errorf("视频文件不存在或不可访问: %v", err)
 	}
+	if err := xiaohongshu.ValidatePublishPermissions(xiaohongshu.NoteTypeVideo, req.PublishPermissions); err != nil {
+		return nil, err
+	}
+
+	if err := approvePublish(ctx, "publish_video", req); err != nil {
+		return nil, err
+	}
 
 	// 构建发布内容
 	content := xiaohongshu.PublishVideoContent{
-		Title:     req.Title,
-		Content:   req.Content,
-		Tags:      req.Tags,
-		VideoPath: req.Video,
+		Title:       req.Title,
+		Content:     req.Content,
+		Tags:        req.Tags,
+		VideoPath:   req.Video,
+		Permissions: req.PublishPermissions,
 	}
 
 	// 执行发布
-	if err := s.publishVideo(ctx, content); err != nil {
+	upload, err := s.publishVideo(ctx, content)
+	if err != nil {
 		return nil, err
 	}
 
 	resp := &PublishVideoResponse{
-		Title:   req.Title,
-		Content: req.Content,
-		Video:   req.Video,
-		Status:  "发布完成",
+		Title:       req.Title,
+		Content:     req.Content,
+		Video:       req.Video,
+		Status:      xiaohongshu.PublishStatusPublished,
+		StatusLabel: xiaohongshu.EnumLabel(xiaohongshu.EnumPublishStatus, xiaohongshu.PublishStatusPublished),
+		Upload:      upload,
+	}
+	if !req.PublishPermissions.IsEmpty() {
+		resp.Permissions = &req.PublishPermissions
+	}
+	if req.VerifyAfterPublish || req.CommentsEnabled != nil {
+		resp.Verification = verifyPublished(ctx, req.Title, req.Content, req.CommentsEnabled)
+		resp.PostID = resp.Verification.FeedID
 	}
 	return resp, nil
 }
 
 // publishVideo 执行视频发布
-func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongshu.PublishVideoContent) error {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
+func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongshu.PublishVideoContent) (*xiaohongshu.UploadReport, error) {
+	var upload *xiaohongshu.UploadReport
+	err := withBrowserPageOnce(func(page *rod.Page) error {
+		action, err := xiaohongshu.NewPublishVideoAction(page)
+		if err != nil {
+			return err
+		}
 
-	action, err := xiaohongshu.NewPublishVideoAction(page)
-	if err != nil {
+		upload, err = action.PublishVideo(ctx, content)
 		return err
-	}
-
-	return action.PublishVideo(ctx, content)
+	})
+	return upload, err
 }
 
 // ListFeeds 获取Feeds列表
-func (s *XiaohongshuService) ListFeeds(ctx context.Context) (*FeedsListResponse, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	// 创建 Feeds 列表 action
-	action := xiaohongshu.NewFeedsListAction(page)
-
-	// 获取 Feeds 列表
-	feeds, err := action.GetFeedsList(ctx)
+func (s *XiaohongshuService) ListFeeds(ctx context.Context, paginate PaginateRequest) (*FeedsListResponse, error) {
+	var result *xiaohongshu.PaginateResult
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		// 获取 Feeds 列表
+		result, err = xiaohongshu.NewFeedsListAction(page).GetFeedsListWithPagination(ctx, paginate.toOption())
+		return err
+	})
 	if err != nil {
 		logrus.Errorf("获取 Feeds 列表失败: %v", err)
 		return nil, err
 	}
 
-	response := &FeedsListResponse{
-		Feeds: feeds,
-		Count: len(feeds),
+	return newFeedsListResponse(result, paginate.AutoPaginate), nil
+}
+
+// SearchFeeds 搜索Feeds，resetFilters 为 true 时先把平台记住的筛选恢复为默认值
+func (s *XiaohongshuService) SearchFeeds(ctx context.Context, keyword string, paginate PaginateRequest, resetFilters bool, filters ...xiaohongshu.FilterOption) (*FeedsListResponse, error) {
+	var result *xiaohongshu.PaginateResult
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		action := xiaohongshu.NewSearchAction(page).KeepFilters(!resetFilters)
+		result, err = action.SearchWithPagination(ctx, keyword, paginate.toOption(), filters...)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	return newFeedsListResponse(result, paginate.AutoPaginate), nil
 }
 
-func (s *XiaohongshuService) SearchFeeds(ctx context.Context, keyword string, filters ...xiaohongshu.FilterOption) (*FeedsListResponse, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
+// GetFeedDetail 获取Feed详情，fields 不为空时只返回指定字段
+func (s *XiaohongshuService) GetFeedDetail(ctx context.Context, feedID, xsecToken string, fields []string, includeEngagement bool) (*FeedDetailResponse, error) {
+	if err := xiaohongshu.ValidateFeedDetailFields(fields); err != nil {
+		return nil, err
+	}
 
-	action := xiaohongshu.NewSearchAction(page)
+	var response *FeedDetailResponse
+	err := withBrowserPage(func(page *rod.Page) error {
+		// 获取 Feed 详情
+		result, err := xiaohongshu.NewFeedDetailAction(page).GetFeedDetail(ctx, feedID, xsecToken)
+		if err != nil {
+			return err
+		}
 
-	feeds, err := action.Search(ctx, keyword, filters...)
+		response = &FeedDetailResponse{
+			FeedID:          feedID,
+			Data:            result,
+			CommentsEnabled: xiaohongshu.ReadNoteSettings(page.Context(ctx), feedID).CommentsEnabled,
+		}
+		if len(fields) > 0 {
+			response.Data = xiaohongshu.ProjectFeedDetail(result, fields)
+		}
+		if includeEngagement {
+			response.Engagement = noteEngagement(ctx, page, result.Note, xsecToken)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	response := &FeedsListResponse{
-		Feeds: feeds,
-		Count: len(feeds),
-	}
-
 	return response, nil
 }
 
-// GetFeedDetail 获取Feed详情
-func (s *XiaohongshuService) GetFeedDetail(ctx context.Context, feedID, xsecToken string) (*FeedDetailResponse, error) {
-	b := newBrowser()
-	defer b.Close()
+// noteEngagement 在同一页面打开作者主页读取粉丝数并计算互动率，读取失败时分母为 null
+func noteEngagement(ctx context.Context, page *rod.Page, note xiaohongshu.FeedDetail, xsecToken string) *xiaohongshu.Engagement {
+	var followers *int64
+	var notes []string
+
+	if note.User.UserID == "" {
+		notes = append(notes, "笔记详情中没有作者 ID，无法读取粉丝数")
+	} else if profile, err := xiaohongshu.NewUserProfileAction(page).UserProfile(ctx, note.User.UserID, xsecToken); err != nil {
+		logrus.Warnf("读取作者 %s 的粉丝数失败: %v", note.User.UserID, err)
+		notes = append(notes, "读取作者粉丝数失败: "+err.Error())
+	} else if n, ok := xiaohongshu.FollowerCount(profile.Interactions); ok {
+		followers = &n
+	} else {
+		notes = append(notes, "作者主页没有粉丝数")
+	}
+	notes = append(notes, "网页端笔记详情不提供浏览量")
 
-	page := b.NewPage()
-	defer page.Close()
+	engagement := xiaohongshu.ComputeEngagement(note.InteractInfo, followers, nil)
+	engagement.Notes = notes
+	return engagement
+}
 
-	// 创建 Feed 详情 action
-	action := xiaohongshu.NewFeedDetailAction(page)
+// ExportSummary 流式导出结束时的汇总
+type ExportSummary struct {
+	Scope      string `json:"scope"` // keyword|user
+	Count      int    `json:"count"`
+	MaxItems   int    `json:"max_items"`
+	Complete   bool   `json:"complete"` // 是否已加载到底（未因 max_items 截断）
+	DurationMs int64  `json:"duration_ms"`
+}
 
-	// 获取 Feed 详情
-	result, err := action.GetFeedDetail(ctx, feedID, xsecToken)
-	if err != nil {
-		return nil, err
+// ExportNotes 按关键词或用户主页滚动抓取笔记，每条交给 emit 后即丢弃，内存占用不随导出量增长。
+// 出错时仍返回已导出部分的汇总。
+func (s *XiaohongshuService) ExportNotes(ctx context.Context, req *ExportNotesRequest, emit func(xiaohongshu.Feed) error) (*ExportSummary, error) {
+	start := time.Now()
+	summary := &ExportSummary{
+		Scope:    req.scope(),
+		MaxItems: configs.ClampExportItems(req.MaxItems),
 	}
 
-	response := &FeedDetailResponse{
-		FeedID: feedID,
-		Data:   result,
+	opt := xiaohongshu.PaginateOption{
+		AutoPaginate: true,
+		MaxItems:     summary.MaxItems,
+		Interval:     configs.GetPageInterval(),
+	}
+	counted := func(feed xiaohongshu.Feed) error {
+		if err := emit(feed); err != nil {
+			return err
+		}
+		summary.Count++
+		return nil
 	}
 
-	return response, nil
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		if summary.Scope == "user" {
+			summary.Complete, err = xiaohongshu.NewUserProfileAction(page).
+				StreamUserNotes(ctx, req.UserID, req.XsecToken, opt, counted)
+		} else {
+			summary.Complete, err = xiaohongshu.NewSearchAction(page).
+				StreamSearch(ctx, req.Keyword, opt, counted)
+		}
+		return err
+	})
+	summary.DurationMs = time.Since(start).Milliseconds()
+
+	return summary, err
 }
 
 // UserProfile 获取用户信息
 func (s *XiaohongshuService) UserProfile(ctx context.Context, userID, xsecToken string) (*UserProfileResponse, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	action := xiaohongshu.NewUserProfileAction(page)
-
-	result, err := action.UserProfile(ctx, userID, xsecToken)
+	var result *xiaohongshu.UserProfileResponse
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		result, err = xiaohongshu.NewUserProfileAction(page).UserProfile(ctx, userID, xsecToken)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -409,15 +906,10 @@ func (s *XiaohongshuService) UserProfile(ctx context.Context, userID, xsecToken
 
 // PostCommentToFeed 发表评论到Feed
 func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string) (*PostCommentResponse, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	action := xiaohongshu.NewCommentFeedAction(page)
-
-	if err := action.PostComment(ctx, feedID, xsecToken, content); err != nil {
+	err := withBrowserPageOnce(func(page *rod.Page) error {
+		return xiaohongshu.NewCommentFeedAction(page).PostComment(ctx, feedID, xsecToken, content)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -426,14 +918,10 @@ func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsec
 
 // LikeFeed 点赞笔记
 func (s *XiaohongshuService) LikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	action := xiaohongshu.NewLikeAction(page)
-	if err := action.Like(ctx, feedID, xsecToken); err != nil {
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewLikeAction(page).Like(ctx, feedID, xsecToken)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &ActionResult{FeedID: feedID, Success: true, Message: "点赞成功或已点赞"}, nil
@@ -441,14 +929,10 @@ func (s *XiaohongshuService) LikeFeed(ctx context.Context, feedID, xsecToken str
 
 // UnlikeFeed 取消点赞笔记
 func (s *XiaohongshuService) UnlikeFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	action := xiaohongshu.NewLikeAction(page)
-	if err := action.Unlike(ctx, feedID, xsecToken); err != nil {
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewLikeAction(page).Unlike(ctx, feedID, xsecToken)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &ActionResult{FeedID: feedID, Success: true, Message: "取消点赞成功或未点赞"}, nil
@@ -456,14 +940,10 @@ func (s *XiaohongshuService) UnlikeFeed(ctx context.Context, feedID, xsecToken s
 
 // FavoriteFeed 收藏笔记
 func (s *XiaohongshuService) FavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	action := xiaohongshu.NewFavoriteAction(page)
-	if err := action.Favorite(ctx, feedID, xsecToken); err != nil {
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewFavoriteAction(page).Favorite(ctx, feedID, xsecToken)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &ActionResult{FeedID: feedID, Success: true, Message: "收藏成功或已收藏"}, nil
@@ -471,21 +951,65 @@ func (s *XiaohongshuService) FavoriteFeed(ctx context.Context, feedID, xsecToken
 
 // UnfavoriteFeed 取消收藏笔记
 func (s *XiaohongshuService) UnfavoriteFeed(ctx context.Context, feedID, xsecToken string) (*ActionResult, error) {
-	b := newBrowser()
-	defer b.Close()
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewFavoriteAction(page).Unfavorite(ctx, feedID, xsecToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ActionResult{FeedID: feedID, Success: true, Message: "取消收藏成功或未收藏"}, nil
+}
 
-	page := b.NewPage()
-	defer page.Close()
+// GetMyComments 列出当前账号在笔记下发表的评论与回复
+func (s *XiaohongshuService) GetMyComments(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.MyComments, error) {
+	var result *xiaohongshu.MyComments
+	var err error
 
-	action := xiaohongshu.NewFavoriteAction(page)
-	if err := action.Unfavorite(ctx, feedID, xsecToken); err != nil {
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewCommentManageAction(page)
+		result, err = action.GetMyComments(ctx, feedID, xsecToken)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
-	return &ActionResult{FeedID: feedID, Success: true, Message: "取消收藏成功或未收藏"}, nil
+	return result, nil
+}
+
+// DeleteComment 删除当前账号的一条评论，删除后确认评论已消失
+func (s *XiaohongshuService) DeleteComment(ctx context.Context, ref xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteResult, error) {
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewCommentManageAction(page).DeleteComment(ctx, ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &xiaohongshu.CommentDeleteResult{FeedID: ref.FeedID, CommentID: ref.CommentID, Deleted: true}, nil
+}
+
+// BatchDeleteComments 逐条删除评论，单条失败不影响其余评论，返回每条的结果与汇总
+func (s *XiaohongshuService) BatchDeleteComments(ctx context.Context, refs []xiaohongshu.CommentRef) (*xiaohongshu.CommentDeleteSummary, error) {
+	if err := xiaohongshu.ValidateCommentRefs(refs); err != nil {
+		return nil, err
+	}
+
+	var summary *xiaohongshu.CommentDeleteSummary
+
+	err := withBrowserPage(func(page *rod.Page) error {
+		summary = xiaohongshu.NewCommentManageAction(page).BatchDeleteComments(ctx, refs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
 }
 
 func newBrowser() *headless_browser.Browser {
-	return browser.NewBrowser(configs.IsHeadless(), browser.WithBinPath(configs.GetBinPath()))
+	return browser.NewBrowser(configs.IsHeadless(),
+		browser.WithBinPath(configs.GetBinPath()),
+		browser.WithLaunchRetry(configs.GetBrowserLaunchAttempts(), configs.GetBrowserLaunchBackoff()),
+	)
 }
 
 func saveCookies(page *rod.Page) error {
@@ -500,18 +1024,12 @@ func saveCookies(page *rod.Page) error {
 	}
 
 	cookieLoader := cookies.NewLoadCookie(cookies.GetCookiesFilePath())
-	return cookieLoader.SaveCookies(data)
-}
-
-// withBrowserPage 执行需要浏览器页面的操作的通用函数
-func withBrowserPage(fn func(*rod.Page) error) error {
-	b := newBrowser()
-	defer b.Close()
-
-	page := b.NewPage()
-	defer page.Close()
-
-	return fn(page)
+	if err := cookieLoader.SaveCookies(data); err != nil {
+		return err
+	}
+	// 登录后可能换了账号，之前的统计不再适用
+	accountStatsCache.Clear()
+	return nil
 }
 
 // GetMyProfile 获取当前登录用户的个人信息
@@ -537,3 +1055,710 @@ func (s *XiaohongshuService) GetMyProfile(ctx context.Context) (*UserProfileResp
 
 	return response, nil
 }
+
+// UpdateProfile 修改当前账号的昵称、简介或头像，保存后重新读取资料确认
+func (s *XiaohongshuService) UpdateProfile(ctx context.Context, update xiaohongshu.ProfileUpdate) (*xiaohongshu.ProfileUpdateResult, error) {
+	var result *xiaohongshu.ProfileUpdateResult
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewProfileEditAction(page)
+		result, err = action.UpdateProfile(ctx, update)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetEditorConfig 获取发布编辑器的默认配置及可选项（不发布任何内容）
+func (s *XiaohongshuService) GetEditorConfig(ctx context.Context) (*xiaohongshu.EditorConfig, error) {
+	var result *xiaohongshu.EditorConfig
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewEditorConfigAction(page)
+		result, err = action.GetEditorConfig(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetEarnings 获取创作者收益信息，period 为 7d|30d|all
+func (s *XiaohongshuService) GetEarnings(ctx context.Context, period string) (*xiaohongshu.Earnings, error) {
+	if err := xiaohongshu.ValidateEarningsPeriod(period); err != nil {
+		return nil, err
+	}
+
+	var result *xiaohongshu.Earnings
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewEarningsAction(page)
+		result, err = action.GetEarnings(ctx, period)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBestPostingTimes 获取创作者中心根据粉丝活跃时间推荐的发布时段，账号没有该功能时返回 available=false
+func (s *XiaohongshuService) GetBestPostingTimes(ctx context.Context) (*xiaohongshu.PostingTimes, error) {
+	var result *xiaohongshu.PostingTimes
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewPostingTimesAction(page)
+		result, err = action.GetBestPostingTimes(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetViewHistory 获取一页笔记浏览记录，网页端没有该数据时返回 available=false
+func (s *XiaohongshuService) GetViewHistory(ctx context.Context, cursor string) (*xiaohongshu.ViewHistory, error) {
+	if err := xiaohongshu.ValidateViewHistoryCursor(cursor); err != nil {
+		return nil, err
+	}
+
+	var result *xiaohongshu.ViewHistory
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewViewHistoryAction(page)
+		result, err = action.GetViewHistory(ctx, cursor)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBlockedUsers 获取一页黑名单，网页端没有该设置时返回 supported=false
+func (s *XiaohongshuService) GetBlockedUsers(ctx context.Context, cursor string) (*xiaohongshu.BlockedUsers, error) {
+	if err := xiaohongshu.ValidateModerationCursor(cursor); err != nil {
+		return nil, err
+	}
+
+	var result *xiaohongshu.BlockedUsers
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewModerationAction(page)
+		result, err = action.GetBlockedUsers(ctx, cursor)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetMutedKeywords 获取一页屏蔽词，网页端没有该设置时返回 supported=false
+func (s *XiaohongshuService) GetMutedKeywords(ctx context.Context, cursor string) (*xiaohongshu.MutedKeywords, error) {
+	if err := xiaohongshu.ValidateModerationCursor(cursor); err != nil {
+		return nil, err
+	}
+
+	var result *xiaohongshu.MutedKeywords
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewModerationAction(page)
+		result, err = action.GetMutedKeywords(ctx, cursor)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAccountRegion 获取当前账号的 IP 属地、网页端语言与浏览器时区，平台未展示时返回 available=false
+func (s *XiaohongshuService) GetAccountRegion(ctx context.Context) (*xiaohongshu.AccountRegion, error) {
+	var result *xiaohongshu.AccountRegion
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewAccountRegionAction(page)
+		result, err = action.GetAccountRegion(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// accountStatsCache 按 max_notes 缓存当前账号的汇总统计，逐篇读取详情的代价较高；
+// 删除 cookies 或重新登录时清空
+var accountStatsCache = ttlcache.New[*xiaohongshu.AccountAggregateStats](configs.AccountStatsTTL)
+
+// GetAccountAggregateStats 汇总当前账号最多 maxNotes 篇笔记的互动数据，结果缓存 AccountStatsTTL；
+// refresh 为 true 时忽略缓存重新统计。progress 为 nil 时不报告进度。
+func (s *XiaohongshuService) GetAccountAggregateStats(ctx context.Context, maxNotes int, refresh bool, progress xiaohongshu.ProgressFunc) (*xiaohongshu.AccountAggregateStats, error) {
+	maxNotes = configs.ClampAggregateNotes(maxNotes)
+	key := strconv.Itoa(maxNotes)
+
+	if !refresh {
+		if cached, expiresAt, ok := accountStatsCache.Get(key); ok {
+			result := *cached
+			result.Cached = true
+			result.ExpiresAt = expiresAt
+			return &result, nil
+		}
+	}
+
+	var result *xiaohongshu.AccountAggregateStats
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewAccountStatsAction(page)
+		result, err = action.GetAccountAggregateStats(ctx, maxNotes, configs.GetPageInterval(), progress)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.ExpiresAt = accountStatsCache.Set(key, result)
+	return result, nil
+}
+
+// GetAutoReply 获取私信自动回复设置，账号不支持时返回 supported=false
+func (s *XiaohongshuService) GetAutoReply(ctx context.Context) (*xiaohongshu.AutoReplySettings, error) {
+	var result *xiaohongshu.AutoReplySettings
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewAutoReplyAction(page)
+		result, err = action.GetAutoReply(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetAutoReply 修改私信自动回复设置，返回重新读取后的设置及是否已生效
+func (s *XiaohongshuService) SetAutoReply(ctx context.Context, text string, enabled bool) (*xiaohongshu.AutoReplySettings, error) {
+	var result *xiaohongshu.AutoReplySettings
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewAutoReplyAction(page)
+		result, err = action.SetAutoReply(ctx, text, enabled)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNotificationSettings 获取账号的通知类别设置
+func (s *XiaohongshuService) GetNotificationSettings(ctx context.Context) (*xiaohongshu.NotificationSettings, error) {
+	var result *xiaohongshu.NotificationSettings
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNotificationSettingsAction(page)
+		result, err = action.GetNotificationSettings(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetNotificationSettings 修改指定类别的通知开关，返回重新读取后的设置及是否已生效
+func (s *XiaohongshuService) SetNotificationSettings(ctx context.Context, settings map[string]bool) (*xiaohongshu.NotificationSettings, error) {
+	var result *xiaohongshu.NotificationSettings
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNotificationSettingsAction(page)
+		result, err = action.SetNotificationSettings(ctx, settings)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ProxyTestResponse 代理检测响应
+type ProxyTestResponse struct {
+	Proxy    string `json:"proxy"` // 已隐藏密码
+	Success  bool   `json:"success"`
+	LaunchMs int64  `json:"launch_ms"`
+	*xiaohongshu.ProxyCheckResult
+}
+
+// TestProxy 通过代理启动一次性浏览器访问小红书，报告是否可用、延迟和出口 IP。
+// 该浏览器不加载 cookies，不影响当前登录会话。
+func (s *XiaohongshuService) TestProxy(ctx context.Context, proxyURL string) (*ProxyTestResponse, error) {
+	proxy, err := browser.ParseProxyURL(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	b, err := browser.NewProxyBrowser(true, proxy, browser.WithBinPath(configs.GetBinPath()))
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+	launchMs := time.Since(start).Milliseconds()
+
+	page, err := b.NewPage()
+	if err != nil {
+		return nil, err
+	}
+	defer page.Close()
+
+	check := xiaohongshu.NewProxyCheckAction(page).Check(ctx)
+	logrus.Infof("代理检测完成: %s status=%s latency=%dms", proxy.Redacted(), check.Status, check.LatencyMs)
+
+	return &ProxyTestResponse{
+		Proxy:            proxy.Redacted(),
+		Success:          check.Status == xiaohongshu.ProxyStatusOK,
+		LaunchMs:         launchMs,
+		ProxyCheckResult: check,
+	}, nil
+}
+
+// GetVideoComments 获取视频笔记的弹幕，非视频或没有弹幕时返回空列表
+func (s *XiaohongshuService) GetVideoComments(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.VideoCommentsResult, error) {
+	var result *xiaohongshu.VideoCommentsResult
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewVideoCommentsAction(page)
+		result, err = action.GetVideoComments(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNoteTaggedUsers 获取笔记中标记的用户（不含正文 @ 提及），没有时返回空列表
+func (s *XiaohongshuService) GetNoteTaggedUsers(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteTaggedUsers, error) {
+	var result *xiaohongshu.NoteTaggedUsers
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewTaggedUsersAction(page)
+		result, err = action.GetTaggedUsers(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNoteCollaborators 获取笔记的合著者与合作/赞助披露标签，没有时返回空列表
+func (s *XiaohongshuService) GetNoteCollaborators(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteCollaboration, error) {
+	var result *xiaohongshu.NoteCollaboration
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewCollaboratorsAction(page)
+		result, err = action.GetCollaborators(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNoteReposts 获取平台展示的复用了相同图片/内容的笔记，没有时返回空列表
+func (s *XiaohongshuService) GetNoteReposts(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteReposts, error) {
+	var result *xiaohongshu.NoteReposts
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewRepostsAction(page)
+		result, err = action.GetReposts(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetVideoCover 获取视频笔记的封面，download 为 true 时同时下载到本地图片目录
+func (s *XiaohongshuService) GetVideoCover(ctx context.Context, feedID, xsecToken string, download bool) (*xiaohongshu.VideoCover, error) {
+	var result *xiaohongshu.VideoCover
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewVideoCoverAction(page)
+		result, err = action.GetVideoCover(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if download {
+		path, err := downloader.NewImageDownloader(configs.GetImagesPath()).DownloadImage(result.URL)
+		if err != nil {
+			return nil, fmt.Errorf("下载封面失败: %w", err)
+		}
+		result.LocalPath = path
+	}
+
+	return result, nil
+}
+
+// GetNoteMusic 获取笔记的背景音乐，没有音乐时 has_music 为 false
+func (s *XiaohongshuService) GetNoteMusic(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteMusic, error) {
+	var result *xiaohongshu.NoteMusic
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNoteMusicAction(page)
+		result, err = action.GetNoteMusic(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNoteThread 在同一个页面中读取笔记详情与评论树，评论最多 maxComments 条（含回复）
+func (s *XiaohongshuService) GetNoteThread(ctx context.Context, feedID, xsecToken string, maxComments int) (*xiaohongshu.NoteThread, error) {
+	var result *xiaohongshu.NoteThread
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNoteThreadAction(page)
+		result, err = action.GetNoteThread(ctx, feedID, xsecToken, maxComments)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ArchiveNoteScreenshot 截取笔记详情页的完整长图（含全部图片与已加载的评论），保存到数据目录的 archive 下
+func (s *XiaohongshuService) ArchiveNoteScreenshot(ctx context.Context, feedID, xsecToken string, maxComments int) (*xiaohongshu.NoteScreenshot, error) {
+	var result *xiaohongshu.NoteScreenshot
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNoteScreenshotAction(page)
+		result, err = action.ArchiveNoteScreenshot(ctx, feedID, xsecToken, configs.GetArchivePath(), maxComments)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CheckNoteDiscoverability 以未登录身份搜索笔记的关键词，检查笔记能否被其他人搜到
+func (s *XiaohongshuService) CheckNoteDiscoverability(ctx context.Context, feedID, xsecToken string, keywords []string) (*xiaohongshu.NoteDiscoverability, error) {
+	var result *xiaohongshu.NoteDiscoverability
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewDiscoverabilityAction(page)
+		result, err = action.CheckDiscoverability(ctx, feedID, xsecToken, keywords)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetSearchFilters 读取关键词搜索结果页上平台记住的筛选条件
+func (s *XiaohongshuService) GetSearchFilters(ctx context.Context, keyword string) (*xiaohongshu.SearchFilterState, error) {
+	var result *xiaohongshu.SearchFilterState
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewSearchAction(page)
+		result, err = action.GetSearchFilters(ctx, keyword)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CheckKeyword 检测关键词是否被平台限制搜索
+func (s *XiaohongshuService) CheckKeyword(ctx context.Context, keyword string) (*xiaohongshu.KeywordCheck, error) {
+	var result *xiaohongshu.KeywordCheck
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewKeywordCheckAction(page)
+		result, err = action.CheckKeyword(ctx, keyword)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IsMyNote 检查笔记是否由当前登录账号发布
+func (s *XiaohongshuService) IsMyNote(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteOwnership, error) {
+	var result *xiaohongshu.NoteOwnership
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewOwnershipAction(page)
+		result, err = action.CheckOwnership(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNoteSettings 读取笔记的评论、点赞、收藏、分享权限
+func (s *XiaohongshuService) GetNoteSettings(ctx context.Context, feedID, xsecToken string) (*xiaohongshu.NoteSettings, error) {
+	var result *xiaohongshu.NoteSettings
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNoteSettingsAction(page)
+		result, err = action.GetNoteSettings(ctx, feedID, xsecToken)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RequireNoteOwner 删除、编辑、置顶等破坏性操作前调用，笔记不属于当前账号时返回 ErrNotOwner
+func (s *XiaohongshuService) RequireNoteOwner(ctx context.Context, feedID, xsecToken string) error {
+	ownership, err := s.IsMyNote(ctx, feedID, xsecToken)
+	if err != nil {
+		return err
+	}
+	if !ownership.IsMine {
+		return fmt.Errorf("%w: 笔记 %s 的作者是 %s", errors.ErrNotOwner, feedID, ownership.OwnerID)
+	}
+	return nil
+}
+
+// NoteTypesResponse 笔记类型检测响应
+type NoteTypesResponse struct {
+	Results []xiaohongshu.NoteTypeResult `json:"results"`
+	Count   int                          `json:"count"`
+}
+
+// GetNoteTypes 批量检测笔记类型（image|video|commerce|live），复用同一个页面
+func (s *XiaohongshuService) GetNoteTypes(ctx context.Context, refs []xiaohongshu.NoteRef) (*NoteTypesResponse, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("笔记列表不能为空")
+	}
+
+	var results []xiaohongshu.NoteTypeResult
+
+	err := withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewNoteTypeAction(page)
+		results = action.GetNoteTypes(ctx, refs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NoteTypesResponse{Results: results, Count: len(results)}, nil
+}
+
+// MutualStatusResponse 批量关注关系检查响应
+type MutualStatusResponse struct {
+	Results []xiaohongshu.MutualStatus `json:"results"`
+	Count   int                        `json:"count"`
+}
+
+// CheckMutual 批量检查当前账号与用户之间的关注关系，复用同一个页面
+func (s *XiaohongshuService) CheckMutual(ctx context.Context, refs []xiaohongshu.UserRef) (*MutualStatusResponse, error) {
+	if err := xiaohongshu.ValidateUserRefs(refs); err != nil {
+		return nil, err
+	}
+
+	var results []xiaohongshu.MutualStatus
+
+	err := withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewFollowStatusAction(page)
+		results = action.CheckMutual(ctx, refs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MutualStatusResponse{Results: results, Count: len(results)}, nil
+}
+
+// NoteCommentsResponse 笔记评论响应
+type NoteCommentsResponse struct {
+	FeedID    string                `json:"feed_id"`
+	Sort      string                `json:"sort,omitempty"`
+	Comments  []xiaohongshu.Comment `json:"comments"`
+	Count     int                   `json:"count"`
+	Cursor    string                `json:"cursor,omitempty"`
+	HasMore   bool                  `json:"has_more"`
+	MaxDepth  int                   `json:"max_depth"`
+	Truncated bool                  `json:"truncated"` // 部分评论的回复没有全部返回，对应评论带有 repliesTruncated
+}
+
+// StreamNoteComments 持续轮询笔记评论，每发现新评论就调用 emit，直到 ctx 结束。
+// 整个过程复用同一个浏览器页面，emit 返回错误时停止。
+func (s *XiaohongshuService) StreamNoteComments(ctx context.Context, feedID, xsecToken string, interval time.Duration, emit func([]xiaohongshu.Comment) error) error {
+	return withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewCommentsAction(page)
+		stream, err := action.OpenCommentStream(ctx, feedID, xsecToken)
+		if err != nil {
+			return err
+		}
+		return pollCommentStream(ctx, feedID, interval, stream.Poll, emit)
+	})
+}
+
+// pollCommentStream 每隔 interval 调用 poll，有新评论时交给 emit，直到 ctx 结束或 emit 返回错误。
+// 单次轮询失败（如刷新超时）只记录日志，下一轮继续
+func pollCommentStream(ctx context.Context, feedID string, interval time.Duration, poll func() ([]xiaohongshu.Comment, error), emit func([]xiaohongshu.Comment) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		comments, err := poll()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logrus.Warnf("评论流轮询失败: feed=%s %v", feedID, err)
+			continue
+		}
+		if len(comments) == 0 {
+			continue
+		}
+		if err := emit(comments); err != nil {
+			return err
+		}
+	}
+}
+
+// GetNoteComments 获取笔记评论，sort 为 hot|time，为空时使用平台默认排序；
+// maxDepth 为评论树最大深度，0 表示默认深度
+func (s *XiaohongshuService) GetNoteComments(ctx context.Context, feedID, xsecToken, sort string, maxDepth int) (*NoteCommentsResponse, error) {
+	if err := xiaohongshu.ValidateCommentSort(sort); err != nil {
+		return nil, err
+	}
+	if err := xiaohongshu.ValidateCommentDepth(maxDepth); err != nil {
+		return nil, err
+	}
+	if maxDepth == 0 {
+		maxDepth = xiaohongshu.DefaultCommentDepth
+	}
+
+	var comments *xiaohongshu.CommentList
+	var err error
+
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewCommentsAction(page)
+		comments, err = action.GetComments(ctx, feedID, xsecToken, xiaohongshu.CommentsOption{Sort: sort, MaxDepth: maxDepth})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NoteCommentsResponse{
+		FeedID:    feedID,
+		Sort:      sort,
+		Comments:  comments.List,
+		Count:     len(comments.List),
+		Cursor:    comments.Cursor,
+		HasMore:   comments.HasMore,
+		MaxDepth:  maxDepth,
+		Truncated: comments.DepthTruncated,
+	}, nil
+}
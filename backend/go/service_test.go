@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+func TestPollCommentStreamSurvivesFailedPoll(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	polls := 0
+	poll := func() ([]xiaohongshu.Comment, error) {
+		polls++
+		switch polls {
+		case 1:
+			return nil, errors.New("navigation timeout")
+		case 2:
+			return nil, nil
+		}
+		return []xiaohongshu.Comment{{ID: "c1"}}, nil
+	}
+
+	var got []xiaohongshu.Comment
+	stop := errors.New("stop")
+	emit := func(comments []xiaohongshu.Comment) error {
+		got = append(got, comments...)
+		return stop
+	}
+
+	err := pollCommentStream(ctx, "feed", time.Millisecond, poll, emit)
+	if !errors.Is(err, stop) {
+		t.Fatalf("pollCommentStream() = %v, want the emit error", err)
+	}
+	if polls != 3 || len(got) != 1 || got[0].ID != "c1" {
+		t.Errorf("polls = %d, emitted = %+v", polls, got)
+	}
+}
+
+func TestPollCommentStreamStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	poll := func() ([]xiaohongshu.Comment, error) {
+		cancel()
+		return nil, errors.New("context canceled")
+	}
+	emit := func([]xiaohongshu.Comment) error {
+		t.Error("emit called after a failed poll")
+		return nil
+	}
+
+	if err := pollCommentStream(ctx, "feed", time.Millisecond, poll, emit); err != nil {
+		t.Errorf("pollCommentStream() = %v, want nil", err)
+	}
+}
@@ -1,6 +1,9 @@
 package main
 
-import "github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+import (
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/templates"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
 
 // HTTP API 响应类型
 
@@ -24,6 +27,9 @@ type SuccessResponse struct {
 type MCPToolResult struct {
 	Content []MCPContent `json:"content"`
 	IsError bool         `json:"isError,omitempty"`
+
+	// StructuredContent 结构化结果，工具声明了输出 Schema 时会按 Schema 校验
+	StructuredContent any `json:"structuredContent,omitempty"`
 }
 
 // MCPContent MCP 内容（内部使用）
@@ -36,19 +42,46 @@ type MCPContent struct {
 
 // FeedDetailRequest Feed详情请求
 type FeedDetailRequest struct {
-	FeedID    string `json:"feed_id" binding:"required"`
-	XsecToken string `json:"xsec_token" binding:"required"`
+	FeedID    string   `json:"feed_id" binding:"required"`
+	XsecToken string   `json:"xsec_token" binding:"required"`
+	Fields    []string `json:"fields,omitempty"` // 只返回指定字段，为空返回完整数据
+	// IncludeEngagement 额外打开作者主页读取粉丝数，返回服务端计算的互动率
+	IncludeEngagement bool `json:"include_engagement,omitempty"`
 }
 
 type SearchFeedsRequest struct {
-	Keyword string                   `json:"keyword" binding:"required"`
-	Filters xiaohongshu.FilterOption `json:"filters,omitempty"`
+	Keyword      string                   `json:"keyword" binding:"required"`
+	Filters      xiaohongshu.FilterOption `json:"filters,omitempty"`
+	Fields       []string                 `json:"fields,omitempty"`        // 只返回指定字段，为空返回完整数据
+	ResetFilters *bool                    `json:"reset_filters,omitempty"` // 搜索前重置平台记住的筛选，默认 true
+	PaginateRequest
+}
+
+// resetFiltersOrDefault 未指定 reset_filters 时默认重置
+func resetFiltersOrDefault(reset *bool) bool {
+	return reset == nil || *reset
 }
 
 // FeedDetailResponse Feed详情响应
 type FeedDetailResponse struct {
 	FeedID string `json:"feed_id"`
 	Data   any    `json:"data"`
+	// CommentsEnabled 笔记是否允许评论，页面没有相关信息时为 null
+	CommentsEnabled *bool `json:"comments_enabled"`
+	// Engagement 服务端计算的互动率，仅在 include_engagement 为 true 时返回
+	Engagement *xiaohongshu.Engagement `json:"engagement,omitempty"`
+}
+
+// FullFeedDetailOutput 与 ProjectedFeedDetailOutput 只用于生成 get_feed_detail 的输出 Schema：
+// 其余字段与 FeedDetailResponse 相同，data 分别为完整详情与按 fields 投影的结果
+type FullFeedDetailOutput struct {
+	FeedDetailResponse
+	Data xiaohongshu.FeedDetailResponse `json:"data"`
+}
+
+type ProjectedFeedDetailOutput struct {
+	FeedDetailResponse
+	Data xiaohongshu.ProjectedFeedDetail `json:"data"`
 }
 
 // PostCommentRequest 发表评论请求
@@ -85,3 +118,123 @@ type CookiesInfoResponse struct {
 	Size     int64  `json:"size,omitempty"`
 	Modified string `json:"modified,omitempty"`
 }
+
+// NoteTypeRequest 批量检测笔记类型请求
+type NoteTypeRequest struct {
+	Notes []xiaohongshu.NoteRef `json:"notes" binding:"required,min=1"`
+}
+
+// CheckMutualRequest 批量检查关注关系请求
+type CheckMutualRequest struct {
+	Users []xiaohongshu.UserRef `json:"users" binding:"required,min=1"`
+}
+
+// BatchDeleteCommentsRequest 批量删除评论请求
+type BatchDeleteCommentsRequest struct {
+	Comments []xiaohongshu.CommentRef `json:"comments" binding:"required,min=1"`
+}
+
+// NoteCommentsRequest 获取笔记评论请求
+type NoteCommentsRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	Sort      string `json:"sort,omitempty"`
+	MaxDepth  int    `json:"max_depth,omitempty"` // 评论树最大深度，默认 2
+}
+
+// CommentStreamRequest 评论流请求（query 参数）
+type CommentStreamRequest struct {
+	XsecToken string `form:"xsec_token" binding:"required"`
+	Interval  int    `form:"interval"` // 轮询间隔（秒）
+}
+
+// ExportNotesRequest 流式导出笔记请求（query 参数），keyword 与 user_id 二选一
+type ExportNotesRequest struct {
+	Keyword   string `form:"keyword"`
+	UserID    string `form:"user_id"`
+	XsecToken string `form:"xsec_token"` // user_id 范围时必填
+	MaxItems  int    `form:"max_items"`
+}
+
+// scope 导出范围：keyword|user
+func (r *ExportNotesRequest) scope() string {
+	if r.UserID != "" {
+		return "user"
+	}
+	return "keyword"
+}
+
+// VideoCoverRequest 获取视频封面请求
+type VideoCoverRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	Download  bool   `json:"download,omitempty"`
+}
+
+// UpdateProfileRequest 修改账号资料请求
+type UpdateProfileRequest struct {
+	Nickname   string `json:"nickname,omitempty"`
+	Bio        string `json:"bio,omitempty"`
+	AvatarPath string `json:"avatar_path,omitempty"`
+}
+
+// SetAutoReplyRequest 修改私信自动回复请求
+type SetAutoReplyRequest struct {
+	Text    string `json:"text,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+// SetNotificationSettingsRequest 修改通知设置请求
+type SetNotificationSettingsRequest struct {
+	Categories map[string]bool `json:"categories" binding:"required"`
+}
+
+// ProxyTestRequest 代理检测请求
+type ProxyTestRequest struct {
+	ProxyURL string `json:"proxy_url" binding:"required"`
+}
+
+// SavePublishTemplateRequest 保存发布模板请求
+type SavePublishTemplateRequest struct {
+	Name string         `json:"name" binding:"required"`
+	Spec templates.Spec `json:"spec"`
+}
+
+// NoteThreadRequest 获取笔记与完整评论树请求
+type NoteThreadRequest struct {
+	FeedID      string `json:"feed_id" binding:"required"`
+	XsecToken   string `json:"xsec_token" binding:"required"`
+	MaxComments int    `json:"max_comments,omitempty"`
+}
+
+// NoteScreenshotRequest 笔记长截图请求
+type NoteScreenshotRequest struct {
+	FeedID      string `json:"feed_id" binding:"required"`
+	XsecToken   string `json:"xsec_token" binding:"required"`
+	MaxComments int    `json:"max_comments,omitempty"`
+}
+
+// NoteDiscoverabilityRequest 笔记可发现性检测请求，未指定 keywords 时需要 xsec_token
+type NoteDiscoverabilityRequest struct {
+	FeedID    string   `json:"feed_id" binding:"required"`
+	XsecToken string   `json:"xsec_token,omitempty"`
+	Keywords  []string `json:"keywords,omitempty"`
+}
+
+// PlanCrawlRequest 估算抓取计划请求（query 参数）
+type PlanCrawlRequest struct {
+	Scope          string `form:"scope" binding:"required"`
+	MaxItems       int    `form:"max_items"`
+	IncludeDetails bool   `form:"include_details"`
+}
+
+// AccountAggregateStatsRequest 账号汇总统计请求
+type AccountAggregateStatsRequest struct {
+	MaxNotes int  `form:"max_notes" binding:"min=0"`
+	Refresh  bool `form:"refresh"`
+}
+
+// UploadImagesRequest 预上传图片请求
+type UploadImagesRequest struct {
+	Images []string `json:"images" binding:"required,min=1"`
+}
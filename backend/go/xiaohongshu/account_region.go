@@ -0,0 +1,124 @@
+package xiaohongshu
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// BrowserLocale 平台页面读取到的浏览器语言与时区
+type BrowserLocale struct {
+	Language         string   `json:"language"`
+	Languages        []string `json:"languages,omitempty"`
+	Timezone         string   `json:"timezone"`
+	UTCOffsetMinutes int      `json:"utc_offset_minutes"`
+}
+
+// AccountRegion 当前账号在平台上的地区、语言与时区
+type AccountRegion struct {
+	Available bool   `json:"available"`        // 平台是否展示了账号的地区信息
+	Reason    string `json:"reason,omitempty"` // 不可用时的原因
+
+	// Region 个人主页展示的 IP 属地，由平台根据登录 IP 判定，不是可修改的设置
+	Region string `json:"region,omitempty"`
+	// Language 网页端的界面语言（页面 lang 属性）
+	Language string `json:"language,omitempty"`
+	// Timezone 平台没有账号时区设置，始终为 null；请参考 browser.timezone
+	Timezone *string `json:"timezone"`
+
+	Browser BrowserLocale `json:"browser"`
+	// LocaleMismatch 为 true 表示浏览器语言与网页端界面语言不一致，推荐与搜索结果可能受影响
+	LocaleMismatch bool     `json:"locale_mismatch"`
+	Notes          []string `json:"notes,omitempty"`
+}
+
+// AccountRegionAction 读取账号的地区与语言
+type AccountRegionAction struct {
+	page *rod.Page
+}
+
+func NewAccountRegionAction(page *rod.Page) *AccountRegionAction {
+	pp := page.Timeout(60 * time.Second)
+	return &AccountRegionAction{page: pp}
+}
+
+// GetAccountRegion 打开个人主页读取 IP 属地，并读取页面语言与浏览器的语言、时区。
+// 个人主页没有展示 IP 属地时不会报错，而是返回 Available=false。
+func (a *AccountRegionAction) GetAccountRegion(ctx context.Context) (*AccountRegion, error) {
+	page := a.page.Context(ctx)
+
+	profile, err := NewUserProfileAction(page).GetMyProfileViaSidebar(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := page.MustEval(`() => {
+		const tz = Intl.DateTimeFormat().resolvedOptions().timeZone || "";
+		const loc = document.querySelector('.user-IP, [class*="ip-location"], [class*="ipLocation"]');
+		return {
+			lang: document.documentElement.lang || "",
+			language: navigator.language || "",
+			languages: Array.from(navigator.languages || []),
+			timezone: tz,
+			offset: -new Date().getTimezoneOffset(),
+			ipText: loc ? loc.innerText : "",
+		};
+	}`)
+
+	result := &AccountRegion{
+		Region:   normalizeIPLocation(profile.UserBasicInfo.IpLocation),
+		Language: info.Get("lang").Str(),
+		Browser: BrowserLocale{
+			Language:         info.Get("language").Str(),
+			Timezone:         info.Get("timezone").Str(),
+			UTCOffsetMinutes: info.Get("offset").Int(),
+		},
+		Notes: []string{
+			"region 为平台根据登录 IP 判定的 IP 属地，不是账号设置",
+			"平台没有账号时区设置，timezone 为 null，浏览器时区见 browser.timezone",
+		},
+	}
+	for _, l := range info.Get("languages").Arr() {
+		result.Browser.Languages = append(result.Browser.Languages, l.Str())
+	}
+	if result.Region == "" {
+		result.Region = normalizeIPLocation(info.Get("ipText").Str())
+	}
+	result.LocaleMismatch = localeMismatch(result.Language, result.Browser.Language)
+
+	if result.Region == "" {
+		result.Reason = "个人主页没有展示 IP 属地，平台未公开该账号的地区信息"
+		logrus.Info("个人主页没有 IP 属地")
+		return result, nil
+	}
+
+	result.Available = true
+	return result, nil
+}
+
+// normalizeIPLocation 去掉页面文本中的 "IP属地：" 前缀，如 "IP属地：广东" -> "广东"
+func normalizeIPLocation(s string) string {
+	s = strings.TrimSpace(s)
+	for _, prefix := range []string{"IP属地：", "IP属地:", "IP属地"} {
+		if strings.HasPrefix(s, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(s, prefix))
+		}
+	}
+	return s
+}
+
+// localeMismatch 比较页面语言与浏览器语言的主语言部分（如 zh-CN 与 zh-TW 视为一致），任一为空时不判断
+func localeMismatch(pageLang, browserLang string) bool {
+	if pageLang == "" || browserLang == "" {
+		return false
+	}
+	primary := func(lang string) string {
+		lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+		base, _, _ := strings.Cut(lang, "-")
+		return base
+	}
+	return primary(pageLang) != primary(browserLang)
+}
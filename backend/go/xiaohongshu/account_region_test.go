@@ -0,0 +1,21 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeIPLocation(t *testing.T) {
+	require.Equal(t, "广东", normalizeIPLocation("IP属地：广东"))
+	require.Equal(t, "美国", normalizeIPLocation(" IP属地: 美国 "))
+	require.Equal(t, "上海", normalizeIPLocation("上海"))
+	require.Empty(t, normalizeIPLocation(""))
+}
+
+func TestLocaleMismatch(t *testing.T) {
+	require.False(t, localeMismatch("zh-CN", "zh-TW"))
+	require.False(t, localeMismatch("zh", "zh_CN"))
+	require.True(t, localeMismatch("zh-CN", "en-US"))
+	require.False(t, localeMismatch("", "en-US"))
+}
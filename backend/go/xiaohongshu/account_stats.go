@@ -0,0 +1,185 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// AggregateTopNotes 汇总统计中返回的表现最好的笔记数
+const AggregateTopNotes = 5
+
+// ProgressFunc 报告长时间操作的进度，done 单调递增，total 为 0 表示总数未知
+type ProgressFunc func(done, total int, message string)
+
+// StatTotals 互动计数之和
+type StatTotals struct {
+	Likes        int64 `json:"likes"`
+	Collects     int64 `json:"collects"`
+	Comments     int64 `json:"comments"`
+	Shares       int64 `json:"shares"`
+	Interactions int64 `json:"interactions"` // 四项之和
+}
+
+// StatAverages 每篇笔记的平均互动数，保留 2 位小数
+type StatAverages struct {
+	Likes        float64 `json:"likes"`
+	Collects     float64 `json:"collects"`
+	Comments     float64 `json:"comments"`
+	Shares       float64 `json:"shares"`
+	Interactions float64 `json:"interactions"`
+}
+
+// NoteStat 单篇笔记的互动计数
+type NoteStat struct {
+	FeedID       string `json:"feed_id"`
+	XsecToken    string `json:"xsec_token,omitempty"`
+	Title        string `json:"title,omitempty"`
+	Likes        int64  `json:"likes"`
+	Collects     int64  `json:"collects"`
+	Comments     int64  `json:"comments"`
+	Shares       int64  `json:"shares"`
+	Interactions int64  `json:"interactions"`
+}
+
+// AccountAggregateStats 当前账号全部笔记的互动汇总
+type AccountAggregateStats struct {
+	Notes       int          `json:"notes"`                  // 计入统计的笔记数
+	Scanned     int          `json:"scanned"`                // 从个人主页加载的笔记数
+	Complete    bool         `json:"complete"`               // 已加载到主页底部；false 表示因 max_notes 截断
+	FailedNotes []string     `json:"failed_notes,omitempty"` // 读取详情失败、未计入统计的笔记
+	Totals      StatTotals   `json:"totals"`
+	Averages    StatAverages `json:"averages"`
+	TopNotes    []NoteStat   `json:"top_notes"` // 按互动总数从高到低
+	// Approximate 为 true 表示部分计数经过取整（如 "1.2万"），汇总为近似值
+	Approximate bool      `json:"approximate"`
+	ComputedAt  time.Time `json:"computed_at"`
+
+	// 以下由缓存填写
+	Cached    bool      `json:"cached"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AccountStatsAction 汇总当前账号全部笔记的互动数据
+type AccountStatsAction struct {
+	page *rod.Page
+}
+
+func NewAccountStatsAction(page *rod.Page) *AccountStatsAction {
+	return &AccountStatsAction{page: page}
+}
+
+// GetAccountAggregateStats 从个人主页滚动加载最多 maxNotes 篇笔记，逐篇打开详情读取点赞、收藏、评论、分享数并汇总。
+// 每篇笔记之间间隔 interval，耗时随笔记数增长，由 ctx 控制取消；单篇读取失败不影响其他笔记。
+func (a *AccountStatsAction) GetAccountAggregateStats(ctx context.Context, maxNotes int, interval time.Duration, progress ProgressFunc) (*AccountAggregateStats, error) {
+	if progress == nil {
+		progress = func(int, int, string) {}
+	}
+	page := a.page.Context(ctx)
+
+	if _, err := NewUserProfileAction(page).GetMyProfileViaSidebar(ctx); err != nil {
+		return nil, err
+	}
+
+	var feeds []Feed
+	opt := PaginateOption{AutoPaginate: true, MaxItems: maxNotes, Interval: interval}
+	complete, err := streamFeedsByScroll(page, opt, readUserNotes, func(feed Feed) error {
+		feeds = append(feeds, feed)
+		if len(feeds)%10 == 0 {
+			progress(len(feeds), 0, fmt.Sprintf("正在加载笔记列表，已加载 %d 篇", len(feeds)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]NoteStat, 0, len(feeds))
+	var failed []string
+	approximate := false
+	for i, feed := range feeds {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		detail, err := NewFeedDetailAction(page).GetFeedDetail(ctx, feed.ID, feed.XsecToken)
+		if err != nil {
+			logrus.Warnf("读取笔记 %s 的互动数据失败: %v", feed.ID, err)
+			failed = append(failed, feed.ID)
+		} else {
+			stat, exact := newNoteStat(feed, detail.Note)
+			stats = append(stats, stat)
+			approximate = approximate || !exact
+		}
+		// 进度按“加载列表 + 逐篇读取详情”两个阶段合计，保证单调递增
+		progress(len(feeds)+i+1, 2*len(feeds), fmt.Sprintf("已读取 %d/%d 篇笔记的互动数据", i+1, len(feeds)))
+	}
+
+	result := summarizeNoteStats(stats, AggregateTopNotes)
+	result.Scanned = len(feeds)
+	result.Complete = complete
+	result.FailedNotes = failed
+	result.Approximate = approximate
+	result.ComputedAt = time.Now()
+	logrus.Infof("账号汇总统计: %d 篇笔记, %d 篇失败, 互动总数 %d", result.Notes, len(failed), result.Totals.Interactions)
+	return result, nil
+}
+
+// newNoteStat 按详情页的互动数据生成单篇统计，第二个返回值表示计数是否都是精确值
+func newNoteStat(feed Feed, note FeedDetail) (NoteStat, bool) {
+	e := ComputeEngagement(note.InteractInfo, nil, nil)
+	title := note.Title
+	if title == "" {
+		title = feed.NoteCard.DisplayTitle
+	}
+	return NoteStat{
+		FeedID:       feed.ID,
+		XsecToken:    feed.XsecToken,
+		Title:        title,
+		Likes:        e.Likes,
+		Collects:     e.Collects,
+		Comments:     e.Comments,
+		Shares:       e.Shares,
+		Interactions: e.Interactions,
+	}, !e.Approximate
+}
+
+// summarizeNoteStats 计算总数、平均数与互动总数最高的 top 篇笔记，互动数相同时保持主页顺序
+func summarizeNoteStats(stats []NoteStat, top int) *AccountAggregateStats {
+	result := &AccountAggregateStats{Notes: len(stats), TopNotes: []NoteStat{}}
+	for _, s := range stats {
+		result.Totals.Likes += s.Likes
+		result.Totals.Collects += s.Collects
+		result.Totals.Comments += s.Comments
+		result.Totals.Shares += s.Shares
+		result.Totals.Interactions += s.Interactions
+	}
+
+	if n := float64(len(stats)); n > 0 {
+		avg := func(total int64) float64 { return math.Round(float64(total)/n*100) / 100 }
+		result.Averages = StatAverages{
+			Likes:        avg(result.Totals.Likes),
+			Collects:     avg(result.Totals.Collects),
+			Comments:     avg(result.Totals.Comments),
+			Shares:       avg(result.Totals.Shares),
+			Interactions: avg(result.Totals.Interactions),
+		}
+	}
+
+	sorted := append([]NoteStat(nil), stats...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Interactions > sorted[j].Interactions })
+	if len(sorted) > top {
+		sorted = sorted[:top]
+	}
+	result.TopNotes = append(result.TopNotes, sorted...)
+	return result
+}
@@ -0,0 +1,50 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeNoteStats(t *testing.T) {
+	stats := []NoteStat{
+		{FeedID: "a", Likes: 10, Collects: 2, Comments: 1, Interactions: 13},
+		{FeedID: "b", Likes: 100, Collects: 20, Comments: 5, Shares: 1, Interactions: 126},
+		{FeedID: "c", Likes: 0, Interactions: 0},
+		{FeedID: "d", Likes: 10, Collects: 3, Interactions: 13},
+	}
+
+	result := summarizeNoteStats(stats, 3)
+	require.Equal(t, 4, result.Notes)
+	require.Equal(t, StatTotals{Likes: 120, Collects: 25, Comments: 6, Shares: 1, Interactions: 152}, result.Totals)
+	require.Equal(t, 30.0, result.Averages.Likes)
+	require.Equal(t, 6.25, result.Averages.Collects)
+	require.Equal(t, 38.0, result.Averages.Interactions)
+
+	ids := []string{}
+	for _, n := range result.TopNotes {
+		ids = append(ids, n.FeedID)
+	}
+	require.Equal(t, []string{"b", "a", "d"}, ids)
+	require.Equal(t, "a", stats[0].FeedID, "input order must not change")
+}
+
+func TestSummarizeNoteStatsEmpty(t *testing.T) {
+	result := summarizeNoteStats(nil, AggregateTopNotes)
+	require.Zero(t, result.Notes)
+	require.Zero(t, result.Averages.Interactions)
+	require.NotNil(t, result.TopNotes)
+}
+
+func TestNewNoteStat(t *testing.T) {
+	feed := Feed{ID: "n1", XsecToken: "tok", NoteCard: NoteCard{DisplayTitle: "卡片标题"}}
+
+	stat, exact := newNoteStat(feed, FeedDetail{InteractInfo: InteractInfo{LikedCount: "1.2万", CollectedCount: "30", CommentCount: "4"}})
+	require.False(t, exact)
+	require.Equal(t, "卡片标题", stat.Title)
+	require.Equal(t, int64(12000), stat.Likes)
+	require.Equal(t, int64(12034), stat.Interactions)
+
+	_, exact = newNoteStat(feed, FeedDetail{Title: "详情", InteractInfo: InteractInfo{LikedCount: "5"}})
+	require.True(t, exact)
+}
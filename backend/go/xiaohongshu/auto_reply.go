@@ -0,0 +1,147 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxAutoReplyLength 私信自动回复内容的最大字数
+const MaxAutoReplyLength = 200
+
+// 创作者中心私信自动回复入口可能使用的菜单名称
+var autoReplyMenuLabels = []string{"自动回复", "私信自动回复", "私信设置", "私信管理"}
+
+// AutoReplySettings 私信自动回复（欢迎语）设置
+type AutoReplySettings struct {
+	Supported bool   `json:"supported"`
+	Reason    string `json:"reason,omitempty"` // 不支持时的原因
+	Enabled   bool   `json:"enabled"`
+	Text      string `json:"text"`
+	Persisted *bool  `json:"persisted,omitempty"` // 仅设置时返回：重新加载后读取的设置是否与提交一致
+}
+
+// ValidateAutoReply 校验自动回复设置，开启时必须提供内容
+func ValidateAutoReply(text string, enabled bool) error {
+	text = strings.TrimSpace(text)
+	if enabled && text == "" {
+		return fmt.Errorf("开启自动回复时 text 不能为空")
+	}
+	if n := utf8.RuneCountInString(text); n > MaxAutoReplyLength {
+		return fmt.Errorf("自动回复内容 %d 字，超过上限 %d 字", n, MaxAutoReplyLength)
+	}
+	return nil
+}
+
+// AutoReplyAction 读取和修改私信自动回复设置
+type AutoReplyAction struct {
+	page *rod.Page
+}
+
+func NewAutoReplyAction(page *rod.Page) *AutoReplyAction {
+	pp := page.Timeout(60 * time.Second)
+	return &AutoReplyAction{page: pp}
+}
+
+// GetAutoReply 读取当前的自动回复设置。
+// 账号没有该功能时不会报错，而是返回 Supported=false。
+func (a *AutoReplyAction) GetAutoReply(ctx context.Context) (*AutoReplySettings, error) {
+	page := a.page.Context(ctx)
+
+	return openAutoReplySettings(page), nil
+}
+
+// SetAutoReply 修改自动回复设置并保存，随后重新加载页面读取以确认设置已生效。
+// text 为空时保留原有内容，只切换开关。
+func (a *AutoReplyAction) SetAutoReply(ctx context.Context, text string, enabled bool) (*AutoReplySettings, error) {
+	if err := ValidateAutoReply(text, enabled); err != nil {
+		return nil, err
+	}
+	text = strings.TrimSpace(text)
+
+	page := a.page.Context(ctx)
+
+	current := openAutoReplySettings(page)
+	if !current.Supported {
+		return current, nil
+	}
+
+	if text != "" && text != current.Text {
+		input, err := page.Element(`textarea, [contenteditable="true"]`)
+		if err != nil {
+			return nil, fmt.Errorf("没有找到自动回复内容输入框: %w", err)
+		}
+		input.MustSelectAllText().MustInput(text)
+	}
+
+	if enabled != current.Enabled {
+		toggle, err := page.Element(`.d-switch, [class*="switch"], [role="switch"]`)
+		if err != nil {
+			return nil, fmt.Errorf("没有找到自动回复开关: %w", err)
+		}
+		toggle.MustClick()
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	// 部分页面切换开关即保存，没有保存按钮时忽略
+	if save, err := page.Timeout(5*time.Second).ElementR("button", "^(保存|确定|提交)$"); err == nil {
+		save.MustClick()
+		page.MustWaitStable()
+	}
+	time.Sleep(1 * time.Second)
+
+	result := openAutoReplySettings(page)
+	want := text
+	if want == "" {
+		want = current.Text
+	}
+	persisted := result.Supported && result.Enabled == enabled && result.Text == want
+	result.Persisted = &persisted
+	if !persisted {
+		logrus.Warnf("自动回复设置未生效: 期望 enabled=%v text=%q，实际 enabled=%v text=%q",
+			enabled, want, result.Enabled, result.Text)
+	}
+
+	return result, nil
+}
+
+// openAutoReplySettings 打开创作者中心的自动回复设置并读取当前状态
+func openAutoReplySettings(page *rod.Page) *AutoReplySettings {
+	page.MustNavigate(urlOfCreatorHome).MustWaitIdle().MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if _, err := openCreatorSection(page, autoReplyMenuLabels); err != nil {
+		logrus.Infof("未找到自动回复入口: %v", err)
+		return &AutoReplySettings{Reason: "当前账号不支持私信自动回复，或创作者中心未提供该设置"}
+	}
+
+	state := page.MustEval(`() => {
+		const toggle = document.querySelector('.d-switch, [class*="switch"], [role="switch"]');
+		const input = document.querySelector('textarea, [contenteditable="true"]');
+		if (!toggle && !input) return null;
+		let enabled = false;
+		if (toggle) {
+			const cls = toggle.className || "";
+			enabled = toggle.getAttribute('aria-checked') === 'true' || /checked|active|\bon\b/.test(cls);
+		}
+		let text = "";
+		if (input) {
+			text = input.value !== undefined ? input.value : input.innerText;
+		}
+		return {enabled, text: (text || "").trim()};
+	}`)
+	if state.Nil() {
+		return &AutoReplySettings{Reason: "自动回复页面没有可用的设置项，账号可能不满足条件"}
+	}
+
+	return &AutoReplySettings{
+		Supported: true,
+		Enabled:   state.Get("enabled").Bool(),
+		Text:      state.Get("text").Str(),
+	}
+}
@@ -0,0 +1,16 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAutoReply(t *testing.T) {
+	require.NoError(t, ValidateAutoReply("你好，稍后回复你", true))
+	require.NoError(t, ValidateAutoReply("", false))
+	require.Error(t, ValidateAutoReply("  ", true))
+	require.NoError(t, ValidateAutoReply(strings.Repeat("好", MaxAutoReplyLength), true))
+	require.Error(t, ValidateAutoReply(strings.Repeat("好", MaxAutoReplyLength+1), true))
+}
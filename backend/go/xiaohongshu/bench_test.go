@@ -0,0 +1,105 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// 基准测试覆盖抓取路径上的纯解析部分：搜索结果与评论树。运行方式：
+//
+//	go test ./xiaohongshu -run '^$' -bench . -benchmem
+
+func benchFeedsJSON(b *testing.B, n int) string {
+	feeds := make([]Feed, n)
+	for i := range feeds {
+		feeds[i] = Feed{
+			ID:        fmt.Sprintf("feed%04d", i),
+			XsecToken: "ABxsectoken",
+			ModelType: "note",
+			NoteCard: NoteCard{
+				Type:         "normal",
+				DisplayTitle: fmt.Sprintf("第 %d 篇笔记的标题", i),
+				User:         User{UserID: fmt.Sprintf("user%04d", i), Nickname: "小红薯"},
+				InteractInfo: InteractInfo{LikedCount: "1.2万", CommentCount: "345"},
+				Cover:        Cover{Width: 1080, Height: 1440, URLDefault: "https://sns-webpic-qc.xhscdn.com/cover!nd_dft_wlteh_webp_3"},
+			},
+		}
+	}
+	data, err := json.Marshal(feeds)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return string(data)
+}
+
+func benchCommentsJSON(b *testing.B, n, replies, depth int) string {
+	var build func(prefix string, level int) []Comment
+	build = func(prefix string, level int) []Comment {
+		count := replies
+		if level == 0 {
+			count = n
+		}
+		comments := make([]Comment, count)
+		for i := range comments {
+			id := fmt.Sprintf("%s-%d", prefix, i)
+			comments[i] = Comment{
+				ID:              id,
+				Content:         "这条评论用于基准测试，长度接近真实评论",
+				UserInfo:        User{UserID: "u" + id, Nickname: "评论者"},
+				LikeCount:       "12",
+				SubCommentCount: fmt.Sprint(replies),
+			}
+			if level < depth {
+				comments[i].SubComments = build(id, level+1)
+			}
+		}
+		return comments
+	}
+
+	data, err := json.Marshal(CommentList{List: build("c", 0), Cursor: "cursor", HasMore: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return string(data)
+}
+
+func BenchmarkParseFeeds(b *testing.B) {
+	data := benchFeedsJSON(b, 200)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := parseFeeds(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseComments(b *testing.B) {
+	data := benchCommentsJSON(b, 100, 5, 2)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := parseComments(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLimitCommentDepth(b *testing.B) {
+	data := benchCommentsJSON(b, 100, 5, 3)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		list, err := parseComments(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		limitCommentDepth(list.List, 1, DefaultCommentDepth)
+	}
+}
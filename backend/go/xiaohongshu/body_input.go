@@ -0,0 +1,113 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// 正文分段输入的参数：编辑器一次粘贴过长的文本会丢字
+const (
+	bodyChunkSize     = 200 // 每段字数（按字符计）
+	bodyChunkPause    = 150 * time.Millisecond
+	bodyInputAttempts = 3
+)
+
+// inputBody 分段输入正文，输入后读回编辑器内容比对，不一致时清空重新输入
+func inputBody(contentElem *rod.Element, content string) error {
+	chunks := splitChunks(content, bodyChunkSize)
+
+	for attempt := 1; attempt <= bodyInputAttempts; attempt++ {
+		if attempt > 1 {
+			if err := clearEditor(contentElem); err != nil {
+				return err
+			}
+		}
+
+		for i, chunk := range chunks {
+			contentElem.MustInput(chunk)
+			if i < len(chunks)-1 {
+				time.Sleep(bodyChunkPause)
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+
+		got, err := contentElem.Text()
+		if err != nil {
+			return errors.Wrap(err, "读取正文失败")
+		}
+		if bodyMatches(got, content) {
+			return nil
+		}
+		logrus.Warnf("正文输入不完整（第 %d 次）: 期望 %d 字，实际 %d 字", attempt, len([]rune(content)), len([]rune(got)))
+	}
+
+	return errors.Errorf("正文输入 %d 次后仍与原文不一致", bodyInputAttempts)
+}
+
+// clearEditor 清空编辑器中的内容。不用 Ctrl+A 快捷键（macOS 上全选是 Cmd+A），
+// 而是用 Selection 选中全部内容后删除，编辑器仍有残留时直接置空并触发 input 事件
+func clearEditor(contentElem *rod.Element) error {
+	_, err := contentElem.Eval(`function () {
+		this.focus();
+		const range = document.createRange();
+		range.selectNodeContents(this);
+		const selection = window.getSelection();
+		selection.removeAllRanges();
+		selection.addRange(range);
+		document.execCommand('delete');
+		if ((this.innerText || "").trim() !== "") {
+			this.innerHTML = "";
+			this.dispatchEvent(new Event('input', { bubbles: true }));
+		}
+	}`)
+	if err != nil {
+		return errors.Wrap(err, "清空正文失败")
+	}
+	time.Sleep(300 * time.Millisecond)
+	return nil
+}
+
+// splitChunks 按字符把文本切成不超过 size 的段，不会切断多字节字符；
+// 尽量在换行处断开，避免段落被拆到两次输入中
+func splitChunks(text string, size int) []string {
+	runes := []rune(text)
+	if size <= 0 || len(runes) <= size {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(runes) > size {
+		cut := size
+		for i := size; i > size/2; i-- {
+			if runes[i-1] == '\n' {
+				cut = i
+				break
+			}
+		}
+		chunks = append(chunks, string(runes[:cut]))
+		runes = runes[cut:]
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
+
+// bodyMatches 比较编辑器读回的正文与原文，忽略编辑器对空白和换行的调整
+func bodyMatches(got, want string) bool {
+	return stripSpace(got) == stripSpace(want)
+}
+
+func stripSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
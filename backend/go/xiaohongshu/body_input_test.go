@@ -0,0 +1,41 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitChunks(t *testing.T) {
+	// 1000 字的正文，中英文混排并带换行
+	body := string([]rune(strings.Repeat("今天去了西湖，天气很好 sunny!\n", 60))[:1000])
+	require.Equal(t, 1000, utf8.RuneCountInString(body))
+
+	chunks := splitChunks(body, bodyChunkSize)
+	require.Greater(t, len(chunks), 1)
+	require.Equal(t, body, strings.Join(chunks, ""))
+	for _, chunk := range chunks {
+		require.True(t, utf8.ValidString(chunk))
+		require.LessOrEqual(t, utf8.RuneCountInString(chunk), bodyChunkSize)
+	}
+
+	require.Equal(t, []string{"短正文"}, splitChunks("短正文", bodyChunkSize))
+	require.Equal(t, []string{""}, splitChunks("", bodyChunkSize))
+}
+
+func TestSplitChunksPrefersNewline(t *testing.T) {
+	text := strings.Repeat("字", 8) + "\n" + strings.Repeat("文", 8)
+
+	chunks := splitChunks(text, 10)
+	require.Equal(t, []string{strings.Repeat("字", 8) + "\n", strings.Repeat("文", 8)}, chunks)
+}
+
+func TestBodyMatches(t *testing.T) {
+	want := "第一段\n\n第二段 hello"
+
+	require.True(t, bodyMatches("第一段\n第二段 hello\n", want))
+	require.False(t, bodyMatches("第一段\n第二 hello", want))
+	require.False(t, bodyMatches("", want))
+}
@@ -0,0 +1,173 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 笔记数据中可能存放合著者的字段，平台不同版本字段名不一致
+var coAuthorKeys = []string{"coAuthors", "coAuthorList", "cooperateUsers", "collaborators"}
+
+// 笔记数据中可能存放合作/赞助标识的字段，元素为字符串或带 name/text 的对象
+var disclosureKeys = []string{"cooperateBinds", "brandLabels", "noteLabels", "adLabels"}
+
+// 合作披露标签中的关键字，用于过滤 DOM 中的标签文本
+var disclosureMarkers = []string{"合作", "赞助", "广告", "推广", "品牌"}
+
+// 详情页上可能展示合作披露标签的元素
+const disclosureLabelSelector = `.note-container [class*="cooperate"], .note-container [class*="brand"], .note-container [class*="sponsor"], .note-container [class*="ad-tag"]`
+
+// NoteCollaboration 笔记的合著者与合作披露信息，没有时为空列表
+type NoteCollaboration struct {
+	FeedID    string   `json:"feed_id"`
+	CoAuthors []User   `json:"co_authors"`
+	Labels    []string `json:"labels"` // 如 "品牌合作"、"赞助"
+}
+
+// CollaboratorsAction 获取笔记的合著者与合作披露信息
+type CollaboratorsAction struct {
+	page *rod.Page
+}
+
+func NewCollaboratorsAction(page *rod.Page) *CollaboratorsAction {
+	return &CollaboratorsAction{page: page}
+}
+
+// GetCollaborators 打开笔记详情页，从 __INITIAL_STATE__ 和页面标签中读取合著者与合作披露
+func (a *CollaboratorsAction) GetCollaborators(ctx context.Context, feedID, xsecToken string) (*NoteCollaboration, error) {
+	page := a.page.Context(ctx).Timeout(60 * time.Second)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	raw := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		if (state && state.note && state.note.noteDetailMap && state.note.noteDetailMap[feedID]) {
+			return JSON.stringify(state.note.noteDetailMap[feedID].note || {});
+		}
+		return "";
+	}`, feedID).String()
+	if raw == "" {
+		return nil, fmt.Errorf("feed %s not found in noteDetailMap", feedID)
+	}
+
+	result, err := parseCollaboration(feedID, []byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var domLabels []string
+	if elements, err := page.Elements(disclosureLabelSelector); err == nil {
+		for _, el := range elements {
+			if text, err := el.Text(); err == nil {
+				domLabels = append(domLabels, text)
+			}
+		}
+	}
+	result.Labels = mergeLabels(result.Labels, filterDisclosureLabels(domLabels))
+
+	logrus.Infof("笔记 %s 合作信息: 合著者 %d 个, 标签 %v", feedID, len(result.CoAuthors), result.Labels)
+	return result, nil
+}
+
+// parseCollaboration 从笔记原始 JSON 中提取合著者与合作披露标签
+func parseCollaboration(feedID string, raw []byte) (*NoteCollaboration, error) {
+	var note map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &note); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal note: %w", err)
+	}
+
+	result := &NoteCollaboration{FeedID: feedID, CoAuthors: []User{}, Labels: []string{}}
+
+	seen := make(map[string]bool)
+	for _, key := range coAuthorKeys {
+		var users []User
+		if err := json.Unmarshal(note[key], &users); err != nil {
+			continue
+		}
+		for _, user := range users {
+			if user.UserID == "" || seen[user.UserID] {
+				continue
+			}
+			seen[user.UserID] = true
+			result.CoAuthors = append(result.CoAuthors, user)
+		}
+	}
+
+	for _, key := range disclosureKeys {
+		var items []json.RawMessage
+		if err := json.Unmarshal(note[key], &items); err != nil {
+			continue
+		}
+		for _, item := range items {
+			if label := disclosureLabelText(item); label != "" {
+				result.Labels = mergeLabels(result.Labels, []string{label})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// disclosureLabelText 读取标签文本，元素可能是字符串或带 name/text 字段的对象
+func disclosureLabelText(item json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(item, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	var obj struct {
+		Name string `json:"name"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(item, &obj); err != nil {
+		return ""
+	}
+	if obj.Name != "" {
+		return strings.TrimSpace(obj.Name)
+	}
+	return strings.TrimSpace(obj.Text)
+}
+
+// filterDisclosureLabels 只保留包含合作/赞助等关键字的标签文本
+func filterDisclosureLabels(texts []string) []string {
+	var labels []string
+	for _, text := range texts {
+		text = strings.TrimSpace(text)
+		for _, marker := range disclosureMarkers {
+			if strings.Contains(text, marker) {
+				labels = append(labels, text)
+				break
+			}
+		}
+	}
+	return labels
+}
+
+// mergeLabels 合并标签并去重，保持先后顺序
+func mergeLabels(labels, more []string) []string {
+	for _, label := range more {
+		exists := false
+		for _, l := range labels {
+			if l == label {
+				exists = true
+				break
+			}
+		}
+		if !exists && label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
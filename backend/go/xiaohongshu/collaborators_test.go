@@ -0,0 +1,36 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCollaboration(t *testing.T) {
+	raw := []byte(`{
+		"noteId": "n1",
+		"coAuthors": [{"userId": "u1", "nickname": "小明"}, {"userId": "u1", "nickname": "小明"}],
+		"cooperateUsers": [{"userId": "u2", "nickname": "小红"}],
+		"cooperateBinds": [{"name": "品牌合作"}, "赞助"],
+		"noteLabels": [{"text": "品牌合作"}]
+	}`)
+
+	result, err := parseCollaboration("n1", raw)
+	require.NoError(t, err)
+	require.Len(t, result.CoAuthors, 2)
+	require.Equal(t, "u2", result.CoAuthors[1].UserID)
+	require.Equal(t, []string{"品牌合作", "赞助"}, result.Labels)
+
+	empty, err := parseCollaboration("n2", []byte(`{"noteId": "n2"}`))
+	require.NoError(t, err)
+	require.NotNil(t, empty.CoAuthors)
+	require.Empty(t, empty.CoAuthors)
+	require.NotNil(t, empty.Labels)
+	require.Empty(t, empty.Labels)
+}
+
+func TestFilterDisclosureLabels(t *testing.T) {
+	labels := filterDisclosureLabels([]string{" 品牌合作 ", "关注", "广告", ""})
+	require.Equal(t, []string{"品牌合作", "广告"}, labels)
+	require.Equal(t, []string{"赞助", "广告"}, mergeLabels([]string{"赞助"}, labels[1:]))
+}
@@ -33,15 +33,21 @@ func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken,
 
 	time.Sleep(1 * time.Second)
 
-	elem := page.MustElement("div.input-box div.content-edit span")
+	if err := passContentGate(page); err != nil {
+		return err
+	}
+
+	sel := detectUIVariant(page).Selectors
+
+	elem := page.MustElement(sel.CommentInputTrigger)
 	elem.MustClick()
 
-	elem2 := page.MustElement("div.input-box div.content-edit p.content-input")
+	elem2 := page.MustElement(sel.CommentInput)
 	elem2.MustInput(content)
 
 	time.Sleep(1 * time.Second)
 
-	submitButton := page.MustElement("div.bottom button.submit")
+	submitButton := page.MustElement(sel.CommentSubmit)
 	submitButton.MustClick()
 
 	time.Sleep(1 * time.Second)
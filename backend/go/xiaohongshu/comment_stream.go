@@ -0,0 +1,107 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// CommentStream 在同一个详情页上反复刷新，返回新出现的评论
+type CommentStream struct {
+	page      *rod.Page
+	feedID    string
+	xsecToken string
+	seen      map[string]bool
+}
+
+// OpenCommentStream 打开笔记详情页并记录当前已有的评论，之后每次 Poll 只返回新评论
+func (a *CommentsAction) OpenCommentStream(ctx context.Context, feedID, xsecToken string) (*CommentStream, error) {
+	stream := &CommentStream{
+		page:      a.page.Context(ctx),
+		feedID:    feedID,
+		xsecToken: xsecToken,
+		seen:      make(map[string]bool),
+	}
+
+	comments, err := stream.load(true)
+	if err != nil {
+		return nil, err
+	}
+	stream.diff(comments.List)
+
+	logrus.Infof("评论流已建立: feed=%s, 已有评论 %d 条", feedID, len(stream.seen))
+	return stream, nil
+}
+
+// Poll 刷新页面，返回上次读取之后新出现的评论（含新回复）
+func (s *CommentStream) Poll() ([]Comment, error) {
+	comments, err := s.load(false)
+	if err != nil {
+		return nil, err
+	}
+	return s.diff(comments.List), nil
+}
+
+// load 导航或刷新详情页，并切换到按时间排序，保证新评论在已加载的第一页内。
+// 页面超时等错误都作为本次轮询的错误返回，不中断评论流
+func (s *CommentStream) load(navigate bool) (list *CommentList, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("读取评论失败: %v", r)
+		}
+	}()
+
+	page := s.page.Timeout(60 * time.Second)
+
+	if navigate {
+		if err := page.Navigate(makeFeedDetailURL(s.feedID, s.xsecToken)); err != nil {
+			return nil, fmt.Errorf("打开笔记详情页失败: %w", err)
+		}
+	} else if err := page.Reload(); err != nil {
+		return nil, fmt.Errorf("刷新笔记详情页失败: %w", err)
+	}
+	if err := page.WaitDOMStable(time.Second, 0); err != nil {
+		return nil, fmt.Errorf("等待笔记详情页加载失败: %w", err)
+	}
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	if err := switchCommentSort(page, commentSortLabels[CommentSortTime]); err != nil {
+		logrus.Warnf("评论流切换为最新排序失败，使用默认排序: %v", err)
+	}
+
+	return readCommentsFromState(page, s.feedID)
+}
+
+// diff 返回未见过的评论并标记为已见。新的一级评论连同其回复一起返回；
+// 已见的一级评论下新增的回复单独返回。
+func (s *CommentStream) diff(list []Comment) []Comment {
+	var fresh []Comment
+
+	for _, comment := range list {
+		if !s.seen[comment.ID] {
+			s.seen[comment.ID] = true
+			for _, sub := range comment.SubComments {
+				s.seen[sub.ID] = true
+			}
+			fresh = append(fresh, comment)
+			continue
+		}
+
+		for _, sub := range comment.SubComments {
+			if s.seen[sub.ID] {
+				continue
+			}
+			s.seen[sub.ID] = true
+			fresh = append(fresh, sub)
+		}
+	}
+
+	return fresh
+}
@@ -0,0 +1,30 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentStreamDiff(t *testing.T) {
+	stream := &CommentStream{seen: make(map[string]bool)}
+
+	initial := []Comment{
+		{ID: "c1", SubComments: []Comment{{ID: "r1"}}},
+		{ID: "c2"},
+	}
+	require.Len(t, stream.diff(initial), 2)
+	require.Empty(t, stream.diff(initial))
+
+	next := []Comment{
+		{ID: "c3", SubComments: []Comment{{ID: "r3"}}},
+		{ID: "c1", SubComments: []Comment{{ID: "r1"}, {ID: "r2"}}},
+		{ID: "c2"},
+	}
+	fresh := stream.diff(next)
+	require.Len(t, fresh, 2)
+	require.Equal(t, "c3", fresh[0].ID)
+	require.Equal(t, "r2", fresh[1].ID)
+
+	require.Empty(t, stream.diff(next))
+}
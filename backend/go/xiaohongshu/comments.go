@@ -0,0 +1,239 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 评论排序方式
+const (
+	CommentSortDefault = ""     // 平台默认排序
+	CommentSortHot     = "hot"  // 最热
+	CommentSortTime    = "time" // 最新
+)
+
+// 排序方式与页面上排序切换按钮文本的对应关系
+var commentSortLabels = map[string]string{
+	CommentSortHot:  "最热",
+	CommentSortTime: "最新",
+}
+
+// 评论树深度：1 只返回一级评论，2 同时返回回复
+const (
+	DefaultCommentDepth = 2
+	MaxCommentDepth     = 5
+)
+
+// 最多点击多少次“展开回复”，避免热门笔记上无休止地加载
+const maxReplyExpansions = 10
+
+// CommentsOption 获取评论的选项
+type CommentsOption struct {
+	Sort     string // hot|time，为空表示平台默认排序
+	MaxDepth int    // 评论树最大深度，0 表示 DefaultCommentDepth
+}
+
+// ValidateCommentSort 校验评论排序方式
+func ValidateCommentSort(sort string) error {
+	if sort == CommentSortDefault {
+		return nil
+	}
+	if _, ok := commentSortLabels[sort]; !ok {
+		return fmt.Errorf("无效的评论排序方式 %q，可选值: hot|time", sort)
+	}
+	return nil
+}
+
+// ValidateCommentDepth 校验评论树深度，0 表示使用默认深度
+func ValidateCommentDepth(depth int) error {
+	if depth < 0 || depth > MaxCommentDepth {
+		return fmt.Errorf("无效的评论深度 %d，可选范围 1-%d", depth, MaxCommentDepth)
+	}
+	return nil
+}
+
+// CommentsAction 获取笔记评论
+type CommentsAction struct {
+	page *rod.Page
+}
+
+func NewCommentsAction(page *rod.Page) *CommentsAction {
+	return &CommentsAction{page: page}
+}
+
+// GetComments 打开笔记详情页，按指定排序读取评论列表
+func (a *CommentsAction) GetComments(ctx context.Context, feedID, xsecToken string, opt CommentsOption) (*CommentList, error) {
+	if err := ValidateCommentSort(opt.Sort); err != nil {
+		return nil, err
+	}
+	if err := ValidateCommentDepth(opt.MaxDepth); err != nil {
+		return nil, err
+	}
+	maxDepth := opt.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultCommentDepth
+	}
+
+	page := a.page.Context(ctx).Timeout(60 * time.Second)
+
+	url := makeFeedDetailURL(feedID, xsecToken)
+	logrus.Infof("打开 feed 详情页读取评论: %s", url)
+
+	page.MustNavigate(url)
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	if opt.Sort != CommentSortDefault {
+		if err := switchCommentSort(page, commentSortLabels[opt.Sort]); err != nil {
+			return nil, err
+		}
+	}
+
+	if maxDepth > 1 {
+		expandReplies(page, maxReplyExpansions)
+	}
+
+	comments, err := readCommentsFromState(page, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments.DepthTruncated = limitCommentDepth(comments.List, 1, maxDepth)
+	return comments, nil
+}
+
+// expandReplies 点击评论区的“展开回复”按钮加载更多回复，最多点击 limit 次
+func expandReplies(page *rod.Page, limit int) {
+	clicked := 0
+	for clicked < limit {
+		elems, err := page.Elements(`.show-more, [class*="show-more"]`)
+		if err != nil {
+			return
+		}
+
+		found := false
+		for _, elem := range elems {
+			text, err := elem.Text()
+			if err != nil || !strings.Contains(text, "展开") {
+				continue
+			}
+			if visible, err := elem.Visible(); err != nil || !visible {
+				continue
+			}
+
+			found = true
+			elem.MustScrollIntoView()
+			elem.MustClick()
+			time.Sleep(500 * time.Millisecond)
+			clicked++
+			break
+		}
+		if !found {
+			break
+		}
+	}
+
+	if clicked > 0 {
+		page.MustWaitStable()
+		logrus.Infof("已展开 %d 次评论回复", clicked)
+	}
+}
+
+// limitCommentDepth 裁剪深度超过 maxDepth 的回复，depth 为 comments 所在层级（一级评论为 1）。
+// 被裁剪或回复未全部加载的评论标记 RepliesTruncated，返回是否有任何截断。
+func limitCommentDepth(comments []Comment, depth, maxDepth int) bool {
+	truncated := false
+	for i := range comments {
+		c := &comments[i]
+
+		if depth >= maxDepth {
+			if len(c.SubComments) > 0 || subCommentCount(c) > 0 {
+				c.SubComments = nil
+				c.RepliesTruncated = true
+			}
+		} else {
+			if limitCommentDepth(c.SubComments, depth+1, maxDepth) {
+				truncated = true
+			}
+			if subCommentCount(c) > len(c.SubComments) {
+				c.RepliesTruncated = true
+			}
+		}
+
+		if c.RepliesTruncated {
+			truncated = true
+		}
+	}
+	return truncated
+}
+
+// subCommentCount 解析平台给出的回复数，无法解析时为 0
+func subCommentCount(c *Comment) int {
+	n, err := strconv.Atoi(strings.TrimSpace(c.SubCommentCount))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// switchCommentSort 点击评论区的排序切换按钮
+func switchCommentSort(page *rod.Page, label string) error {
+	elems, err := page.Elements(detectUIVariant(page).Selectors.CommentSortItem)
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		text, err := elem.Text()
+		if err != nil || strings.TrimSpace(text) != label {
+			continue
+		}
+
+		elem.MustClick()
+		page.MustWaitStable()
+		time.Sleep(500 * time.Millisecond)
+		return nil
+	}
+
+	return fmt.Errorf("没有找到评论排序选项 - %s", label)
+}
+
+// readCommentsFromState 从 __INITIAL_STATE__ 中读取笔记当前已加载的评论
+func readCommentsFromState(page *rod.Page, feedID string) (*CommentList, error) {
+	result := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		if (map && map[feedID] && map[feedID].comments) {
+			return JSON.stringify(map[feedID].comments);
+		}
+		return "";
+	}`, feedID).String()
+
+	if result == "" {
+		return nil, errors.ErrNoFeedDetail
+	}
+
+	return parseComments(result)
+}
+
+// parseComments 解析 __INITIAL_STATE__ 中序列化的评论列表（含嵌套回复）
+func parseComments(data string) (*CommentList, error) {
+	var comments CommentList
+	if err := json.Unmarshal([]byte(data), &comments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comments: %w", err)
+	}
+
+	return &comments, nil
+}
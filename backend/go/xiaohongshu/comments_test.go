@@ -0,0 +1,51 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCommentDepth(t *testing.T) {
+	require.NoError(t, ValidateCommentDepth(0))
+	require.NoError(t, ValidateCommentDepth(1))
+	require.NoError(t, ValidateCommentDepth(MaxCommentDepth))
+	require.Error(t, ValidateCommentDepth(-1))
+	require.Error(t, ValidateCommentDepth(MaxCommentDepth+1))
+}
+
+func TestLimitCommentDepth(t *testing.T) {
+	newTree := func() []Comment {
+		return []Comment{
+			{
+				ID:              "c1",
+				SubCommentCount: "2",
+				SubComments: []Comment{
+					{ID: "r1", SubComments: []Comment{{ID: "rr1"}}},
+					{ID: "r2"},
+				},
+			},
+			{ID: "c2", SubCommentCount: "5", SubComments: []Comment{{ID: "r3"}}},
+			{ID: "c3", SubCommentCount: "0"},
+		}
+	}
+
+	comments := newTree()
+	require.True(t, limitCommentDepth(comments, 1, 1))
+	require.Nil(t, comments[0].SubComments)
+	require.True(t, comments[0].RepliesTruncated)
+	require.True(t, comments[1].RepliesTruncated)
+	require.False(t, comments[2].RepliesTruncated)
+
+	comments = newTree()
+	require.True(t, limitCommentDepth(comments, 1, 2))
+	require.False(t, comments[0].RepliesTruncated)
+	require.Len(t, comments[0].SubComments, 2)
+	require.Nil(t, comments[0].SubComments[0].SubComments)
+	require.True(t, comments[0].SubComments[0].RepliesTruncated)
+	require.True(t, comments[1].RepliesTruncated, "只加载了 1/5 条回复")
+
+	comments = newTree()[:1]
+	require.False(t, limitCommentDepth(comments, 1, 3))
+	require.Len(t, comments[0].SubComments[0].SubComments, 1)
+}
@@ -0,0 +1,106 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// 抓取范围
+const (
+	CrawlScopeHome          = "home"           // 首页推荐：list_feeds 自动翻页
+	CrawlScopeSearch        = "search"         // 关键词搜索：search_feeds 自动翻页
+	CrawlScopeExportKeyword = "export_keyword" // 按关键词流式导出
+	CrawlScopeExportUser    = "export_user"    // 按用户主页流式导出
+)
+
+// 估算抓取耗时的经验值
+const (
+	crawlItemsPerLoad   = 20              // 每次加载（首屏或一次滚动）返回的笔记数
+	crawlBrowserStartup = 3 * time.Second // 每次工具调用启动浏览器的耗时
+	crawlPageLoad       = 3 * time.Second // 打开列表页并等待稳定的耗时
+	crawlDetailLoad     = 3 * time.Second // 打开一篇笔记详情的耗时（不含启动浏览器）
+)
+
+// CrawlPlan 抓取计划：不访问平台，只按当前的翻页间隔与上限估算请求数和耗时
+type CrawlPlan struct {
+	Scope          string `json:"scope"`
+	ScopeLabel     string `json:"scope_label"`
+	Tool           string `json:"tool"` // 执行该抓取的工具或接口
+	RequestedItems int    `json:"requested_items"`
+	MaxItems       int    `json:"max_items"` // 按默认值与硬上限规范后的条数
+	Clamped        bool   `json:"clamped"`   // 请求的条数超过硬上限被截断
+	IncludeDetails bool   `json:"include_details"`
+	PageInterval   string `json:"page_interval"`
+
+	PageLoads         int     `json:"page_loads"`         // 打开页面的次数（列表页与详情页）
+	Scrolls           int     `json:"scrolls"`            // 列表页滚动加载的次数
+	BrowserLaunches   int     `json:"browser_launches"`   // 启动浏览器的次数
+	EstimatedRequests int     `json:"estimated_requests"` // 访问平台的请求数：打开页面 + 滚动加载
+	EstimatedSeconds  int     `json:"estimated_seconds"`
+	EstimatedDuration string  `json:"estimated_duration"`
+	RequestsPerMinute float64 `json:"requests_per_minute"`
+
+	Assumptions []string `json:"assumptions"`
+}
+
+// PlanCrawl 估算抓取 maxItems 条笔记的请求数与耗时。includeDetails 表示之后逐条调用 get_feed_detail 读取详情。
+// 翻页间隔与条数上限与实际抓取使用同一份配置。
+func PlanCrawl(scope string, maxItems int, includeDetails bool) (*CrawlPlan, error) {
+	plan := &CrawlPlan{
+		Scope:          scope,
+		RequestedItems: maxItems,
+		IncludeDetails: includeDetails,
+	}
+
+	switch scope {
+	case CrawlScopeHome:
+		plan.Tool = "list_feeds (auto_paginate=true)"
+		plan.MaxItems = configs.ClampMaxItems(maxItems)
+	case CrawlScopeSearch:
+		plan.Tool = "search_feeds (auto_paginate=true)"
+		plan.MaxItems = configs.ClampMaxItems(maxItems)
+	case CrawlScopeExportKeyword:
+		plan.Tool = "GET /api/v1/export/notes?keyword="
+		plan.MaxItems = configs.ClampExportItems(maxItems)
+	case CrawlScopeExportUser:
+		plan.Tool = "GET /api/v1/export/notes?user_id="
+		plan.MaxItems = configs.ClampExportItems(maxItems)
+	default:
+		return nil, fmt.Errorf("无效的抓取范围 %q，可选: %s|%s|%s|%s", scope,
+			CrawlScopeHome, CrawlScopeSearch, CrawlScopeExportKeyword, CrawlScopeExportUser)
+	}
+	plan.ScopeLabel = EnumLabel(EnumCrawlScope, scope)
+	plan.Clamped = maxItems > plan.MaxItems
+
+	interval := configs.GetPageInterval()
+	plan.PageInterval = interval.String()
+
+	loads := (plan.MaxItems + crawlItemsPerLoad - 1) / crawlItemsPerLoad
+	plan.Scrolls = loads - 1
+	plan.PageLoads = 1
+	plan.BrowserLaunches = 1
+	elapsed := crawlBrowserStartup + crawlPageLoad + time.Duration(plan.Scrolls)*interval
+
+	if includeDetails {
+		// 每次 get_feed_detail 都会启动一次浏览器，调用之间按翻页间隔等待
+		plan.PageLoads += plan.MaxItems
+		plan.BrowserLaunches += plan.MaxItems
+		elapsed += time.Duration(plan.MaxItems) * (crawlBrowserStartup + crawlDetailLoad + interval)
+	}
+
+	plan.EstimatedRequests = plan.PageLoads + plan.Scrolls
+	plan.EstimatedSeconds = int(math.Ceil(elapsed.Seconds()))
+	plan.EstimatedDuration = (time.Duration(plan.EstimatedSeconds) * time.Second).String()
+	plan.RequestsPerMinute = math.Round(float64(plan.EstimatedRequests)/elapsed.Minutes()*10) / 10
+
+	plan.Assumptions = []string{
+		fmt.Sprintf("每次加载约返回 %d 条笔记，实际数量随页面布局变化", crawlItemsPerLoad),
+		fmt.Sprintf("两次滚动加载之间等待 %s（-page-interval）", interval),
+		fmt.Sprintf("启动浏览器约 %s，打开列表页约 %s，打开详情页约 %s", crawlBrowserStartup, crawlPageLoad, crawlDetailLoad),
+		fmt.Sprintf("内容不足时会提前结束：连续 %d 次滚动没有新内容或出现结束标记即停止，实际条数和耗时都会更少", maxIdleScrolls),
+	}
+	return plan, nil
+}
@@ -0,0 +1,41 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanCrawl(t *testing.T) {
+	plan, err := PlanCrawl(CrawlScopeSearch, 100, false)
+	require.NoError(t, err)
+	require.Equal(t, 100, plan.MaxItems)
+	require.False(t, plan.Clamped)
+	require.Equal(t, 4, plan.Scrolls)
+	require.Equal(t, 1, plan.PageLoads)
+	require.Equal(t, 5, plan.EstimatedRequests)
+	// 启动 3s + 打开 3s + 4 次滚动 * 默认间隔 2s
+	require.Equal(t, 14, plan.EstimatedSeconds)
+	require.Equal(t, "14s", plan.EstimatedDuration)
+	require.Equal(t, "关键词搜索", plan.ScopeLabel)
+
+	clamped, err := PlanCrawl(CrawlScopeHome, 10000, false)
+	require.NoError(t, err)
+	require.True(t, clamped.Clamped)
+	require.Equal(t, 500, clamped.MaxItems)
+
+	export, err := PlanCrawl(CrawlScopeExportUser, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, 1000, export.MaxItems)
+	require.Equal(t, 49, export.Scrolls)
+
+	details, err := PlanCrawl(CrawlScopeSearch, 20, true)
+	require.NoError(t, err)
+	require.Equal(t, 0, details.Scrolls)
+	require.Equal(t, 21, details.PageLoads)
+	require.Equal(t, 21, details.BrowserLaunches)
+	require.Equal(t, 6+20*8, details.EstimatedSeconds)
+
+	_, err = PlanCrawl("topic", 10, false)
+	require.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/stealth"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 笔记可发现性检测结果
+const (
+	DiscoverabilitySurfaced     = "surfaced"     // 未登录搜索能搜到笔记
+	DiscoverabilityNotSurfaced  = "not_surfaced" // 搜索有结果但不包含笔记，可能被限流
+	DiscoverabilityInconclusive = "inconclusive" // 搜索被拦截或没有可用结果，无法判断
+)
+
+const (
+	// MaxDiscoverabilityKeywords 一次检测最多搜索的关键词数
+	MaxDiscoverabilityKeywords = 5
+	// 每个关键词最多查看的搜索结果数
+	discoverabilitySearchDepth = 60
+	// 标题作为关键词时截取的长度，过长的搜索词会被平台截断
+	discoverabilityTitleRunes = 20
+)
+
+// DiscoverabilityLimitations 检测方法的局限，随结果一并返回
+var DiscoverabilityLimitations = []string{
+	"检测是启发式的，不代表平台的官方判定；平台不会公开笔记是否被限流",
+	"只查看每个关键词的前 60 条结果，热门关键词下正常笔记也可能排在更后面",
+	"搜索结果因地区、时间和推荐策略而异，未登录环境与其他用户看到的结果不完全相同",
+	"新发布的笔记需要一段时间才会被搜索收录，发布后立即检测可能搜不到",
+	"未登录搜索可能被登录弹窗拦截，这种情况下结果为 inconclusive",
+}
+
+var topicTagPattern = regexp.MustCompile(`#([^#\[\]\s]+)\[话题\]#`)
+
+// DiscoverabilityQuery 单个关键词的搜索情况
+type DiscoverabilityQuery struct {
+	Keyword     string `json:"keyword"`
+	Found       bool   `json:"found"`
+	Rank        int    `json:"rank,omitempty"` // 笔记在结果中的位置，从 1 开始
+	ResultCount int    `json:"result_count"`   // 实际查看的结果数
+	Status      string `json:"keyword_status"` // 关键词本身的搜索状态：available | restricted | no_results
+	Blocked     bool   `json:"blocked,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// NoteDiscoverability 笔记在未登录环境中的可发现性
+type NoteDiscoverability struct {
+	FeedID      string                 `json:"feed_id"`
+	Title       string                 `json:"title,omitempty"`
+	Status      string                 `json:"status"` // surfaced | not_surfaced | inconclusive
+	StatusLabel string                 `json:"status_label"`
+	Queries     []DiscoverabilityQuery `json:"queries"`
+	Limitations []string               `json:"limitations"`
+}
+
+// DiscoverabilityAction 以未登录身份搜索笔记的关键词，检查笔记能否被搜到
+type DiscoverabilityAction struct {
+	page *rod.Page
+}
+
+func NewDiscoverabilityAction(page *rod.Page) *DiscoverabilityAction {
+	pp := page.Timeout(3 * time.Minute)
+	return &DiscoverabilityAction{page: pp}
+}
+
+// CheckDiscoverability 检测笔记能否通过搜索被其他人发现。
+// keywords 为空时使用笔记标题和正文中的话题作为关键词，此时需要 xsecToken 打开笔记详情。
+// 搜索在不带 cookies 的无痕上下文中进行，避免当前账号看到自己笔记的结果。
+func (a *DiscoverabilityAction) CheckDiscoverability(ctx context.Context, feedID, xsecToken string, keywords []string) (*NoteDiscoverability, error) {
+	page := a.page.Context(ctx)
+	result := &NoteDiscoverability{
+		FeedID:      feedID,
+		Queries:     []DiscoverabilityQuery{},
+		Limitations: DiscoverabilityLimitations,
+	}
+
+	if len(keywords) == 0 {
+		if xsecToken == "" {
+			return nil, fmt.Errorf("未指定关键词时需要 xsec_token 读取笔记标题")
+		}
+		detail, err := NewFeedDetailAction(page).GetFeedDetail(ctx, feedID, xsecToken)
+		if err != nil {
+			return nil, fmt.Errorf("读取笔记失败: %w", err)
+		}
+		result.Title = detail.Note.Title
+		keywords = discoverabilityKeywords(detail.Note.Title, detail.Note.Desc)
+		if len(keywords) == 0 {
+			return nil, fmt.Errorf("笔记没有标题或话题，请指定 keywords")
+		}
+	}
+
+	incognito, err := page.Browser().Incognito()
+	if err != nil {
+		return nil, fmt.Errorf("创建未登录浏览环境失败: %w", err)
+	}
+	defer incognito.Close()
+
+	guest, err := stealth.Page(incognito)
+	if err != nil {
+		return nil, fmt.Errorf("创建未登录页面失败: %w", err)
+	}
+	guest = guest.Timeout(60 * time.Second).Context(ctx)
+
+	for i, keyword := range keywords {
+		if i > 0 {
+			time.Sleep(2 * time.Second)
+		}
+		query, err := searchAsGuest(guest, feedID, keyword)
+		if err != nil {
+			return nil, fmt.Errorf("搜索关键词 %q 失败: %w", keyword, err)
+		}
+		result.Queries = append(result.Queries, *query)
+	}
+
+	result.Status = classifyDiscoverability(result.Queries)
+	result.StatusLabel = EnumLabel(EnumDiscoverability, result.Status)
+	logrus.Infof("笔记 %s 可发现性: %s（%d 个关键词）", feedID, result.Status, len(result.Queries))
+	return result, nil
+}
+
+// searchAsGuest 在未登录页面上搜索关键词，查找笔记在结果中的位置
+func searchAsGuest(page *rod.Page, feedID, keyword string) (*DiscoverabilityQuery, error) {
+	query := &DiscoverabilityQuery{Keyword: keyword}
+
+	page.MustNavigate(makeSearchURL(keyword))
+	page.MustWaitStable()
+	time.Sleep(1 * time.Second)
+
+	if text := readGateText(page); classifyGate(text) == gateRestricted {
+		query.Status = KeywordRestricted
+		query.Message = text
+		return query, nil
+	}
+	if err := passContentGate(page); err != nil {
+		query.Blocked = true
+		query.Message = err.Error()
+		return query, nil
+	}
+
+	res, err := collectFeedsByScroll(page, PaginateOption{AutoPaginate: true, MaxItems: discoverabilitySearchDepth, Interval: time.Second}, readSearchFeeds)
+	if err != nil && err != errors.ErrNoFeeds {
+		return nil, err
+	}
+	var feeds []Feed
+	if res != nil {
+		feeds = res.Feeds
+	}
+
+	notice := readSearchNotice(page)
+	query.ResultCount = len(feeds)
+	query.Status = keywordStatus(len(feeds), notice)
+	query.Message = notice
+	query.Rank = feedRank(feeds, feedID)
+	query.Found = query.Rank > 0
+
+	// 未登录时搜索页可能只显示登录弹窗而不加载结果
+	if len(feeds) == 0 && hasLoginWall(page) {
+		query.Blocked = true
+		query.Message = "搜索被登录弹窗拦截"
+	}
+	return query, nil
+}
+
+// hasLoginWall 页面上是否显示了登录弹窗
+func hasLoginWall(page *rod.Page) bool {
+	return page.MustEval(`() => {
+		const el = document.querySelector('.login-container, .login-modal');
+		return !!el && el.offsetParent !== null;
+	}`).Bool()
+}
+
+// NormalizeDiscoverabilityKeywords 去掉空白与重复的关键词，数量不超过 MaxDiscoverabilityKeywords
+func NormalizeDiscoverabilityKeywords(keywords []string) ([]string, error) {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, k := range keywords {
+		k = strings.TrimSpace(k)
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		normalized = append(normalized, k)
+	}
+	if len(normalized) > MaxDiscoverabilityKeywords {
+		return nil, fmt.Errorf("最多指定 %d 个关键词，实际 %d 个", MaxDiscoverabilityKeywords, len(normalized))
+	}
+	return normalized, nil
+}
+
+// discoverabilityKeywords 从标题和正文话题生成搜索关键词：标题优先，其次是话题，去重后最多 MaxDiscoverabilityKeywords 个
+func discoverabilityKeywords(title, desc string) []string {
+	var keywords []string
+	seen := map[string]bool{}
+	add := func(k string) {
+		k = strings.TrimSpace(k)
+		if k == "" || seen[k] || len(keywords) >= MaxDiscoverabilityKeywords {
+			return
+		}
+		seen[k] = true
+		keywords = append(keywords, k)
+	}
+
+	if runes := []rune(strings.TrimSpace(title)); len(runes) > discoverabilityTitleRunes {
+		add(string(runes[:discoverabilityTitleRunes]))
+	} else {
+		add(string(runes))
+	}
+	for _, m := range topicTagPattern.FindAllStringSubmatch(desc, -1) {
+		add(m[1])
+	}
+	return keywords
+}
+
+// feedRank 返回笔记在结果中的位置（从 1 开始），不在结果中返回 0
+func feedRank(feeds []Feed, feedID string) int {
+	for i, feed := range feeds {
+		if feed.ID == feedID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// classifyDiscoverability 任一关键词搜到即为可发现；
+// 只有在至少一个关键词正常返回结果却不包含笔记时才提示可能限流，其余情况无法判断
+func classifyDiscoverability(queries []DiscoverabilityQuery) string {
+	usable := 0
+	for _, q := range queries {
+		if q.Found {
+			return DiscoverabilitySurfaced
+		}
+		if !q.Blocked && q.Status == KeywordAvailable {
+			usable++
+		}
+	}
+	if usable > 0 {
+		return DiscoverabilityNotSurfaced
+	}
+	return DiscoverabilityInconclusive
+}
@@ -0,0 +1,50 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverabilityKeywords(t *testing.T) {
+	keywords := discoverabilityKeywords(" 周末去哪儿 ", "城市漫步路线 #周末去哪儿[话题]# #citywalk[话题]# #旅行")
+	require.Equal(t, []string{"周末去哪儿", "citywalk"}, keywords)
+
+	long := discoverabilityKeywords("这是一个非常非常长的标题用于测试截断是否按字符进行", "")
+	require.Len(t, long, 1)
+	require.Len(t, []rune(long[0]), discoverabilityTitleRunes)
+
+	require.Empty(t, discoverabilityKeywords("", "没有话题"))
+}
+
+func TestNormalizeDiscoverabilityKeywords(t *testing.T) {
+	got, err := NormalizeDiscoverabilityKeywords([]string{" 穿搭 ", "", "穿搭", "通勤"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"穿搭", "通勤"}, got)
+
+	_, err = NormalizeDiscoverabilityKeywords([]string{"a", "b", "c", "d", "e", "f"})
+	require.Error(t, err)
+}
+
+func TestFeedRank(t *testing.T) {
+	feeds := []Feed{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	require.Equal(t, 2, feedRank(feeds, "b"))
+	require.Equal(t, 0, feedRank(feeds, "x"))
+}
+
+func TestClassifyDiscoverability(t *testing.T) {
+	require.Equal(t, DiscoverabilitySurfaced, classifyDiscoverability([]DiscoverabilityQuery{
+		{Status: KeywordAvailable, ResultCount: 20},
+		{Status: KeywordAvailable, ResultCount: 20, Found: true, Rank: 7},
+	}))
+	require.Equal(t, DiscoverabilityNotSurfaced, classifyDiscoverability([]DiscoverabilityQuery{
+		{Status: KeywordAvailable, ResultCount: 60},
+		{Status: KeywordNoResults},
+	}))
+	// 被登录弹窗拦截或关键词本身被屏蔽时不能判定为限流
+	require.Equal(t, DiscoverabilityInconclusive, classifyDiscoverability([]DiscoverabilityQuery{
+		{Status: KeywordNoResults, Blocked: true},
+		{Status: KeywordRestricted},
+	}))
+	require.Equal(t, DiscoverabilityInconclusive, classifyDiscoverability(nil))
+}
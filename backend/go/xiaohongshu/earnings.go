@@ -0,0 +1,172 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+const urlOfCreatorHome = `https://creator.xiaohongshu.com/new/home`
+
+// 收益统计周期
+const (
+	EarningsPeriod7d  = "7d"
+	EarningsPeriod30d = "30d"
+	EarningsPeriodAll = "all"
+)
+
+// 统计周期与页面上周期切换按钮文本的对应关系
+var earningsPeriodLabels = map[string][]string{
+	EarningsPeriod7d:  {"近7日", "近7天", "7日"},
+	EarningsPeriod30d: {"近30日", "近30天", "30日"},
+	EarningsPeriodAll: {"累计", "全部"},
+}
+
+// 创作者中心收益入口可能使用的菜单名称
+var earningsMenuLabels = []string{"收益", "变现", "创作者收益", "商业合作"}
+
+// ValidateEarningsPeriod 校验收益统计周期，为空时调用方应使用 7d
+func ValidateEarningsPeriod(period string) error {
+	if _, ok := earningsPeriodLabels[period]; !ok {
+		return fmt.Errorf("无效的统计周期 %q，可选值: 7d|30d|all", period)
+	}
+	return nil
+}
+
+// EarningsFigure 收益页上展示的一项数据，按页面原样返回
+type EarningsFigure struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Earnings 创作者收益信息
+type Earnings struct {
+	Available bool             `json:"available"`
+	Reason    string           `json:"reason,omitempty"` // 不可用时的原因
+	Period    string           `json:"period"`
+	PeriodSet bool             `json:"period_set"` // 是否成功切换到指定周期；false 表示返回的是页面默认周期
+	Section   string           `json:"section,omitempty"`
+	Figures   []EarningsFigure `json:"figures,omitempty"`
+}
+
+// EarningsAction 读取创作者中心的收益/变现数据
+type EarningsAction struct {
+	page *rod.Page
+}
+
+func NewEarningsAction(page *rod.Page) *EarningsAction {
+	pp := page.Timeout(60 * time.Second)
+	return &EarningsAction{page: pp}
+}
+
+// GetEarnings 打开创作者中心，进入收益页面并读取指定周期的数据。
+// 账号未开通变现功能时不会报错，而是返回 Available=false。
+func (a *EarningsAction) GetEarnings(ctx context.Context, period string) (*Earnings, error) {
+	if err := ValidateEarningsPeriod(period); err != nil {
+		return nil, err
+	}
+
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(urlOfCreatorHome).MustWaitIdle().MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	earnings := &Earnings{Period: period}
+
+	section, err := openCreatorSection(page, earningsMenuLabels)
+	if err != nil {
+		earnings.Reason = "当前账号未开通收益功能，或创作者中心未提供收益数据"
+		logrus.Infof("未找到收益入口: %v", err)
+		return earnings, nil
+	}
+	earnings.Section = section
+
+	earnings.PeriodSet = switchEarningsPeriod(page, period)
+
+	earnings.Figures = readEarningsFigures(page)
+	if len(earnings.Figures) == 0 {
+		earnings.Reason = "收益页面没有可展示的数据，账号可能不满足变现条件"
+		return earnings, nil
+	}
+
+	earnings.Available = true
+	return earnings, nil
+}
+
+// openCreatorSection 在创作者中心侧边栏中按 labels 顺序查找并点击入口，返回入口名称
+func openCreatorSection(page *rod.Page, labels []string) (string, error) {
+	elems, err := page.Elements(".menu-item, .d-menu-item, [class*='menu'] li, nav a")
+	if err != nil {
+		return "", err
+	}
+
+	for _, label := range labels {
+		for _, elem := range elems {
+			text, err := elem.Text()
+			if err != nil || strings.TrimSpace(text) != label {
+				continue
+			}
+			if !isElementVisible(elem) {
+				continue
+			}
+
+			elem.MustClick()
+			page.MustWaitStable()
+			time.Sleep(1 * time.Second)
+			return label, nil
+		}
+	}
+
+	return "", fmt.Errorf("没有找到入口: %s", strings.Join(labels, "|"))
+}
+
+// switchEarningsPeriod 点击周期切换按钮，成功返回 true
+func switchEarningsPeriod(page *rod.Page, period string) bool {
+	for _, label := range earningsPeriodLabels[period] {
+		elem, err := page.ElementR("button, .d-tab, .tab-item, [class*='tab'] span", "^"+label+"$")
+		if err != nil {
+			continue
+		}
+
+		elem.MustClick()
+		page.MustWaitStable()
+		time.Sleep(500 * time.Millisecond)
+		return true
+	}
+
+	logrus.Warnf("没有找到统计周期选项 %s，使用页面默认周期", period)
+	return false
+}
+
+// readEarningsFigures 读取收益页面上的数据卡片（标题 + 数值）
+func readEarningsFigures(page *rod.Page) []EarningsFigure {
+	items := page.MustEval(`() => {
+		const cards = document.querySelectorAll('[class*="data-card"], [class*="income"] [class*="item"], [class*="earning"] [class*="item"], [class*="overview"] [class*="item"]');
+		const seen = new Set();
+		const result = [];
+		cards.forEach(card => {
+			const label = card.querySelector('[class*="title"], [class*="label"], [class*="name"]');
+			const value = card.querySelector('[class*="value"], [class*="num"], [class*="amount"]');
+			if (!label || !value) return;
+			const l = label.innerText.trim();
+			const v = value.innerText.trim();
+			if (!l || !v || seen.has(l)) return;
+			seen.add(l);
+			result.push({label: l, value: v});
+		});
+		return result;
+	}`).Arr()
+
+	figures := make([]EarningsFigure, 0, len(items))
+	for _, item := range items {
+		figures = append(figures, EarningsFigure{
+			Label: item.Get("label").Str(),
+			Value: item.Get("value").Str(),
+		})
+	}
+	return figures
+}
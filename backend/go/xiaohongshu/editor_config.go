@@ -0,0 +1,172 @@
+package xiaohongshu
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// 发布编辑器的已知限制：发布时标题宽度与图片数超出会拒绝发布，标签超出会截取前 EditorMaxTags 个
+const (
+	EditorTitleMaxWidth = 40 // 标题最大宽度：中文占2个单位，英文占1个单位
+	EditorMaxImages     = 18
+	EditorMaxTags       = 10
+)
+
+// EditorMode 发布编辑器的一种发布模式（上传视频/上传图文/写长文）
+type EditorMode struct {
+	Name     string `json:"name"`
+	Accept   string `json:"accept,omitempty"`   // 上传框允许的文件类型
+	Multiple bool   `json:"multiple,omitempty"` // 是否支持多文件上传
+}
+
+// EditorConfig 发布编辑器的默认配置及可选项
+type EditorConfig struct {
+	Modes                  []EditorMode `json:"modes"`
+	DefaultMode            string       `json:"default_mode"`
+	VisibilityOptions      []EnumValue  `json:"visibility_options"`
+	DefaultVisibility      string       `json:"default_visibility"` // public | private | friends
+	DefaultVisibilityLabel string       `json:"default_visibility_label"`
+	VisibilitySource       string       `json:"visibility_source"` // page: 从页面读取；default: 平台默认值
+	TitleMaxWidth          int          `json:"title_max_width"`
+	MaxImages              int          `json:"max_images"`
+	MaxTags                int          `json:"max_tags"`
+	// Categories 编辑器提供的内容分类（内容类型声明）选项，平台没有其他分类字段
+	Categories     []string `json:"categories"`
+	CategorySource string   `json:"category_source"` // page: 从页面读取；unavailable: 页面未渲染，categories 为空
+}
+
+// EditorConfigAction 读取发布编辑器配置，不会填写或发布任何内容
+type EditorConfigAction struct {
+	page *rod.Page
+}
+
+func NewEditorConfigAction(page *rod.Page) *EditorConfigAction {
+	pp := page.Timeout(60 * time.Second)
+	return &EditorConfigAction{page: pp}
+}
+
+// GetEditorConfig 打开发布页，依次切换各发布模式读取上传限制，
+// 全程不上传文件、不填写内容，关闭页面即丢弃编辑器状态。
+func (e *EditorConfigAction) GetEditorConfig(ctx context.Context) (*EditorConfig, error) {
+	page := e.page.Context(ctx)
+
+	page.MustNavigate(urlOfPublic).MustWaitIdle().MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	page.MustElement(`div.upload-content`).MustWaitVisible()
+
+	names, active, err := listPublishTabs(page)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("没有找到发布 TAB")
+	}
+
+	config := &EditorConfig{
+		DefaultMode:   active,
+		TitleMaxWidth: EditorTitleMaxWidth,
+		MaxImages:     EditorMaxImages,
+		MaxTags:       EditorMaxTags,
+	}
+
+	for _, name := range names {
+		mode := EditorMode{Name: name}
+
+		if err := mustClickPublishTab(page, name); err != nil {
+			logrus.Warnf("切换发布 TAB %s 失败: %v", name, err)
+			config.Modes = append(config.Modes, mode)
+			continue
+		}
+		time.Sleep(500 * time.Millisecond)
+
+		if input, err := page.Element(".upload-input"); err == nil {
+			if accept, err := input.Attribute("accept"); err == nil && accept != nil {
+				mode.Accept = *accept
+			}
+			if multiple, err := input.Attribute("multiple"); err == nil && multiple != nil {
+				mode.Multiple = true
+			}
+		}
+
+		config.Modes = append(config.Modes, mode)
+	}
+
+	// 可见范围设置仅在上传素材后渲染，未渲染时返回平台默认值
+	options := page.MustEval(`() => {
+		const items = document.querySelectorAll('.permission-card-select .d-options .d-option, .permission-select .d-option');
+		return Array.from(items).map(el => el.innerText.trim()).filter(Boolean);
+	}`).Arr()
+
+	for _, opt := range options {
+		config.VisibilityOptions = append(config.VisibilityOptions, visibilityOption(opt.String()))
+	}
+
+	if len(config.VisibilityOptions) > 0 {
+		config.VisibilitySource = "page"
+	} else {
+		config.VisibilityOptions = EnumSets()[EnumVisibility]
+		config.VisibilitySource = "default"
+	}
+	config.DefaultVisibility = config.VisibilityOptions[0].ID
+	config.DefaultVisibilityLabel = config.VisibilityOptions[0].Label
+
+	// 内容类型声明同样仅在上传素材后渲染，平台没有公开固定的选项列表，未渲染时不猜测
+	categories := page.MustEval(`() => {
+		const items = document.querySelectorAll('.declaration-select .d-option, [class*="declaration"] .d-option');
+		return Array.from(items).map(el => el.innerText.trim()).filter(Boolean);
+	}`).Arr()
+
+	config.Categories = make([]string, 0, len(categories))
+	for _, c := range categories {
+		config.Categories = append(config.Categories, c.String())
+	}
+	if len(config.Categories) > 0 {
+		config.CategorySource = "page"
+	} else {
+		config.CategorySource = "unavailable"
+	}
+
+	return config, nil
+}
+
+// listPublishTabs 返回可见的发布 TAB 名称以及当前选中的 TAB
+func listPublishTabs(page *rod.Page) ([]string, string, error) {
+	elems, err := page.Elements("div.creator-tab")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var names []string
+	var active string
+	for _, elem := range elems {
+		if !isElementVisible(elem) {
+			continue
+		}
+
+		text, err := elem.Text()
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSpace(text)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+
+		if cls, err := elem.Attribute("class"); err == nil && cls != nil && strings.Contains(*cls, "active") {
+			active = name
+		}
+	}
+
+	if active == "" && len(names) > 0 {
+		active = names[0]
+	}
+
+	return names, active, nil
+}
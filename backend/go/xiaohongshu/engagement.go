@@ -0,0 +1,132 @@
+package xiaohongshu
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// 互动率的分母
+const (
+	EngagementByFollowers = "followers" // 作者粉丝数
+	EngagementByViews     = "views"     // 笔记浏览量
+)
+
+// EngagementFormula 互动率的计算公式
+const EngagementFormula = "engagement_rate = (likes + collects + comments + shares) / denominator"
+
+// Engagement 服务端计算的互动率，原始计数一并返回。
+// 分母不可用时对应的字段为 null，而不是 0。
+type Engagement struct {
+	Likes        int64 `json:"likes"`
+	Collects     int64 `json:"collects"`
+	Comments     int64 `json:"comments"`
+	Shares       int64 `json:"shares"`
+	Interactions int64 `json:"interactions"` // 四项之和
+
+	Followers *int64 `json:"followers"` // 作者粉丝数，读取失败时为 null
+	Views     *int64 `json:"views"`     // 笔记浏览量，网页端笔记详情不提供，为 null
+
+	RateByFollowers *float64 `json:"rate_by_followers"` // interactions / followers，保留 4 位小数
+	RateByViews     *float64 `json:"rate_by_views"`     // interactions / views，保留 4 位小数
+
+	Formula      string   `json:"formula"`
+	Denominators []string `json:"available_denominators"` // 可用的分母: followers|views
+	// Approximate 为 true 表示平台返回的计数经过取整（如 "1.2万"、"10+"），互动率为近似值
+	Approximate bool     `json:"approximate"`
+	Notes       []string `json:"notes,omitempty"` // 分母缺失的原因
+}
+
+// ComputeEngagement 按笔记的互动计数与可用的分母计算互动率，followers 或 views 为 nil 表示该分母不可用
+func ComputeEngagement(info InteractInfo, followers, views *int64) *Engagement {
+	e := &Engagement{
+		Formula:      EngagementFormula,
+		Denominators: []string{},
+		Followers:    followers,
+		Views:        views,
+	}
+
+	counts := []struct {
+		raw string
+		dst *int64
+	}{
+		{info.LikedCount, &e.Likes},
+		{info.CollectedCount, &e.Collects},
+		{info.CommentCount, &e.Comments},
+		{info.SharedCount, &e.Shares},
+	}
+	for _, c := range counts {
+		n, exact := ParseCount(c.raw)
+		*c.dst = n
+		e.Interactions += n
+		if !exact {
+			e.Approximate = true
+		}
+	}
+
+	if rate, ok := engagementRate(e.Interactions, followers); ok {
+		e.RateByFollowers = &rate
+		e.Denominators = append(e.Denominators, EngagementByFollowers)
+	}
+	if rate, ok := engagementRate(e.Interactions, views); ok {
+		e.RateByViews = &rate
+		e.Denominators = append(e.Denominators, EngagementByViews)
+	}
+	return e
+}
+
+// engagementRate 分母缺失或为 0 时返回 false
+func engagementRate(interactions int64, denominator *int64) (float64, bool) {
+	if denominator == nil || *denominator <= 0 {
+		return 0, false
+	}
+	rate := float64(interactions) / float64(*denominator)
+	return math.Round(rate*10000) / 10000, true
+}
+
+// ParseCount 解析页面上的计数，如 "123"、"1.2万"、"3w"、"10+"、"1,024"。
+// 第二个返回值表示是否为精确值；空文本或无法识别（如未互动时显示的 "赞"）按 0 处理并视为精确。
+func ParseCount(s string) (int64, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if s == "" {
+		return 0, true
+	}
+
+	exact := true
+	if strings.HasSuffix(s, "+") {
+		s = strings.TrimSuffix(s, "+")
+		exact = false
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "万"):
+		s, multiplier = strings.TrimSuffix(s, "万"), 1e4
+	case strings.HasSuffix(s, "w"), strings.HasSuffix(s, "W"):
+		s, multiplier = s[:len(s)-1], 1e4
+	case strings.HasSuffix(s, "亿"):
+		s, multiplier = strings.TrimSuffix(s, "亿"), 1e8
+	case strings.HasSuffix(s, "k"), strings.HasSuffix(s, "K"):
+		s, multiplier = s[:len(s)-1], 1e3
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, true
+	}
+	if multiplier > 1 {
+		exact = false
+	}
+	return int64(math.Round(n * multiplier)), exact
+}
+
+// FollowerCount 从用户主页的互动数据中读取粉丝数
+func FollowerCount(interactions []UserInteractions) (int64, bool) {
+	for _, it := range interactions {
+		if it.Type == "fans" {
+			n, _ := ParseCount(it.Count)
+			return n, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,58 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCount(t *testing.T) {
+	cases := []struct {
+		in    string
+		n     int64
+		exact bool
+	}{
+		{"", 0, true},
+		{"123", 123, true},
+		{"1,024", 1024, true},
+		{"1.2万", 12000, false},
+		{"3w", 30000, false},
+		{"10+", 10, false},
+		{"1.5亿", 150000000, false},
+		{"赞", 0, true},
+	}
+	for _, c := range cases {
+		n, exact := ParseCount(c.in)
+		require.Equal(t, c.n, n, c.in)
+		require.Equal(t, c.exact, exact, c.in)
+	}
+}
+
+func TestComputeEngagement(t *testing.T) {
+	info := InteractInfo{LikedCount: "100", CollectedCount: "50", CommentCount: "30", SharedCount: "20"}
+	followers := int64(1000)
+
+	e := ComputeEngagement(info, &followers, nil)
+	require.Equal(t, int64(200), e.Interactions)
+	require.NotNil(t, e.RateByFollowers)
+	require.InDelta(t, 0.2, *e.RateByFollowers, 1e-9)
+	require.Nil(t, e.RateByViews)
+	require.Equal(t, []string{EngagementByFollowers}, e.Denominators)
+	require.False(t, e.Approximate)
+
+	// 分母不可用或为 0 时返回 null
+	zero := int64(0)
+	e = ComputeEngagement(InteractInfo{LikedCount: "1.2万"}, &zero, nil)
+	require.Nil(t, e.RateByFollowers)
+	require.Empty(t, e.Denominators)
+	require.True(t, e.Approximate)
+}
+
+func TestFollowerCount(t *testing.T) {
+	n, ok := FollowerCount([]UserInteractions{{Type: "follows", Count: "12"}, {Type: "fans", Count: "3.4万"}})
+	require.True(t, ok)
+	require.Equal(t, int64(34000), n)
+
+	_, ok = FollowerCount(nil)
+	require.False(t, ok)
+}
@@ -0,0 +1,139 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 笔记可见范围
+const (
+	VisibilityPublic  = "public"  // 公开可见
+	VisibilityPrivate = "private" // 仅自己可见
+	VisibilityFriends = "friends" // 仅互关好友可见
+)
+
+// 发布状态
+const (
+	PublishStatusPublished = "published"
+)
+
+// 输出中的枚举字段统一使用稳定的英文标识，中文名称放在对应的 *_label 字段，
+// 平台文案调整时只需要修改这里的名称。
+const (
+	EnumVisibility       = "visibility"
+	EnumNoteType         = "note_type"
+	EnumNotification     = "notification_category"
+	EnumPublishStatus    = "publish_status"
+	EnumKeywordStatus    = "keyword_status"
+	EnumVideoCoverSource = "video_cover_source"
+	EnumTaggedUserSource = "tagged_user_source"
+	EnumDiscoverability  = "discoverability"
+	EnumCrawlScope       = "crawl_scope"
+)
+
+// EnumValue 枚举值：英文标识与中文名称
+type EnumValue struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// enumSets 各枚举的全部取值，按输出顺序排列
+var enumSets = map[string][]EnumValue{
+	EnumVisibility: {
+		{VisibilityPublic, "公开可见"},
+		{VisibilityPrivate, "仅自己可见"},
+		{VisibilityFriends, "仅互关好友可见"},
+	},
+	EnumNoteType: {
+		{NoteTypeImage, "图文"},
+		{NoteTypeVideo, "视频"},
+		{NoteTypeCommerce, "商品"},
+		{NoteTypeLive, "直播"},
+	},
+	EnumNotification: {
+		{NotificationLikes, "赞和收藏"},
+		{NotificationComments, "评论和@"},
+		{NotificationFollows, "新增关注"},
+		{NotificationMessages, "私信"},
+		{NotificationSystem, "系统通知"},
+	},
+	EnumPublishStatus: {
+		{PublishStatusPublished, "发布完成"},
+	},
+	EnumKeywordStatus: {
+		{KeywordAvailable, "可搜索"},
+		{KeywordRestricted, "已屏蔽"},
+		{KeywordNoResults, "无结果"},
+	},
+	EnumVideoCoverSource: {
+		{VideoCoverCustom, "自定义封面"},
+		{VideoCoverFirstFrame, "视频首帧"},
+	},
+	EnumTaggedUserSource: {
+		{TaggedInNote, "笔记"},
+		{TaggedInImage, "图片"},
+	},
+	EnumDiscoverability: {
+		{DiscoverabilitySurfaced, "可被搜到"},
+		{DiscoverabilityNotSurfaced, "搜索中未出现"},
+		{DiscoverabilityInconclusive, "无法判断"},
+	},
+	EnumCrawlScope: {
+		{CrawlScopeHome, "首页推荐"},
+		{CrawlScopeSearch, "关键词搜索"},
+		{CrawlScopeExportKeyword, "按关键词导出"},
+		{CrawlScopeExportUser, "按用户主页导出"},
+	},
+}
+
+// EnumSets 返回所有枚举及其取值，供客户端对照
+func EnumSets() map[string][]EnumValue {
+	sets := make(map[string][]EnumValue, len(enumSets))
+	for name, values := range enumSets {
+		sets[name] = append([]EnumValue(nil), values...)
+	}
+	return sets
+}
+
+// EnumNames 返回所有枚举名称
+func EnumNames() []string {
+	names := make([]string, 0, len(enumSets))
+	for name := range enumSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnumLabel 返回枚举值的中文名称，未知的值原样返回
+func EnumLabel(set, id string) string {
+	for _, v := range enumSets[set] {
+		if v.ID == id {
+			return v.Label
+		}
+	}
+	return id
+}
+
+// ParseVisibility 解析可见范围，接受英文标识或中文名称，返回英文标识；为空表示平台默认
+func ParseVisibility(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	for _, value := range enumSets[EnumVisibility] {
+		if strings.EqualFold(v, value.ID) || v == value.Label {
+			return value.ID, nil
+		}
+	}
+	return "", fmt.Errorf("无效的可见范围 %q，可选值: public|private|friends（或 公开可见|仅自己可见|仅互关好友可见）", v)
+}
+
+// visibilityOption 把发布页上的可见范围文本转换为枚举值，未知的文本标识为 unknown
+func visibilityOption(label string) EnumValue {
+	if id, err := ParseVisibility(label); err == nil && id != "" {
+		return EnumValue{ID: id, Label: label}
+	}
+	return EnumValue{ID: "unknown", Label: label}
+}
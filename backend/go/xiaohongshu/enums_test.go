@@ -0,0 +1,55 @@
+package xiaohongshu
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVisibility(t *testing.T) {
+	for input, want := range map[string]string{
+		"":        "",
+		"public":  VisibilityPublic,
+		"Private": VisibilityPrivate,
+		"仅互关好友可见": VisibilityFriends,
+		" 仅自己可见 ": VisibilityPrivate,
+	} {
+		got, err := ParseVisibility(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, got, input)
+	}
+
+	_, err := ParseVisibility("仅粉丝可见")
+	require.Error(t, err)
+}
+
+func TestEnumLabel(t *testing.T) {
+	require.Equal(t, "仅自己可见", EnumLabel(EnumVisibility, VisibilityPrivate))
+	require.Equal(t, "视频", EnumLabel(EnumNoteType, NoteTypeVideo))
+	require.Equal(t, "unknown", EnumLabel(EnumNoteType, "unknown"))
+}
+
+func TestEnumSets(t *testing.T) {
+	for name, values := range EnumSets() {
+		seen := map[string]bool{}
+		for _, v := range values {
+			require.NotEmpty(t, v.ID, name)
+			require.NotEmpty(t, v.Label, name)
+			require.False(t, seen[v.ID], "%s: duplicate %s", name, v.ID)
+			seen[v.ID] = true
+		}
+	}
+
+	var categories []string
+	for _, v := range EnumSets()[EnumNotification] {
+		categories = append(categories, v.ID)
+	}
+	sort.Strings(categories)
+	require.Equal(t, NotificationCategories(), categories)
+}
+
+func TestVisibilityOption(t *testing.T) {
+	require.Equal(t, EnumValue{ID: VisibilityFriends, Label: "仅互关好友可见"}, visibilityOption("仅互关好友可见"))
+	require.Equal(t, EnumValue{ID: "unknown", Label: "仅粉丝可见"}, visibilityOption("仅粉丝可见"))
+}
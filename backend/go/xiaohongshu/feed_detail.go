@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -35,6 +36,56 @@ func (f *FeedDetailAction) GetFeedDetail(ctx context.Context, feedID, xsecToken
 	page.MustWaitDOMStable()
 	time.Sleep(1 * time.Second)
 
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	result, err := extractFeedDetailFromState(page, feedID)
+	if err == nil {
+		err = checkFeedDetailComplete(feedID, &result.Note)
+	}
+	if err != nil {
+		// __INITIAL_STATE__ 结构变化时回退到 DOM 解析，日志用于提示维护者平台已变更
+		logrus.Warnf("feed %s 从 __INITIAL_STATE__ 提取失败，回退到 DOM 解析（不包含评论）: %v", feedID, err)
+
+		note, domErr := extractFeedDetailFromDOM(page, feedID)
+		if domErr != nil {
+			return nil, fmt.Errorf("state 提取失败: %v; DOM 提取失败: %w", err, domErr)
+		}
+		return &FeedDetailResponse{Note: *note, Source: DetailSourceDOM}, nil
+	}
+
+	return result, nil
+}
+
+// CompareExtractors 在同一页面上分别使用 __INITIAL_STATE__ 和 DOM 提取笔记，
+// 返回两者关键字段的差异，用于测试中发现其中一条数据通路失效。
+func (f *FeedDetailAction) CompareExtractors(ctx context.Context, feedID, xsecToken string) ([]string, error) {
+	page := f.page.Context(ctx).Timeout(60 * time.Second)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	stateResult, err := extractFeedDetailFromState(page, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("state 提取失败: %w", err)
+	}
+
+	domNote, err := extractFeedDetailFromDOM(page, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("DOM 提取失败: %w", err)
+	}
+
+	return diffFeedDetailKeyFields(&stateResult.Note, domNote), nil
+}
+
+// extractFeedDetailFromState 从 window.__INITIAL_STATE__ 中读取笔记详情
+func extractFeedDetailFromState(page *rod.Page, feedID string) (*FeedDetailResponse, error) {
 	result := page.MustEval(`() => {
 		if (window.__INITIAL_STATE__ &&
 		    window.__INITIAL_STATE__.note &&
@@ -66,9 +117,138 @@ func (f *FeedDetailAction) GetFeedDetail(ctx context.Context, feedID, xsecToken
 	return &FeedDetailResponse{
 		Note:     noteDetail.Note,
 		Comments: noteDetail.Comments,
+		Source:   DetailSourceState,
 	}, nil
 }
 
+// extractFeedDetailFromDOM 从渲染后的页面元素中读取笔记详情（不包含评论）
+func extractFeedDetailFromDOM(page *rod.Page, feedID string) (*FeedDetail, error) {
+	sel := detectUIVariant(page).Selectors
+
+	result := page.MustEval(`(sel) => {
+		const text = (s) => {
+			const el = document.querySelector(s);
+			return el ? el.innerText.trim() : "";
+		};
+		const container = document.querySelector(sel.DetailContainer);
+		if (!container) {
+			return "";
+		}
+		const images = Array.from(document.querySelectorAll(sel.MediaImages))
+			.map(img => img.getAttribute('src'))
+			.filter(Boolean);
+		return JSON.stringify({
+			title: text(sel.DetailTitle),
+			desc: text(sel.DetailDesc),
+			nickname: text(sel.AuthorName),
+			likedCount: text(sel.LikeCount),
+			collectedCount: text(sel.CollectCount),
+			commentCount: text(sel.CommentCount),
+			isVideo: document.querySelector(sel.Video) !== null,
+			images: Array.from(new Set(images)),
+		});
+	}`, sel).String()
+
+	if result == "" {
+		return nil, errors.ErrNoFeedDetail
+	}
+
+	var dom struct {
+		Title          string   `json:"title"`
+		Desc           string   `json:"desc"`
+		Nickname       string   `json:"nickname"`
+		LikedCount     string   `json:"likedCount"`
+		CollectedCount string   `json:"collectedCount"`
+		CommentCount   string   `json:"commentCount"`
+		IsVideo        bool     `json:"isVideo"`
+		Images         []string `json:"images"`
+	}
+	if err := json.Unmarshal([]byte(result), &dom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dom detail: %w", err)
+	}
+
+	note := &FeedDetail{
+		NoteID: feedID,
+		Title:  dom.Title,
+		Desc:   dom.Desc,
+		Type:   "normal",
+		User:   User{Nickname: dom.Nickname},
+		InteractInfo: InteractInfo{
+			LikedCount:     dom.LikedCount,
+			CollectedCount: dom.CollectedCount,
+			CommentCount:   dom.CommentCount,
+		},
+	}
+	if dom.IsVideo {
+		note.Type = "video"
+	}
+	for _, src := range dom.Images {
+		note.ImageList = append(note.ImageList, DetailImageInfo{URLDefault: src})
+	}
+
+	if err := checkFeedDetailComplete(feedID, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// checkFeedDetailComplete 校验提取结果是否可用：笔记 ID 存在且一致。
+// 纯图片笔记可以没有标题和正文，因此不要求这两项。
+func checkFeedDetailComplete(feedID string, note *FeedDetail) error {
+	if note.NoteID == "" {
+		return fmt.Errorf("笔记 %s 缺少笔记 ID", feedID)
+	}
+	if note.NoteID != feedID {
+		return fmt.Errorf("笔记 ID 不一致: 期望 %s，实际 %s", feedID, note.NoteID)
+	}
+	return nil
+}
+
+// diffFeedDetailKeyFields 比较两种提取方式的关键字段，返回不一致字段的描述
+func diffFeedDetailKeyFields(state, dom *FeedDetail) []string {
+	var diffs []string
+
+	compare := func(field, a, b string) {
+		if normalizeDetailText(a) != normalizeDetailText(b) {
+			diffs = append(diffs, fmt.Sprintf("%s: state=%q dom=%q", field, a, b))
+		}
+	}
+
+	compare("title", state.Title, dom.Title)
+	compare("user.nickname", state.User.Nickname, dom.User.Nickname)
+	compare("interactInfo.likedCount", state.InteractInfo.LikedCount, dom.InteractInfo.LikedCount)
+	compare("interactInfo.collectedCount", state.InteractInfo.CollectedCount, dom.InteractInfo.CollectedCount)
+	compare("type", state.Type, dom.Type)
+
+	// DOM 中的正文会把话题渲染为 #话题，只比较去掉话题后的正文前缀
+	stateDesc := stripTopicTags(state.Desc)
+	domDesc := stripTopicTags(dom.Desc)
+	if !strings.HasPrefix(normalizeDetailText(domDesc), normalizeDetailText(stateDesc)) &&
+		!strings.HasPrefix(normalizeDetailText(stateDesc), normalizeDetailText(domDesc)) {
+		diffs = append(diffs, fmt.Sprintf("desc: state=%q dom=%q", state.Desc, dom.Desc))
+	}
+
+	return diffs
+}
+
+// normalizeDetailText 去掉所有空白字符，避免换行/缩进差异导致误报
+func normalizeDetailText(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// stripTopicTags 去掉正文中的话题标签（如 #旅行[话题]# 或 #旅行）
+func stripTopicTags(s string) string {
+	var kept []string
+	for _, field := range strings.Fields(s) {
+		if strings.HasPrefix(field, "#") {
+			continue
+		}
+		kept = append(kept, field)
+	}
+	return strings.Join(kept, " ")
+}
+
 func makeFeedDetailURL(feedID, xsecToken string) string {
 	return fmt.Sprintf("https://www.xiaohongshu.com/explore/%s?xsec_token=%s&xsec_source=pc_feed", feedID, xsecToken)
 }
@@ -0,0 +1,75 @@
+package xiaohongshu
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpzouying/xiaohongshu-mcp/browser"
+)
+
+// TestFeedDetailExtractorsMatch 对同一篇笔记同时运行 state 和 DOM 两种提取方式，
+// 关键字段不一致时失败。需要设置 XHS_TEST_FEED_ID 和 XHS_TEST_XSEC_TOKEN。
+func TestFeedDetailExtractorsMatch(t *testing.T) {
+	feedID := os.Getenv("XHS_TEST_FEED_ID")
+	xsecToken := os.Getenv("XHS_TEST_XSEC_TOKEN")
+	if feedID == "" || xsecToken == "" {
+		t.Skip("SKIP: 未设置 XHS_TEST_FEED_ID / XHS_TEST_XSEC_TOKEN")
+	}
+
+	b := browser.NewBrowser(true)
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := NewFeedDetailAction(page)
+
+	diffs, err := action.CompareExtractors(context.Background(), feedID, xsecToken)
+	require.NoError(t, err)
+	require.Empty(t, diffs, "state 与 DOM 提取结果不一致")
+}
+
+func TestDiffFeedDetailKeyFields(t *testing.T) {
+	state := &FeedDetail{
+		NoteID: "abc",
+		Title:  "周末去哪儿",
+		Desc:   "杭州一日游攻略\n#旅行[话题]# #杭州[话题]#",
+		Type:   "normal",
+		User:   User{Nickname: "小红"},
+		InteractInfo: InteractInfo{
+			LikedCount:     "1.2万",
+			CollectedCount: "356",
+		},
+	}
+
+	dom := &FeedDetail{
+		NoteID: "abc",
+		Title:  "周末去哪儿 ",
+		Desc:   "杭州一日游攻略 #旅行 #杭州",
+		Type:   "normal",
+		User:   User{Nickname: "小红"},
+		InteractInfo: InteractInfo{
+			LikedCount:     "1.2万",
+			CollectedCount: "356",
+		},
+	}
+
+	require.Empty(t, diffFeedDetailKeyFields(state, dom))
+
+	dom.InteractInfo.LikedCount = "1.3万"
+	dom.Type = "video"
+	diffs := diffFeedDetailKeyFields(state, dom)
+	require.Len(t, diffs, 2)
+	require.Contains(t, diffs[0], "interactInfo.likedCount")
+	require.Contains(t, diffs[1], "type")
+}
+
+func TestCheckFeedDetailComplete(t *testing.T) {
+	require.NoError(t, checkFeedDetailComplete("abc", &FeedDetail{NoteID: "abc", Title: "标题"}))
+	// 纯图片笔记没有标题和正文
+	require.NoError(t, checkFeedDetailComplete("abc", &FeedDetail{NoteID: "abc"}))
+	require.Error(t, checkFeedDetailComplete("abc", &FeedDetail{Desc: "正文"}))
+	require.Error(t, checkFeedDetailComplete("abc", &FeedDetail{NoteID: "xyz", Title: "标题"}))
+}
@@ -2,8 +2,6 @@ package xiaohongshu
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -25,10 +23,24 @@ func NewFeedsListAction(page *rod.Page) *FeedsListAction {
 
 // GetFeedsList 获取页面的 Feed 列表数据
 func (f *FeedsListAction) GetFeedsList(ctx context.Context) ([]Feed, error) {
+	result, err := f.GetFeedsListWithPagination(ctx, PaginateOption{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Feeds, nil
+}
+
+// GetFeedsListWithPagination 获取首页 Feed 列表，并按 opt 自动滚动加载更多
+func (f *FeedsListAction) GetFeedsListWithPagination(ctx context.Context, opt PaginateOption) (*PaginateResult, error) {
 	page := f.page.Context(ctx)
 
 	time.Sleep(1 * time.Second)
 
+	return collectFeedsByScroll(page, opt, readHomeFeeds)
+}
+
+// readHomeFeeds 读取首页当前已加载的 feeds
+func readHomeFeeds(page *rod.Page) ([]Feed, error) {
 	result := page.MustEval(`() => {
 		if (window.__INITIAL_STATE__ &&
 		    window.__INITIAL_STATE__.feed &&
@@ -46,10 +58,5 @@ func (f *FeedsListAction) GetFeedsList(ctx context.Context) ([]Feed, error) {
 		return nil, errors.ErrNoFeeds
 	}
 
-	var feeds []Feed
-	if err := json.Unmarshal([]byte(result), &feeds); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal feeds: %w", err)
-	}
-
-	return feeds, nil
+	return parseFeeds(result)
 }
@@ -0,0 +1,125 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// 列表（Feed）可投影的字段
+var feedFields = map[string]func(*Feed) any{
+	"id":            func(f *Feed) any { return f.ID },
+	"xsec_token":    func(f *Feed) any { return f.XsecToken },
+	"title":         func(f *Feed) any { return f.NoteCard.DisplayTitle },
+	"type":          func(f *Feed) any { return f.NoteCard.Type },
+	"author":        func(f *Feed) any { return f.NoteCard.User },
+	"likes":         func(f *Feed) any { return f.NoteCard.InteractInfo.LikedCount },
+	"collects":      func(f *Feed) any { return f.NoteCard.InteractInfo.CollectedCount },
+	"comment_count": func(f *Feed) any { return f.NoteCard.InteractInfo.CommentCount },
+	"shares":        func(f *Feed) any { return f.NoteCard.InteractInfo.SharedCount },
+	"cover":         func(f *Feed) any { return coverURL(f.NoteCard.Cover) },
+}
+
+// 详情（FeedDetailResponse）可投影的字段，与列表字段同名的含义一致
+var feedDetailFields = map[string]func(*FeedDetailResponse) any{
+	"id":            func(d *FeedDetailResponse) any { return d.Note.NoteID },
+	"xsec_token":    func(d *FeedDetailResponse) any { return d.Note.XsecToken },
+	"title":         func(d *FeedDetailResponse) any { return d.Note.Title },
+	"desc":          func(d *FeedDetailResponse) any { return d.Note.Desc },
+	"type":          func(d *FeedDetailResponse) any { return d.Note.Type },
+	"time":          func(d *FeedDetailResponse) any { return d.Note.Time },
+	"ip_location":   func(d *FeedDetailResponse) any { return d.Note.IPLocation },
+	"author":        func(d *FeedDetailResponse) any { return d.Note.User },
+	"likes":         func(d *FeedDetailResponse) any { return d.Note.InteractInfo.LikedCount },
+	"collects":      func(d *FeedDetailResponse) any { return d.Note.InteractInfo.CollectedCount },
+	"comment_count": func(d *FeedDetailResponse) any { return d.Note.InteractInfo.CommentCount },
+	"shares":        func(d *FeedDetailResponse) any { return d.Note.InteractInfo.SharedCount },
+	"cover":         func(d *FeedDetailResponse) any { return detailCoverURL(d.Note.ImageList) },
+	"images":        func(d *FeedDetailResponse) any { return d.Note.ImageList },
+	"comments":      func(d *FeedDetailResponse) any { return d.Comments },
+}
+
+// ProjectedFeedDetail 按 fields 投影后的详情的形态，与 feedDetailFields 一一对应，只用于生成输出 Schema：
+// 所有字段都可选，不允许其他字段
+type ProjectedFeedDetail struct {
+	ID           string            `json:"id,omitempty"`
+	XsecToken    string            `json:"xsec_token,omitempty"`
+	Title        string            `json:"title,omitempty"`
+	Desc         string            `json:"desc,omitempty"`
+	Type         string            `json:"type,omitempty"`
+	Time         int64             `json:"time,omitempty"`
+	IPLocation   string            `json:"ip_location,omitempty"`
+	Author       *User             `json:"author,omitempty"`
+	Likes        string            `json:"likes,omitempty"`
+	Collects     string            `json:"collects,omitempty"`
+	CommentCount string            `json:"comment_count,omitempty"`
+	Shares       string            `json:"shares,omitempty"`
+	Cover        string            `json:"cover,omitempty"`
+	Images       []DetailImageInfo `json:"images,omitempty"`
+	Comments     *CommentList      `json:"comments,omitempty"`
+}
+
+// ValidateFeedFields 校验列表字段投影，空列表表示返回完整数据
+func ValidateFeedFields(fields []string) error {
+	return validateFields(fields, fieldNames(feedFields))
+}
+
+// ValidateFeedDetailFields 校验详情字段投影，空列表表示返回完整数据
+func ValidateFeedDetailFields(fields []string) error {
+	return validateFields(fields, fieldNames(feedDetailFields))
+}
+
+// ProjectFeeds 只保留 fields 指定的字段，调用前需先校验
+func ProjectFeeds(feeds []Feed, fields []string) []map[string]any {
+	result := make([]map[string]any, 0, len(feeds))
+	for i := range feeds {
+		item := make(map[string]any, len(fields))
+		for _, name := range fields {
+			item[name] = feedFields[name](&feeds[i])
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// ProjectFeedDetail 只保留 fields 指定的字段，调用前需先校验
+func ProjectFeedDetail(detail *FeedDetailResponse, fields []string) map[string]any {
+	result := make(map[string]any, len(fields))
+	for _, name := range fields {
+		result[name] = feedDetailFields[name](detail)
+	}
+	return result
+}
+
+func validateFields(fields []string, allowed []string) error {
+	for _, name := range fields {
+		if !slices.Contains(allowed, name) {
+			return fmt.Errorf("不支持的字段 %q，可选: %s", name, strings.Join(allowed, ","))
+		}
+	}
+	return nil
+}
+
+func fieldNames[T any](fields map[string]T) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func coverURL(cover Cover) string {
+	if cover.URLDefault != "" {
+		return cover.URLDefault
+	}
+	return cover.URL
+}
+
+func detailCoverURL(images []DetailImageInfo) string {
+	if len(images) == 0 {
+		return ""
+	}
+	return images[0].URLDefault
+}
@@ -0,0 +1,57 @@
+package xiaohongshu
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectFeeds(t *testing.T) {
+	feeds := []Feed{{
+		ID: "abc",
+		NoteCard: NoteCard{
+			DisplayTitle: "周末去哪儿",
+			InteractInfo: InteractInfo{LikedCount: "1.2万"},
+			Cover:        Cover{URL: "https://example.com/a.jpg"},
+		},
+	}}
+
+	fields := []string{"title", "likes", "cover"}
+	require.NoError(t, ValidateFeedFields(fields))
+
+	items := ProjectFeeds(feeds, fields)
+	require.Equal(t, []map[string]any{{
+		"title": "周末去哪儿",
+		"likes": "1.2万",
+		"cover": "https://example.com/a.jpg",
+	}}, items)
+
+	require.Error(t, ValidateFeedFields([]string{"title", "comments"}))
+	require.NoError(t, ValidateFeedDetailFields([]string{"title", "comments"}))
+}
+
+func TestProjectedFeedDetailMatchesFields(t *testing.T) {
+	var names []string
+	typ := reflect.TypeFor[ProjectedFeedDetail]()
+	for i := 0; i < typ.NumField(); i++ {
+		names = append(names, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+	}
+	sort.Strings(names)
+	require.Equal(t, fieldNames(feedDetailFields), names)
+
+	detail := &FeedDetailResponse{Note: FeedDetail{NoteID: "abc", Title: "周末去哪儿", Time: 1700000000000,
+		ImageList: []DetailImageInfo{{URLDefault: "https://example.com/a.jpg"}}}}
+	raw, err := json.Marshal(ProjectFeedDetail(detail, fieldNames(feedDetailFields)))
+	require.NoError(t, err)
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var projected ProjectedFeedDetail
+	require.NoError(t, dec.Decode(&projected))
+	require.Equal(t, "https://example.com/a.jpg", projected.Cover)
+}
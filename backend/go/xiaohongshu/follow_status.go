@@ -0,0 +1,150 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxMutualBatch 一次最多检查的用户数
+const MaxMutualBatch = 50
+
+// 用户主页 extraInfo.fstatus 的取值
+const (
+	followStatusNone    = "none"    // 互不关注
+	followStatusFollows = "follows" // 我关注了对方
+	followStatusFans    = "fans"    // 对方关注了我
+	followStatusBoth    = "both"    // 互相关注
+)
+
+// 主页关注按钮的文本与对应的 fstatus，数据中没有 fstatus 时使用
+var followButtonStatus = map[string]string{
+	"关注":   followStatusNone,
+	"已关注":  followStatusFollows,
+	"回关":   followStatusFans,
+	"回粉":   followStatusFans,
+	"互相关注": followStatusBoth,
+}
+
+// UserRef 用户引用（用户 ID + 访问令牌）
+type UserRef struct {
+	UserID    string `json:"user_id"`
+	XsecToken string `json:"xsec_token"`
+}
+
+// MutualStatus 当前账号与某个用户的关注关系
+type MutualStatus struct {
+	UserID    string `json:"user_id"`
+	Nickname  string `json:"nickname,omitempty"`
+	IFollow   bool   `json:"i_follow"`   // 我关注了对方
+	FollowsMe bool   `json:"follows_me"` // 对方关注了我
+	Mutual    bool   `json:"mutual"`     // 互相关注
+	Error     string `json:"error,omitempty"`
+}
+
+// FollowStatusAction 检查关注关系，只读取主页上的关系字段，不解析笔记
+type FollowStatusAction struct {
+	page *rod.Page
+}
+
+func NewFollowStatusAction(page *rod.Page) *FollowStatusAction {
+	return &FollowStatusAction{page: page}
+}
+
+// ValidateUserRefs 校验批量检查的用户列表
+func ValidateUserRefs(refs []UserRef) error {
+	if len(refs) == 0 {
+		return fmt.Errorf("用户列表不能为空")
+	}
+	if len(refs) > MaxMutualBatch {
+		return fmt.Errorf("一次最多检查 %d 个用户，当前 %d 个", MaxMutualBatch, len(refs))
+	}
+	for _, ref := range refs {
+		if ref.UserID == "" || ref.XsecToken == "" {
+			return fmt.Errorf("每个用户都需要user_id和xsec_token")
+		}
+	}
+	return nil
+}
+
+// CheckMutual 在同一页面上依次打开用户主页检查关注关系，单个用户失败不影响其他用户
+func (a *FollowStatusAction) CheckMutual(ctx context.Context, refs []UserRef) []MutualStatus {
+	results := make([]MutualStatus, 0, len(refs))
+
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			results = append(results, MutualStatus{UserID: ref.UserID, Error: ctx.Err().Error()})
+			continue
+		}
+
+		status, err := a.checkOne(ctx, ref)
+		if err != nil {
+			logrus.Warnf("检查用户 %s 关注关系失败: %v", ref.UserID, err)
+			results = append(results, MutualStatus{UserID: ref.UserID, Error: err.Error()})
+			continue
+		}
+		results = append(results, *status)
+	}
+
+	return results
+}
+
+func (a *FollowStatusAction) checkOne(ctx context.Context, ref UserRef) (*MutualStatus, error) {
+	page := a.page.Context(ctx).Timeout(30 * time.Second)
+
+	page.MustNavigate(makeUserProfileURL(ref.UserID, ref.XsecToken))
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	result := page.MustEval(`() => {
+		const user = window.__INITIAL_STATE__.user;
+		const wrapped = user && user.userPageData;
+		const data = wrapped && (wrapped.value !== undefined ? wrapped.value : wrapped._value);
+		if (!data) return null;
+		const extra = data.extraInfo || {};
+		const btn = document.querySelector('.follow-button, .user-info button, .info-part button');
+		return {
+			fstatus: extra.fstatus || "",
+			button: btn ? btn.innerText.trim() : "",
+			nickname: (data.basicInfo && data.basicInfo.nickname) || "",
+		};
+	}`)
+	if result.Nil() {
+		return nil, fmt.Errorf("user.userPageData not found in __INITIAL_STATE__")
+	}
+
+	fstatus := resolveFollowStatus(result.Get("fstatus").Str(), result.Get("button").Str())
+	if fstatus == "" {
+		return nil, fmt.Errorf("无法识别关注关系，可能是当前账号自己或未登录")
+	}
+
+	status := newMutualStatus(ref.UserID, fstatus)
+	status.Nickname = result.Get("nickname").Str()
+	return status, nil
+}
+
+// resolveFollowStatus 优先使用数据中的 fstatus，没有时按关注按钮的文本判断
+func resolveFollowStatus(fstatus, button string) string {
+	switch fstatus {
+	case followStatusNone, followStatusFollows, followStatusFans, followStatusBoth:
+		return fstatus
+	}
+	return followButtonStatus[strings.TrimSpace(button)]
+}
+
+func newMutualStatus(userID, fstatus string) *MutualStatus {
+	status := &MutualStatus{
+		UserID:    userID,
+		IFollow:   fstatus == followStatusFollows || fstatus == followStatusBoth,
+		FollowsMe: fstatus == followStatusFans || fstatus == followStatusBoth,
+	}
+	status.Mutual = status.IFollow && status.FollowsMe
+	return status
+}
@@ -0,0 +1,40 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFollowStatus(t *testing.T) {
+	require.Equal(t, followStatusBoth, resolveFollowStatus("both", "关注"))
+	require.Equal(t, followStatusFans, resolveFollowStatus("", " 回关 "))
+	require.Equal(t, followStatusFollows, resolveFollowStatus("unknown", "已关注"))
+	require.Empty(t, resolveFollowStatus("", "编辑资料"))
+}
+
+func TestNewMutualStatus(t *testing.T) {
+	both := newMutualStatus("u1", followStatusBoth)
+	require.True(t, both.IFollow && both.FollowsMe && both.Mutual)
+
+	fans := newMutualStatus("u2", followStatusFans)
+	require.False(t, fans.IFollow)
+	require.True(t, fans.FollowsMe)
+	require.False(t, fans.Mutual)
+
+	none := newMutualStatus("u3", followStatusNone)
+	require.False(t, none.IFollow || none.FollowsMe || none.Mutual)
+}
+
+func TestValidateUserRefs(t *testing.T) {
+	require.NoError(t, ValidateUserRefs([]UserRef{{UserID: "u1", XsecToken: "t"}}))
+	require.Error(t, ValidateUserRefs(nil))
+	require.Error(t, ValidateUserRefs([]UserRef{{UserID: "u1"}}))
+
+	many := make([]UserRef, MaxMutualBatch+1)
+	for i := range many {
+		many[i] = UserRef{UserID: strings.Repeat("u", i+1), XsecToken: "t"}
+	}
+	require.Error(t, ValidateUserRefs(many))
+}
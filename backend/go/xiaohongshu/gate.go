@@ -0,0 +1,149 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 拦截层类型
+const (
+	gateNone       = ""
+	gateRegion     = "region"     // 地区确认，可自动确认
+	gateAge        = "age"        // 年龄确认，需要用户本人确认
+	gateRestricted = "restricted" // 内容受限，无法通过确认解除
+)
+
+// 各类拦截层的提示文本，按 restricted > age > region 的顺序匹配
+var (
+	restrictedGateMarkers = []string{"因地区限制", "当前地区不可见", "暂时无法浏览", "内容无法展示", "笔记不存在", "仅作者可见", "已被删除"}
+	ageGateMarkers        = []string{"年满18", "未成年", "年龄确认", "18岁以上"}
+	regionGateMarkers     = []string{"地区确认", "所在地区", "当前地区", "切换地区"}
+)
+
+// 自动确认地区提示时点击的按钮文本
+var gateConfirmLabels = []string{"确认", "确定", "继续访问", "继续浏览", "我知道了"}
+
+// classifyGate 根据弹窗或页面提示文本判断拦截层类型
+func classifyGate(text string) string {
+	if text == "" {
+		return gateNone
+	}
+	for _, marker := range restrictedGateMarkers {
+		if strings.Contains(text, marker) {
+			return gateRestricted
+		}
+	}
+	for _, marker := range ageGateMarkers {
+		if strings.Contains(text, marker) {
+			return gateAge
+		}
+	}
+	for _, marker := range regionGateMarkers {
+		if strings.Contains(text, marker) {
+			return gateRegion
+		}
+	}
+	return gateNone
+}
+
+// passContentGate 检测页面上的地区/年龄拦截层。地区确认按配置自动确认，
+// 年龄确认和内容受限返回错误，避免静默返回空结果。
+func passContentGate(page *rod.Page) error {
+	text := readGateText(page)
+	kind := classifyGate(text)
+
+	switch kind {
+	case gateNone:
+		return nil
+	case gateRestricted:
+		return fmt.Errorf("%w: %s", errors.ErrContentRestricted, text)
+	case gateAge:
+		return fmt.Errorf("%w: 年龄确认需要在浏览器中由用户本人完成: %s", errors.ErrContentGated, text)
+	}
+
+	if !configs.AutoDismissGates() {
+		return fmt.Errorf("%w: 地区确认（已关闭自动确认）: %s", errors.ErrContentGated, text)
+	}
+
+	if !clickGateConfirm(page) {
+		return fmt.Errorf("%w: 没有找到地区确认按钮: %s", errors.ErrContentGated, text)
+	}
+	logrus.Infof("已自动确认地区提示: %s", text)
+
+	page.MustWaitDOMStable()
+	time.Sleep(500 * time.Millisecond)
+
+	// 确认后仍有拦截说明内容本身受限
+	if kind := classifyGate(readGateText(page)); kind != gateNone {
+		return fmt.Errorf("%w: 确认地区后内容仍不可见", errors.ErrContentRestricted)
+	}
+
+	return nil
+}
+
+// gateCandidate 页面上可能是拦截层的可见容器
+type gateCandidate struct {
+	Text string `json:"text"`
+	// Content 为 true 表示容器中是笔记、评论或登录框等正常内容（笔记详情也以弹窗展示），不是拦截层
+	Content bool `json:"content"`
+}
+
+// readGateText 读取可见的拦截层容器的文本。只读取拦截弹窗和页面级提示本身，
+// 不读取承载笔记详情、评论或登录框的弹窗，避免笔记正文中的“所在地区”等文字被误判为拦截。
+func readGateText(page *rod.Page) string {
+	res := page.MustEval(`() => {
+		const visible = el => el && el.offsetParent !== null && el.innerText.trim() !== "";
+		const content = '#noteContainer, .note-container, .note-content, .comments-container, .feeds-container, .login-container';
+		const candidates = [];
+		const dialogs = document.querySelectorAll('[role="dialog"], .reds-modal, .d-modal, .modal, .mask-container .content');
+		for (const el of dialogs) {
+			if (!visible(el)) continue;
+			candidates.push({
+				text: el.innerText.trim().slice(0, 300),
+				content: el.matches(content) || el.querySelector(content) !== null,
+			});
+		}
+		const notice = document.querySelector('.access-wrapper, .error-container, .not-found, .feeds-page .empty');
+		if (visible(notice)) candidates.push({text: notice.innerText.trim().slice(0, 300), content: false});
+		return candidates;
+	}`)
+
+	var candidates []gateCandidate
+	for _, c := range res.Arr() {
+		candidates = append(candidates, gateCandidate{Text: c.Get("text").Str(), Content: c.Get("content").Bool()})
+	}
+	return pickGateText(candidates)
+}
+
+// pickGateText 返回第一个不是正常内容的容器文本
+func pickGateText(candidates []gateCandidate) string {
+	for _, c := range candidates {
+		if !c.Content && c.Text != "" {
+			return c.Text
+		}
+	}
+	return ""
+}
+
+// clickGateConfirm 点击弹窗中的确认按钮，成功返回 true
+func clickGateConfirm(page *rod.Page) bool {
+	return page.MustEval(`(labels) => {
+		const dialogs = document.querySelectorAll('[role="dialog"], .reds-modal, .d-modal, .modal, .mask-container .content');
+		for (const dialog of dialogs) {
+			if (dialog.offsetParent === null) continue;
+			for (const btn of dialog.querySelectorAll('button, [role="button"], .btn')) {
+				if (labels.includes(btn.innerText.trim())) {
+					btn.click();
+					return true;
+				}
+			}
+		}
+		return false;
+	}`, gateConfirmLabels).Bool()
+}
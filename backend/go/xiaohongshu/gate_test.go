@@ -0,0 +1,26 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyGate(t *testing.T) {
+	require.Equal(t, gateNone, classifyGate(""))
+	require.Equal(t, gateNone, classifyGate("登录后查看更多精彩内容"))
+	require.Equal(t, gateRegion, classifyGate("请确认您当前所在地区\n确认"))
+	require.Equal(t, gateAge, classifyGate("该内容仅对年满18岁的用户开放"))
+	require.Equal(t, gateRestricted, classifyGate("因地区限制，该笔记暂时无法浏览"))
+}
+
+func TestPickGateText(t *testing.T) {
+	// 笔记详情弹窗的正文提到“所在地区”，不是拦截层
+	note := gateCandidate{Text: "分享一下我所在地区的美食\n#探店", Content: true}
+	require.Equal(t, "", pickGateText([]gateCandidate{note}))
+	require.Equal(t, gateNone, classifyGate(pickGateText([]gateCandidate{note})))
+
+	gate := gateCandidate{Text: "请确认您当前所在地区\n确认"}
+	require.Equal(t, gate.Text, pickGateText([]gateCandidate{note, gate}))
+	require.Equal(t, "", pickGateText(nil))
+}
@@ -0,0 +1,50 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// 平台识别出无头浏览器时的拦截页特征。
+// 不包含 /404 和“访问频繁”：笔记被删除或请求过快与浏览器模式无关，切换到有界面模式也无法恢复。
+var (
+	headlessBlockURLMarkers  = []string{"captcha", "website-login/error"}
+	headlessBlockTextMarkers = []string{"当前环境异常", "浏览器环境异常", "请使用浏览器访问", "请更换浏览器", "浏览器版本过低", "安全限制", "验证码"}
+)
+
+// DetectHeadlessBlock 检查页面当前是否停留在拦截页，返回拦截原因；页面正常或无法读取时返回空
+func DetectHeadlessBlock(page *rod.Page) string {
+	info, err := page.Timeout(5 * time.Second).Eval(`() => {
+		const nav = performance.getEntriesByType('navigation')[0];
+		return {
+			status: nav && nav.responseStatus ? nav.responseStatus : 0,
+			url: location.href,
+			text: document.body ? document.body.innerText.slice(0, 2000) : "",
+		};
+	}`)
+	if err != nil {
+		return ""
+	}
+
+	return classifyHeadlessBlock(info.Value.Get("status").Int(), info.Value.Get("url").Str(), info.Value.Get("text").Str())
+}
+
+// classifyHeadlessBlock 根据状态码、地址和页面文本判断是否被拦截
+func classifyHeadlessBlock(httpStatus int, finalURL, text string) string {
+	if httpStatus == 403 || httpStatus == 461 || httpStatus == 471 {
+		return "HTTP 状态码异常"
+	}
+	for _, marker := range headlessBlockURLMarkers {
+		if strings.Contains(finalURL, marker) {
+			return "跳转到拦截页: " + finalURL
+		}
+	}
+	for _, marker := range headlessBlockTextMarkers {
+		if strings.Contains(text, marker) {
+			return "页面提示: " + marker
+		}
+	}
+	return ""
+}
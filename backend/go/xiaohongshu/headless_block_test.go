@@ -0,0 +1,18 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyHeadlessBlock(t *testing.T) {
+	require.Empty(t, classifyHeadlessBlock(200, "https://www.xiaohongshu.com/explore", "发现 推荐"))
+	require.NotEmpty(t, classifyHeadlessBlock(461, "https://www.xiaohongshu.com/explore", ""))
+	require.NotEmpty(t, classifyHeadlessBlock(200, "https://www.xiaohongshu.com/website-login/captcha?redirectPath=x", ""))
+	require.Equal(t, "页面提示: 当前环境异常", classifyHeadlessBlock(200, "https://www.xiaohongshu.com/explore", "当前环境异常，请稍后重试"))
+
+	// 笔记不存在和请求过快不是浏览器模式的问题
+	require.Empty(t, classifyHeadlessBlock(200, "https://www.xiaohongshu.com/404", "当前笔记暂时无法浏览"))
+	require.Empty(t, classifyHeadlessBlock(429, "https://www.xiaohongshu.com/explore", "访问频繁"))
+}
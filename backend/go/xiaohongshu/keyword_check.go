@@ -0,0 +1,98 @@
+package xiaohongshu
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 关键词检测结果
+const (
+	KeywordAvailable  = "available"  // 可以正常搜到笔记
+	KeywordRestricted = "restricted" // 平台按政策屏蔽了搜索结果
+	KeywordNoResults  = "no_results" // 没有屏蔽提示，但搜不到任何笔记
+)
+
+// 搜索结果页上的屏蔽提示
+var restrictedKeywordMarkers = []string{"根据相关法律法规", "相关法律法规和政策", "搜索结果未予显示", "暂不支持搜索", "违反社区规范", "该关键词"}
+
+// KeywordCheck 关键词的搜索可用性
+type KeywordCheck struct {
+	Keyword     string `json:"keyword"`
+	Status      string `json:"status"` // available | restricted | no_results
+	StatusLabel string `json:"status_label"`
+	ResultCount int    `json:"result_count"`
+	Message     string `json:"message,omitempty"` // 页面上的提示文本
+}
+
+// KeywordCheckAction 检测关键词是否被限制搜索
+type KeywordCheckAction struct {
+	page *rod.Page
+}
+
+func NewKeywordCheckAction(page *rod.Page) *KeywordCheckAction {
+	pp := page.Timeout(60 * time.Second)
+	return &KeywordCheckAction{page: pp}
+}
+
+// CheckKeyword 搜索关键词，根据首屏结果数量和页面提示判断是否被平台屏蔽
+func (a *KeywordCheckAction) CheckKeyword(ctx context.Context, keyword string) (*KeywordCheck, error) {
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(makeSearchURL(keyword))
+	page.MustWaitStable()
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	// 整页受限时直接判定，不走 passContentGate 的报错
+	if text := readGateText(page); classifyGate(text) == gateRestricted {
+		return &KeywordCheck{Keyword: keyword, Status: KeywordRestricted, StatusLabel: EnumLabel(EnumKeywordStatus, KeywordRestricted), Message: text}, nil
+	}
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	feeds, err := readSearchFeeds(page)
+	if err != nil && err != errors.ErrNoFeeds {
+		return nil, err
+	}
+
+	result := classifyKeyword(keyword, len(feeds), readSearchNotice(page))
+	logrus.Infof("关键词 %q 检测结果: %s (%d 条)", keyword, result.Status, result.ResultCount)
+	return result, nil
+}
+
+// readSearchNotice 读取搜索结果页的空状态或屏蔽提示
+func readSearchNotice(page *rod.Page) string {
+	return page.MustEval(`() => {
+		const nodes = document.querySelectorAll('.feeds-page .empty, .search-empty, [class*="empty"], [class*="no-result"], [class*="forbidden"]');
+		for (const el of nodes) {
+			const text = el.innerText.trim();
+			if (el.offsetParent !== null && text !== "") return text.slice(0, 300);
+		}
+		return "";
+	}`).Str()
+}
+
+// classifyKeyword 屏蔽提示优先于结果数量：被屏蔽的关键词有时仍会返回少量无关推荐
+func classifyKeyword(keyword string, count int, notice string) *KeywordCheck {
+	result := &KeywordCheck{Keyword: keyword, ResultCount: count, Message: notice}
+	result.Status = keywordStatus(count, notice)
+	result.StatusLabel = EnumLabel(EnumKeywordStatus, result.Status)
+	return result
+}
+
+func keywordStatus(count int, notice string) string {
+	for _, marker := range restrictedKeywordMarkers {
+		if strings.Contains(notice, marker) {
+			return KeywordRestricted
+		}
+	}
+	if count == 0 {
+		return KeywordNoResults
+	}
+	return KeywordAvailable
+}
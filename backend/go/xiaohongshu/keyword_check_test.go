@@ -0,0 +1,17 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyKeyword(t *testing.T) {
+	require.Equal(t, KeywordAvailable, classifyKeyword("穿搭", 20, "").Status)
+	require.Equal(t, KeywordNoResults, classifyKeyword("qwxzv", 0, "没有找到相关内容，换个词试试吧").Status)
+
+	restricted := classifyKeyword("敏感词", 3, "根据相关法律法规和政策，搜索结果未予显示")
+	require.Equal(t, KeywordRestricted, restricted.Status)
+	require.Equal(t, 3, restricted.ResultCount)
+	require.Contains(t, restricted.Message, "未予显示")
+}
@@ -0,0 +1,238 @@
+package xiaohongshu
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// ModerationPageSize 黑名单与屏蔽词每页返回的条数
+const ModerationPageSize = 50
+
+// 创作者中心黑名单与屏蔽词入口可能使用的菜单名称
+var (
+	blockedUsersMenuLabels  = []string{"黑名单", "黑名单管理", "已屏蔽用户", "屏蔽用户"}
+	mutedKeywordsMenuLabels = []string{"屏蔽词", "评论屏蔽词", "关键词屏蔽", "屏蔽词管理"}
+)
+
+// BlockedUser 黑名单中的用户
+type BlockedUser struct {
+	UserID   string `json:"user_id"`
+	Nickname string `json:"nickname,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+}
+
+// BlockedUsers 一页黑名单
+type BlockedUsers struct {
+	Supported bool          `json:"supported"`
+	Reason    string        `json:"reason,omitempty"` // 不支持时的原因
+	Users     []BlockedUser `json:"users"`
+	Cursor    string        `json:"cursor,omitempty"` // 下一页的游标，没有更多时为空
+	HasMore   bool          `json:"has_more"`
+}
+
+// MutedKeywords 一页屏蔽词
+type MutedKeywords struct {
+	Supported bool     `json:"supported"`
+	Reason    string   `json:"reason,omitempty"` // 不支持时的原因
+	Keywords  []string `json:"keywords"`
+	Cursor    string   `json:"cursor,omitempty"` // 下一页的游标，没有更多时为空
+	HasMore   bool     `json:"has_more"`
+}
+
+// ValidateModerationCursor 校验黑名单与屏蔽词的游标
+func ValidateModerationCursor(cursor string) error {
+	_, err := parseHistoryCursor(cursor)
+	return err
+}
+
+// ModerationAction 读取账号的黑名单与屏蔽词
+type ModerationAction struct {
+	page *rod.Page
+}
+
+func NewModerationAction(page *rod.Page) *ModerationAction {
+	pp := page.Timeout(60 * time.Second)
+	return &ModerationAction{page: pp}
+}
+
+// GetBlockedUsers 打开创作者中心的黑名单，返回 cursor 之后的一页。
+// 没有入口时不会报错，而是返回 Supported=false；黑名单为空时返回 Supported=true 和空列表。
+func (a *ModerationAction) GetBlockedUsers(ctx context.Context, cursor string) (*BlockedUsers, error) {
+	offset, err := parseHistoryCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page := a.page.Context(ctx)
+	result := &BlockedUsers{Users: []BlockedUser{}}
+
+	if reason := openModerationSection(page, blockedUsersMenuLabels); reason != "" {
+		result.Reason = reason
+		return result, nil
+	}
+
+	users := loadModerationList(page, offset+ModerationPageSize+1, func() []BlockedUser {
+		return readBlockedUsers(page)
+	})
+
+	result.Supported = true
+	result.Users, result.Cursor, result.HasMore = pageItems(users, offset, ModerationPageSize)
+	logrus.Infof("黑名单: offset=%d 返回 %d 人, has_more=%v", offset, len(result.Users), result.HasMore)
+	return result, nil
+}
+
+// GetMutedKeywords 打开创作者中心的屏蔽词设置，返回 cursor 之后的一页。
+// 没有入口时不会报错，而是返回 Supported=false；没有屏蔽词时返回 Supported=true 和空列表。
+func (a *ModerationAction) GetMutedKeywords(ctx context.Context, cursor string) (*MutedKeywords, error) {
+	offset, err := parseHistoryCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page := a.page.Context(ctx)
+	result := &MutedKeywords{Keywords: []string{}}
+
+	if reason := openModerationSection(page, mutedKeywordsMenuLabels); reason != "" {
+		result.Reason = reason
+		return result, nil
+	}
+
+	keywords := loadModerationList(page, offset+ModerationPageSize+1, func() []string {
+		return readMutedKeywords(page)
+	})
+
+	result.Supported = true
+	result.Keywords, result.Cursor, result.HasMore = pageItems(keywords, offset, ModerationPageSize)
+	logrus.Infof("屏蔽词: offset=%d 返回 %d 个, has_more=%v", offset, len(result.Keywords), result.HasMore)
+	return result, nil
+}
+
+// openModerationSection 打开创作者中心的对应设置页，找不到入口时返回原因
+func openModerationSection(page *rod.Page, labels []string) string {
+	page.MustNavigate(urlOfCreatorHome).MustWaitIdle().MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if _, err := openCreatorSection(page, labels); err != nil {
+		logrus.Infof("未找到设置入口: %v", err)
+		return "当前账号不支持在网页端查看该列表，或创作者中心未提供该设置"
+	}
+	return ""
+}
+
+// loadModerationList 滚动加载列表直到至少 want 条或没有新内容
+func loadModerationList[T any](page *rod.Page, want int, read func() []T) []T {
+	items := read()
+	for idle := 0; len(items) < want && idle < maxIdleScrolls; {
+		page.Mouse.MustScroll(0, 2000)
+		time.Sleep(1500 * time.Millisecond)
+		more := read()
+		if len(more) <= len(items) {
+			idle++
+		} else {
+			idle = 0
+		}
+		items = more
+	}
+	return items
+}
+
+// readBlockedUsers 读取黑名单页已加载的用户，按用户 ID 去重
+func readBlockedUsers(page *rod.Page) []BlockedUser {
+	rows := page.MustEval(`() => {
+		const result = [];
+		document.querySelectorAll('a[href*="/user/profile/"]').forEach(link => {
+			const row = link.closest('[class*="item"], [class*="row"], li, tr') || link;
+			const name = row.querySelector('.name, [class*="name"], [class*="nickname"]');
+			const avatar = row.querySelector('img');
+			result.push({
+				href: link.href,
+				nickname: name ? name.innerText.trim() : link.innerText.trim(),
+				avatar: avatar ? avatar.src : "",
+			});
+		});
+		return result;
+	}`).Arr()
+
+	users := make([]BlockedUser, 0, len(rows))
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		userID, ok := parseProfileLink(row.Get("href").Str())
+		if !ok || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		users = append(users, BlockedUser{
+			UserID:   userID,
+			Nickname: strings.TrimSpace(row.Get("nickname").Str()),
+			Avatar:   row.Get("avatar").Str(),
+		})
+	}
+	return users
+}
+
+// readMutedKeywords 读取屏蔽词页已加载的屏蔽词标签
+func readMutedKeywords(page *rod.Page) []string {
+	tags := page.MustEval(`() => Array.from(
+		document.querySelectorAll('.d-tag, [class*="tag"], [class*="keyword"] span'),
+		el => el.innerText,
+	)`).Arr()
+
+	texts := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		texts = append(texts, tag.Str())
+	}
+	return normalizeMutedKeywords(texts)
+}
+
+// normalizeMutedKeywords 去掉标签文本中的删除按钮符号和空白，按出现顺序去重；多行文本是标签的容器，忽略
+func normalizeMutedKeywords(texts []string) []string {
+	keywords := make([]string, 0, len(texts))
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		keyword := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(text), "×✕"))
+		if keyword == "" || strings.Contains(keyword, "\n") || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		keywords = append(keywords, keyword)
+	}
+	return keywords
+}
+
+// parseProfileLink 从用户主页链接中解析用户 ID
+func parseProfileLink(href string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+
+	const prefix = "/user/profile/"
+	idx := strings.Index(u.Path, prefix)
+	if idx < 0 {
+		return "", false
+	}
+	userID := strings.Trim(u.Path[idx+len(prefix):], "/")
+	if userID == "" || strings.Contains(userID, "/") {
+		return "", false
+	}
+	return userID, true
+}
+
+// pageItems 从已加载的列表中取出 offset 开始的一页，返回下一页游标与是否还有更多
+func pageItems[T any](items []T, offset, size int) ([]T, string, bool) {
+	if offset >= len(items) {
+		return []T{}, "", false
+	}
+
+	end := offset + size
+	if end >= len(items) {
+		return items[offset:], "", false
+	}
+	return items[offset:end], strconv.Itoa(end), true
+}
@@ -0,0 +1,47 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProfileLink(t *testing.T) {
+	userID, ok := parseProfileLink("https://www.xiaohongshu.com/user/profile/5a1b2c3d?xsec_token=abc")
+	require.True(t, ok)
+	require.Equal(t, "5a1b2c3d", userID)
+
+	userID, ok = parseProfileLink("/user/profile/5a1b2c3d/")
+	require.True(t, ok)
+	require.Equal(t, "5a1b2c3d", userID)
+
+	_, ok = parseProfileLink("https://www.xiaohongshu.com/user/profile/")
+	require.False(t, ok)
+	_, ok = parseProfileLink("https://www.xiaohongshu.com/explore/123")
+	require.False(t, ok)
+}
+
+func TestNormalizeMutedKeywords(t *testing.T) {
+	got := normalizeMutedKeywords([]string{" 广告 ×", "引流", "广告", "", "引流\n广告", "box"})
+	require.Equal(t, []string{"广告", "引流", "box"}, got)
+
+	require.Equal(t, []string{}, normalizeMutedKeywords(nil))
+}
+
+func TestPageItems(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4}
+
+	page, cursor, more := pageItems(items, 0, 2)
+	require.Equal(t, []int{0, 1}, page)
+	require.Equal(t, "2", cursor)
+	require.True(t, more)
+
+	page, cursor, more = pageItems(items, 4, 2)
+	require.Equal(t, []int{4}, page)
+	require.Empty(t, cursor)
+	require.False(t, more)
+
+	page, _, more = pageItems(items, 10, 2)
+	require.Equal(t, []int{}, page)
+	require.False(t, more)
+}
@@ -0,0 +1,263 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// MaxCommentDeleteBatch 单次批量删除的评论上限
+const MaxCommentDeleteBatch = 50
+
+// 批量删除时两条评论之间的间隔，另加最多 2 秒的随机抖动
+const commentDeleteInterval = 3 * time.Second
+
+// MyComment 当前账号在笔记下发表的评论或回复
+type MyComment struct {
+	FeedID     string `json:"feed_id"`
+	CommentID  string `json:"comment_id"`
+	ParentID   string `json:"parent_id,omitempty"` // 回复所属的一级评论，一级评论为空
+	Content    string `json:"content"`
+	CreateTime int64  `json:"create_time"`
+	LikeCount  string `json:"like_count,omitempty"`
+}
+
+// MyComments 笔记下当前账号的评论
+type MyComments struct {
+	FeedID   string      `json:"feed_id"`
+	UserID   string      `json:"user_id"`
+	Comments []MyComment `json:"comments"`
+	Count    int         `json:"count"`
+	// Complete 为 false 表示笔记评论未全部加载，可能还有更早的评论没有列出
+	Complete bool `json:"complete"`
+}
+
+// CommentRef 要删除的评论
+type CommentRef struct {
+	FeedID    string `json:"feed_id" jsonschema:"笔记ID"`
+	XsecToken string `json:"xsec_token" jsonschema:"笔记的xsec_token"`
+	CommentID string `json:"comment_id" jsonschema:"评论ID"`
+}
+
+// CommentDeleteResult 单条评论的删除结果
+type CommentDeleteResult struct {
+	FeedID    string `json:"feed_id"`
+	CommentID string `json:"comment_id"`
+	Deleted   bool   `json:"deleted"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CommentDeleteSummary 批量删除的汇总
+type CommentDeleteSummary struct {
+	Results []CommentDeleteResult `json:"results"`
+	Deleted int                   `json:"deleted"`
+	Failed  int                   `json:"failed"`
+}
+
+// ValidateCommentRefs 校验批量删除的评论列表，数量不超过 MaxCommentDeleteBatch
+func ValidateCommentRefs(refs []CommentRef) error {
+	if len(refs) == 0 {
+		return fmt.Errorf("评论列表不能为空")
+	}
+	if len(refs) > MaxCommentDeleteBatch {
+		return fmt.Errorf("一次最多删除 %d 条评论，实际 %d 条", MaxCommentDeleteBatch, len(refs))
+	}
+	for i, ref := range refs {
+		if ref.FeedID == "" || ref.XsecToken == "" || ref.CommentID == "" {
+			return fmt.Errorf("第 %d 项缺少 feed_id、xsec_token 或 comment_id", i+1)
+		}
+	}
+	return nil
+}
+
+// CommentManageAction 列出并删除当前账号的评论
+type CommentManageAction struct {
+	page *rod.Page
+}
+
+func NewCommentManageAction(page *rod.Page) *CommentManageAction {
+	pp := page.Timeout(60 * time.Second)
+	return &CommentManageAction{page: pp}
+}
+
+// GetMyComments 打开笔记详情页，展开回复后列出当前账号发表的评论与回复
+func (a *CommentManageAction) GetMyComments(ctx context.Context, feedID, xsecToken string) (*MyComments, error) {
+	page := a.page.Context(ctx)
+
+	comments, userID, err := openComments(page, feedID, xsecToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mine := filterMyComments(feedID, userID, comments.List, "")
+	return &MyComments{
+		FeedID:   feedID,
+		UserID:   userID,
+		Comments: mine,
+		Count:    len(mine),
+		Complete: !comments.HasMore && !comments.DepthTruncated,
+	}, nil
+}
+
+// DeleteComment 删除当前账号的一条评论，删除后重新读取评论确认已消失。
+// 评论不是当前账号发表的返回 ErrNotOwner。
+func (a *CommentManageAction) DeleteComment(ctx context.Context, ref CommentRef) error {
+	page := a.page.Context(ctx)
+
+	comments, userID, err := openComments(page, ref.FeedID, ref.XsecToken)
+	if err != nil {
+		return err
+	}
+
+	comment := findComment(comments.List, ref.CommentID)
+	if comment == nil {
+		return fmt.Errorf("没有找到评论 %s（可能已删除或未加载）", ref.CommentID)
+	}
+	if comment.UserInfo.UserID != userID {
+		return fmt.Errorf("%w: 评论 %s 由 %s 发表", errors.ErrNotOwner, ref.CommentID, comment.UserInfo.UserID)
+	}
+
+	elem, err := page.Timeout(10 * time.Second).Element("#comment-" + ref.CommentID)
+	if err != nil {
+		return fmt.Errorf("没有找到评论元素 %s: %w", ref.CommentID, err)
+	}
+	elem.MustScrollIntoView()
+	elem.MustHover()
+	time.Sleep(300 * time.Millisecond)
+
+	// 删除入口在评论右侧的“更多”菜单中，部分版本直接显示“删除”
+	if more, err := elem.Element(`[class*="more"], .menu`); err == nil {
+		more.MustClick()
+		time.Sleep(300 * time.Millisecond)
+	}
+	del, err := page.Timeout(5*time.Second).ElementR("div, span, button, li", "^删除$")
+	if err != nil {
+		return fmt.Errorf("没有找到删除入口: %w", err)
+	}
+	del.MustClick()
+	time.Sleep(300 * time.Millisecond)
+
+	confirm, err := page.Timeout(5*time.Second).ElementR("button", "^(确定|确认|删除)$")
+	if err != nil {
+		return fmt.Errorf("没有找到删除确认按钮: %w", err)
+	}
+	confirm.MustClick()
+	time.Sleep(1 * time.Second)
+
+	// 重新加载后确认评论已消失
+	comments, _, err = openComments(page, ref.FeedID, ref.XsecToken)
+	if err != nil {
+		return fmt.Errorf("删除后读取评论失败: %w", err)
+	}
+	if findComment(comments.List, ref.CommentID) != nil {
+		return fmt.Errorf("评论 %s 删除后仍然存在", ref.CommentID)
+	}
+
+	logrus.Infof("已删除评论: feed=%s comment=%s", ref.FeedID, ref.CommentID)
+	return nil
+}
+
+// BatchDeleteComments 逐条删除评论，两次删除之间等待一段时间；单条失败不影响其余评论
+func (a *CommentManageAction) BatchDeleteComments(ctx context.Context, refs []CommentRef) *CommentDeleteSummary {
+	summary := &CommentDeleteSummary{Results: make([]CommentDeleteResult, 0, len(refs))}
+
+	for i, ref := range refs {
+		result := CommentDeleteResult{FeedID: ref.FeedID, CommentID: ref.CommentID}
+
+		if i > 0 {
+			wait := commentDeleteInterval + time.Duration(rand.Int63n(int64(2*time.Second)))
+			select {
+			case <-ctx.Done():
+			case <-time.After(wait):
+			}
+		}
+
+		var err error
+		if err = ctx.Err(); err == nil {
+			err = a.DeleteComment(ctx, ref)
+		}
+		if err != nil {
+			logrus.Warnf("删除评论 %s 失败: %v", ref.CommentID, err)
+			result.Error = err.Error()
+			summary.Failed++
+		} else {
+			result.Deleted = true
+			summary.Deleted++
+		}
+		summary.Results = append(summary.Results, result)
+	}
+
+	return summary
+}
+
+// openComments 打开笔记详情页并展开回复，返回已加载的评论与当前登录账号的用户 ID
+func openComments(page *rod.Page, feedID, xsecToken string) (*CommentList, string, error) {
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, "", err
+	}
+	expandReplies(page, maxReplyExpansions)
+
+	comments, err := readCommentsFromState(page, feedID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	userID := page.MustEval(`() => {
+		const state = window.__INITIAL_STATE__;
+		const info = state && state.user && state.user.userInfo;
+		const me = info && (info.value !== undefined ? info.value : info._value || info);
+		return (me && me.userId) || "";
+	}`).String()
+	if userID == "" {
+		return nil, "", fmt.Errorf("无法获取当前登录账号，请先登录")
+	}
+
+	return comments, userID, nil
+}
+
+// filterMyComments 递归筛选 userID 发表的评论与回复，parentID 为 list 所属的一级评论
+func filterMyComments(feedID, userID string, list []Comment, parentID string) []MyComment {
+	mine := []MyComment{}
+	for _, c := range list {
+		if c.UserInfo.UserID == userID {
+			mine = append(mine, MyComment{
+				FeedID:     feedID,
+				CommentID:  c.ID,
+				ParentID:   parentID,
+				Content:    strings.TrimSpace(c.Content),
+				CreateTime: c.CreateTime,
+				LikeCount:  c.LikeCount,
+			})
+		}
+
+		parent := parentID
+		if parent == "" {
+			parent = c.ID
+		}
+		mine = append(mine, filterMyComments(feedID, userID, c.SubComments, parent)...)
+	}
+	return mine
+}
+
+// findComment 在评论树中查找指定 ID 的评论
+func findComment(list []Comment, id string) *Comment {
+	for i := range list {
+		if list[i].ID == id {
+			return &list[i]
+		}
+		if c := findComment(list[i].SubComments, id); c != nil {
+			return c
+		}
+	}
+	return nil
+}
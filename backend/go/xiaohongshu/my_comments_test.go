@@ -0,0 +1,53 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMyComments(t *testing.T) {
+	me := User{UserID: "me"}
+	other := User{UserID: "other"}
+	list := []Comment{
+		{ID: "c1", Content: " 我的评论 ", UserInfo: me, SubComments: []Comment{
+			{ID: "r1", UserInfo: other},
+			{ID: "r2", Content: "我的回复", UserInfo: me},
+		}},
+		{ID: "c2", UserInfo: other, SubComments: []Comment{
+			{ID: "r3", Content: "回复别人", UserInfo: me},
+		}},
+	}
+
+	mine := filterMyComments("n1", "me", list, "")
+	require.Len(t, mine, 3)
+	require.Equal(t, MyComment{FeedID: "n1", CommentID: "c1", Content: "我的评论"}, mine[0])
+	require.Equal(t, "c1", mine[1].ParentID)
+	require.Equal(t, "r3", mine[2].CommentID)
+	require.Equal(t, "c2", mine[2].ParentID)
+
+	require.Empty(t, filterMyComments("n1", "nobody", list, ""))
+	require.NotNil(t, filterMyComments("n1", "nobody", list, ""))
+}
+
+func TestFindComment(t *testing.T) {
+	list := []Comment{{ID: "c1", SubComments: []Comment{{ID: "r1", Content: "回复"}}}}
+
+	require.Equal(t, "回复", findComment(list, "r1").Content)
+	require.Nil(t, findComment(list, "missing"))
+}
+
+func TestValidateCommentRefs(t *testing.T) {
+	ref := CommentRef{FeedID: "n1", XsecToken: "t", CommentID: "c1"}
+	require.NoError(t, ValidateCommentRefs([]CommentRef{ref}))
+
+	require.Error(t, ValidateCommentRefs(nil))
+	require.Error(t, ValidateCommentRefs([]CommentRef{{FeedID: "n1", XsecToken: "t"}}))
+
+	many := make([]CommentRef, MaxCommentDeleteBatch+1)
+	for i := range many {
+		many[i] = CommentRef{FeedID: "n1", XsecToken: "t", CommentID: fmt.Sprint(i)}
+	}
+	require.Error(t, ValidateCommentRefs(many))
+}
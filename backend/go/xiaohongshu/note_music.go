@@ -0,0 +1,109 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// MusicTrack 笔记使用的背景音乐
+type MusicTrack struct {
+	TrackID  string `json:"track_id,omitempty"`
+	Name     string `json:"name"`
+	Artist   string `json:"artist,omitempty"`
+	Trending *bool  `json:"trending,omitempty"` // 是否为热门音乐，平台未提供时为空
+}
+
+// NoteMusic 笔记的背景音乐，没有音乐时 music 为 null
+type NoteMusic struct {
+	FeedID   string      `json:"feed_id"`
+	HasMusic bool        `json:"has_music"`
+	Music    *MusicTrack `json:"music"`
+}
+
+// musicEntry 笔记数据中的音乐信息，不同版本字段名不一致
+type musicEntry struct {
+	ID        string `json:"id"`
+	MusicID   string `json:"musicId"`
+	MusicIDSC string `json:"music_id"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	MusicName string `json:"musicName"`
+	Author    string `json:"author"`
+	Artist    string `json:"artist"`
+	Singer    string `json:"singer"`
+	IsHot     *bool  `json:"isHot"`
+	Hot       *bool  `json:"hot"`
+	Trending  *bool  `json:"isTrending"`
+}
+
+// NoteMusicAction 读取笔记的背景音乐
+type NoteMusicAction struct {
+	page *rod.Page
+}
+
+func NewNoteMusicAction(page *rod.Page) *NoteMusicAction {
+	pp := page.Timeout(30 * time.Second)
+	return &NoteMusicAction{page: pp}
+}
+
+// GetNoteMusic 打开笔记详情页，从 __INITIAL_STATE__ 中读取背景音乐信息
+func (a *NoteMusicAction) GetNoteMusic(ctx context.Context, feedID, xsecToken string) (*NoteMusic, error) {
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	raw := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		const note = map && map[feedID] && map[feedID].note;
+		if (!note) return "";
+		const video = note.video || {};
+		const media = video.media || {};
+		return JSON.stringify([note.music, note.musicInfo, note.bgm, note.audio, video.music, media.music, media.bgm].filter(Boolean));
+	}`, feedID).Str()
+	if raw == "" {
+		return nil, errors.ErrNoFeedDetail
+	}
+
+	return parseNoteMusic(feedID, []byte(raw))
+}
+
+// parseNoteMusic 从候选字段中取第一个带名称或 ID 的音乐信息
+func parseNoteMusic(feedID string, raw []byte) (*NoteMusic, error) {
+	var entries []musicEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal note music: %w", err)
+	}
+
+	result := &NoteMusic{FeedID: feedID}
+	for _, e := range entries {
+		track := MusicTrack{
+			TrackID: firstNonEmpty(e.ID, e.MusicID, e.MusicIDSC),
+			Name:    firstNonEmpty(e.Name, e.MusicName, e.Title),
+			Artist:  firstNonEmpty(e.Artist, e.Author, e.Singer),
+		}
+		if track.TrackID == "" && track.Name == "" {
+			continue
+		}
+		for _, flag := range []*bool{e.Trending, e.IsHot, e.Hot} {
+			if flag != nil {
+				track.Trending = flag
+				break
+			}
+		}
+		result.HasMusic = true
+		result.Music = &track
+		break
+	}
+	return result, nil
+}
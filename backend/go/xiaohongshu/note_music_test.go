@@ -0,0 +1,31 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNoteMusic(t *testing.T) {
+	music, err := parseNoteMusic("v1", []byte(`[{"musicId": "m1", "musicName": "晴天", "singer": "周杰伦", "isHot": true}]`))
+	require.NoError(t, err)
+	require.True(t, music.HasMusic)
+	require.Equal(t, "m1", music.Music.TrackID)
+	require.Equal(t, "晴天", music.Music.Name)
+	require.Equal(t, "周杰伦", music.Music.Artist)
+	require.True(t, *music.Music.Trending)
+
+	// 第一个候选没有名称和 ID 时取下一个；没有热门标记时 trending 为空
+	fallback, err := parseNoteMusic("v2", []byte(`[{"url": "x"}, {"id": "m2", "name": "原声"}]`))
+	require.NoError(t, err)
+	require.Equal(t, "m2", fallback.Music.TrackID)
+	require.Nil(t, fallback.Music.Trending)
+
+	none, err := parseNoteMusic("n1", []byte(`[]`))
+	require.NoError(t, err)
+	require.False(t, none.HasMusic)
+	require.Nil(t, none.Music)
+
+	_, err = parseNoteMusic("n2", []byte(`{`))
+	require.Error(t, err)
+}
@@ -0,0 +1,251 @@
+package xiaohongshu
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// 归档截图的评论条数，默认比整串抓取少，避免图片过长
+const DefaultArchiveComments = 50
+
+// 归档截图的最大高度（CSS 像素），超出部分不截取并标记 Truncated
+const maxArchiveHeight = 30000
+
+// 截取每一段前等待懒加载图片的时间
+const archiveSegmentWait = 600 * time.Millisecond
+
+// NoteScreenshot 笔记长截图
+type NoteScreenshot struct {
+	FeedID     string    `json:"feed_id"`
+	Title      string    `json:"title,omitempty"`
+	Path       string    `json:"path"` // PNG 文件路径
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Bytes      int       `json:"bytes"`
+	Segments   int       `json:"segments"` // 拼接的截图段数
+	Comments   int       `json:"comments"` // 截图前已加载的评论数，含回复
+	Truncated  bool      `json:"truncated"`
+	CapturedAt time.Time `json:"captured_at"`
+	// PendingImages 截图时仍未加载完成的图片数，不为 0 时截图中可能有空白图片
+	PendingImages int `json:"pending_images"`
+}
+
+// NoteScreenshotAction 截取笔记详情页的完整长图
+type NoteScreenshotAction struct {
+	page *rod.Page
+}
+
+func NewNoteScreenshotAction(page *rod.Page) *NoteScreenshotAction {
+	return &NoteScreenshotAction{page: page}
+}
+
+// 把详情页的内部滚动区和图片轮播展开为普通文档流，使正文、全部图片和评论都在一张长图中
+const archiveLayoutStyle = `
+	#noteContainer, .note-container, .note-container .interaction-container {
+		height: auto !important; max-height: none !important;
+	}
+	.note-container { flex-direction: column !important; }
+	.note-scroller, .interaction-container, .note-content {
+		overflow: visible !important; height: auto !important; max-height: none !important;
+	}
+	.media-container, .media-container .swiper, .media-container .swiper-wrapper {
+		height: auto !important; max-height: none !important; transform: none !important;
+	}
+	.media-container .swiper-wrapper { flex-direction: column !important; }
+	.media-container .swiper-slide { width: 100% !important; height: auto !important; }
+	.media-container .swiper-slide-duplicate, .media-container [class*="arrow"], .media-container .pagination {
+		display: none !important;
+	}
+	.note-detail-mask, .close-circle, .engage-bar { position: static !important; }
+`
+
+// ArchiveNoteScreenshot 打开笔记详情，加载评论和全部图片后分段滚动截图并拼接为一张 PNG，保存到 dir
+func (a *NoteScreenshotAction) ArchiveNoteScreenshot(ctx context.Context, feedID, xsecToken, dir string, maxComments int) (*NoteScreenshot, error) {
+	if err := ValidateThreadComments(maxComments); err != nil {
+		return nil, err
+	}
+	if maxComments == 0 {
+		maxComments = DefaultArchiveComments
+	}
+
+	// 评论加载与逐段截图的耗时随笔记长度增长，由 ctx 控制取消
+	page := a.page.Context(ctx)
+
+	detail, err := NewFeedDetailAction(page).GetFeedDetail(ctx, feedID, xsecToken)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := loadThreadComments(page, feedID, maxComments)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := expandArchiveLayout(page)
+
+	data, width, height, segments, truncated, err := captureStitched(page, maxArchiveHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "创建归档目录失败")
+	}
+	now := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.png", feedID, now.Format("20060102-150405")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, errors.Wrap(err, "保存截图失败")
+	}
+
+	result := &NoteScreenshot{
+		FeedID:        feedID,
+		Title:         detail.Note.Title,
+		Path:          path,
+		Width:         width,
+		Height:        height,
+		Bytes:         len(data),
+		Segments:      segments,
+		Comments:      countComments(comments.List),
+		Truncated:     truncated,
+		CapturedAt:    now,
+		PendingImages: pending,
+	}
+	logrus.Infof("笔记 %s 长截图: %s (%dx%d, %d 段)", feedID, path, width, height, segments)
+	return result, nil
+}
+
+// expandArchiveLayout 注入展开布局的样式，把懒加载图片改为立即加载并等待完成，返回仍未加载完成的图片数
+func expandArchiveLayout(page *rod.Page) int {
+	page.MustEval(`css => {
+		const style = document.createElement('style');
+		style.textContent = css;
+		document.head.appendChild(style);
+		document.querySelectorAll('img').forEach(img => {
+			img.loading = 'eager';
+			const lazy = img.dataset.src || img.dataset.original;
+			if (lazy && !img.src) img.src = lazy;
+		});
+	}`, archiveLayoutStyle)
+	page.MustWaitStable()
+
+	// 最多等待 10 秒让图片加载完成
+	pending := 0
+	for i := 0; i < 20; i++ {
+		pending = page.MustEval(`() => Array.from(document.images).filter(img => img.src && !img.complete).length`).Int()
+		if pending == 0 {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return pending
+}
+
+// captureStitched 按视口高度逐段滚动截图并纵向拼接，返回 PNG 数据、尺寸、段数与是否因超过 maxHeight 被截断
+func captureStitched(page *rod.Page, maxHeight int) ([]byte, int, int, int, bool, error) {
+	metrics := page.MustEval(`() => ({
+		width: document.documentElement.scrollWidth,
+		height: Math.max(document.documentElement.scrollHeight, document.body ? document.body.scrollHeight : 0),
+		viewport: window.innerHeight,
+	})`)
+	width := metrics.Get("width").Int()
+	total := metrics.Get("height").Int()
+	truncated := total > maxHeight
+	if truncated {
+		total = maxHeight
+	}
+
+	segments := screenshotSegments(total, metrics.Get("viewport").Int())
+	if len(segments) == 0 {
+		return nil, 0, 0, 0, false, errors.New("页面没有可截取的内容")
+	}
+
+	pieces := make([][]byte, 0, len(segments))
+	for i, seg := range segments {
+		page.MustEval(`y => window.scrollTo(0, y)`, seg.y)
+		time.Sleep(archiveSegmentWait)
+		if i == 1 {
+			// 固定定位的顶栏等元素只保留在第一段中
+			page.MustEval(`() => document.querySelectorAll('body *').forEach(el => {
+				const pos = getComputedStyle(el).position;
+				if (pos === 'fixed' || pos === 'sticky') el.style.visibility = 'hidden';
+			})`)
+		}
+
+		data, err := page.Screenshot(false, &proto.PageCaptureScreenshot{
+			Format: proto.PageCaptureScreenshotFormatPng,
+			Clip: &proto.PageViewport{
+				X: 0, Y: float64(seg.y), Width: float64(width), Height: float64(seg.height), Scale: 1,
+			},
+			CaptureBeyondViewport: true,
+		})
+		if err != nil {
+			return nil, 0, 0, 0, false, errors.Wrapf(err, "截取第 %d 段失败", i+1)
+		}
+		pieces = append(pieces, data)
+	}
+
+	data, w, h, err := stitchPNGs(pieces)
+	if err != nil {
+		return nil, 0, 0, 0, false, err
+	}
+	return data, w, h, len(pieces), truncated, nil
+}
+
+// screenshotSegment 一段截图在页面中的起始位置与高度
+type screenshotSegment struct {
+	y      int
+	height int
+}
+
+// screenshotSegments 把 total 高的页面按 viewport 高度切分，最后一段取剩余高度
+func screenshotSegments(total, viewport int) []screenshotSegment {
+	if total <= 0 || viewport <= 0 {
+		return nil
+	}
+	var segments []screenshotSegment
+	for y := 0; y < total; y += viewport {
+		segments = append(segments, screenshotSegment{y: y, height: min(viewport, total-y)})
+	}
+	return segments
+}
+
+// stitchPNGs 把多张 PNG 自上而下拼接为一张，宽度取最宽的一张
+func stitchPNGs(pieces [][]byte) ([]byte, int, int, error) {
+	images := make([]image.Image, 0, len(pieces))
+	width, height := 0, 0
+	for i, data := range pieces {
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, 0, 0, errors.Wrapf(err, "解析第 %d 段截图失败", i+1)
+		}
+		images = append(images, img)
+		width = max(width, img.Bounds().Dx())
+		height += img.Bounds().Dy()
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	y := 0
+	for _, img := range images {
+		b := img.Bounds()
+		draw.Draw(canvas, image.Rect(0, y, b.Dx(), y+b.Dy()), img, b.Min, draw.Src)
+		y += b.Dy()
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, 0, 0, errors.Wrap(err, "生成长截图失败")
+	}
+	return buf.Bytes(), width, height, nil
+}
@@ -0,0 +1,49 @@
+package xiaohongshu
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreenshotSegments(t *testing.T) {
+	require.Equal(t, []screenshotSegment{{0, 800}, {800, 800}, {1600, 400}}, screenshotSegments(2000, 800))
+	require.Equal(t, []screenshotSegment{{0, 500}}, screenshotSegments(500, 800))
+	require.Nil(t, screenshotSegments(0, 800))
+	require.Nil(t, screenshotSegments(500, 0))
+}
+
+func solidPNG(t *testing.T, w, h int, c color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestStitchPNGs(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+
+	data, w, h, err := stitchPNGs([][]byte{solidPNG(t, 10, 4, red), solidPNG(t, 10, 3, blue)})
+	require.NoError(t, err)
+	require.Equal(t, 10, w)
+	require.Equal(t, 7, h)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 10, 7), img.Bounds())
+	require.Equal(t, red, color.RGBAModel.Convert(img.At(5, 3)))
+	require.Equal(t, blue, color.RGBAModel.Convert(img.At(5, 4)))
+
+	_, _, _, err = stitchPNGs([][]byte{[]byte("not a png")})
+	require.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 笔记数据中表示关闭评论可能使用的字段名
+var commentDisabledKeys = []string{"commentDisabled", "disableComment", "commentClosed", "closeComment"}
+
+// 关闭评论后评论区与输入框可能展示的提示
+var commentClosedHints = []string{"评论已关闭", "关闭了评论", "已关闭评论", "评论功能已关闭", "暂不支持评论"}
+
+// NoteSettings 笔记的互动权限，平台没有公开的项为 null
+type NoteSettings struct {
+	FeedID          string   `json:"feed_id"`
+	CommentsEnabled *bool    `json:"comments_enabled"`
+	LikesEnabled    *bool    `json:"likes_enabled"`
+	CollectsEnabled *bool    `json:"collects_enabled"`
+	SharesEnabled   *bool    `json:"shares_enabled"`
+	Notes           []string `json:"notes,omitempty"` // 为 null 的项的原因
+}
+
+// noteSettingsSignals 详情页上与互动权限相关的数据
+type noteSettingsSignals struct {
+	State         map[string]any // __INITIAL_STATE__ 中的笔记原始数据，读取失败时为 nil
+	HintText      string         // 输入框与评论区底部的提示文本
+	CommentInput  bool
+	LikeButton    bool
+	CollectButton bool
+	ShareButton   bool
+}
+
+// NoteSettingsAction 读取笔记的评论与互动权限
+type NoteSettingsAction struct {
+	page *rod.Page
+}
+
+func NewNoteSettingsAction(page *rod.Page) *NoteSettingsAction {
+	pp := page.Timeout(60 * time.Second)
+	return &NoteSettingsAction{page: pp}
+}
+
+// GetNoteSettings 打开笔记详情页读取评论、点赞、收藏、分享权限
+func (a *NoteSettingsAction) GetNoteSettings(ctx context.Context, feedID, xsecToken string) (*NoteSettings, error) {
+	page := a.page.Context(ctx)
+
+	if _, err := NewFeedDetailAction(page).GetFeedDetail(ctx, feedID, xsecToken); err != nil {
+		return nil, err
+	}
+
+	settings := ReadNoteSettings(page, feedID)
+	logrus.Infof("笔记 %s 互动权限已读取, %d 项未公开", feedID, len(settings.Notes))
+	return settings, nil
+}
+
+// ReadNoteSettings 从已打开的笔记详情页读取互动权限，优先使用 __INITIAL_STATE__ 中的字段，其次使用页面元素
+func ReadNoteSettings(page *rod.Page, feedID string) *NoteSettings {
+	info := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		const detail = map && map[feedID];
+		let note = "";
+		try {
+			note = detail && detail.note ? JSON.stringify(detail.note) : "";
+		} catch (e) {}
+		const hints = Array.from(
+			document.querySelectorAll('.engage-bar, .input-box, .comments-container .end-container, .no-comments'),
+			el => el.innerText,
+		).join("\n");
+		const exists = s => document.querySelector(s) !== null;
+		return {
+			note: note,
+			hints: hints,
+			commentInput: exists('.input-box .content-edit, .input-box .inner'),
+			like: exists('.like-wrapper'),
+			collect: exists('.collect-wrapper'),
+			share: exists('.share-wrapper, .share-icon-container'),
+		};
+	}`, feedID)
+
+	signals := noteSettingsSignals{
+		HintText:      info.Get("hints").Str(),
+		CommentInput:  info.Get("commentInput").Bool(),
+		LikeButton:    info.Get("like").Bool(),
+		CollectButton: info.Get("collect").Bool(),
+		ShareButton:   info.Get("share").Bool(),
+	}
+	if raw := info.Get("note").Str(); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &signals.State); err != nil {
+			logrus.Warnf("解析笔记 %s 的原始数据失败: %v", feedID, err)
+		}
+	}
+	return resolveNoteSettings(feedID, signals)
+}
+
+// resolveNoteSettings 根据详情页数据判断互动权限。
+// 评论：笔记数据中的关闭评论字段或页面上的关闭提示为 false，有评论输入框为 true；
+// 分享：shareInfo.unShare 为 true 时为 false；点赞、收藏平台不提供关闭选项，按钮存在即为 true。
+func resolveNoteSettings(feedID string, s noteSettingsSignals) *NoteSettings {
+	settings := &NoteSettings{FeedID: feedID}
+
+	if disabled := stateFlag(s.State, commentDisabledKeys...); disabled != nil {
+		settings.CommentsEnabled = boolPtr(!*disabled)
+	} else if containsAny(s.HintText, commentClosedHints) {
+		settings.CommentsEnabled = boolPtr(false)
+	} else if s.CommentInput {
+		settings.CommentsEnabled = boolPtr(true)
+	} else {
+		settings.Notes = append(settings.Notes, "页面上没有评论输入框或关闭评论的提示，无法判断是否可评论")
+	}
+
+	if s.LikeButton {
+		settings.LikesEnabled = boolPtr(true)
+	} else {
+		settings.Notes = append(settings.Notes, "页面上没有点赞按钮")
+	}
+	if s.CollectButton {
+		settings.CollectsEnabled = boolPtr(true)
+	} else {
+		settings.Notes = append(settings.Notes, "页面上没有收藏按钮")
+	}
+
+	shareInfo, _ := s.State["shareInfo"].(map[string]any)
+	if unShare := stateFlag(shareInfo, "unShare"); unShare != nil {
+		settings.SharesEnabled = boolPtr(!*unShare)
+	} else if s.ShareButton {
+		settings.SharesEnabled = boolPtr(true)
+	} else {
+		settings.Notes = append(settings.Notes, "笔记数据与页面都没有分享权限信息")
+	}
+
+	return settings
+}
+
+// stateFlag 返回 m 中第一个存在的布尔字段
+func stateFlag(m map[string]any, keys ...string) *bool {
+	for _, key := range keys {
+		if v, ok := m[key].(bool); ok {
+			return &v
+		}
+	}
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
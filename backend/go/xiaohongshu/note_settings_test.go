@@ -0,0 +1,46 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveNoteSettingsFromState(t *testing.T) {
+	settings := resolveNoteSettings("n1", noteSettingsSignals{
+		State: map[string]any{
+			"commentDisabled": true,
+			"shareInfo":       map[string]any{"unShare": true},
+		},
+		CommentInput:  true,
+		LikeButton:    true,
+		CollectButton: true,
+		ShareButton:   true,
+	})
+
+	require.Equal(t, "n1", settings.FeedID)
+	require.False(t, *settings.CommentsEnabled)
+	require.True(t, *settings.LikesEnabled)
+	require.True(t, *settings.CollectsEnabled)
+	require.False(t, *settings.SharesEnabled)
+	require.Empty(t, settings.Notes)
+}
+
+func TestResolveNoteSettingsFromDOM(t *testing.T) {
+	closed := resolveNoteSettings("n1", noteSettingsSignals{HintText: "作者已关闭评论", CommentInput: true})
+	require.False(t, *closed.CommentsEnabled)
+
+	open := resolveNoteSettings("n1", noteSettingsSignals{CommentInput: true, ShareButton: true})
+	require.True(t, *open.CommentsEnabled)
+	require.True(t, *open.SharesEnabled)
+}
+
+func TestResolveNoteSettingsUnknown(t *testing.T) {
+	settings := resolveNoteSettings("n1", noteSettingsSignals{})
+
+	require.Nil(t, settings.CommentsEnabled)
+	require.Nil(t, settings.LikesEnabled)
+	require.Nil(t, settings.CollectsEnabled)
+	require.Nil(t, settings.SharesEnabled)
+	require.Len(t, settings.Notes, 4)
+}
@@ -0,0 +1,161 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// 整串抓取的评论条数（一级评论与回复合计）
+const (
+	DefaultThreadComments = 200
+	MaxThreadComments     = 1000
+)
+
+// 整串抓取时最多点击“展开回复”的次数，比单独读取评论时更多以尽量拿全回复
+const threadReplyExpansions = 50
+
+// NoteThread 笔记与评论树
+type NoteThread struct {
+	FeedID       string     `json:"feed_id"`
+	Note         FeedDetail `json:"note"`
+	Comments     []Comment  `json:"comments"`
+	CommentCount int        `json:"comment_count"` // 返回的评论数，含回复
+	MaxComments  int        `json:"max_comments"`
+	// CommentsTruncated 为 true 表示评论没有全部返回：达到 max_comments、平台还有更多评论，或有回复未展开
+	CommentsTruncated bool `json:"comments_truncated"`
+}
+
+// ValidateThreadComments 校验评论条数上限，0 表示 DefaultThreadComments
+func ValidateThreadComments(n int) error {
+	if n < 0 || n > MaxThreadComments {
+		return fmt.Errorf("无效的评论条数 %d，可选范围 1-%d", n, MaxThreadComments)
+	}
+	return nil
+}
+
+// NoteThreadAction 在同一个页面中依次读取笔记详情和评论
+type NoteThreadAction struct {
+	page *rod.Page
+}
+
+func NewNoteThreadAction(page *rod.Page) *NoteThreadAction {
+	return &NoteThreadAction{page: page}
+}
+
+// GetNoteThread 打开笔记详情后在同一页面滚动加载评论并展开回复，直到达到 maxComments 条或没有更多评论
+func (a *NoteThreadAction) GetNoteThread(ctx context.Context, feedID, xsecToken string, maxComments int) (*NoteThread, error) {
+	if err := ValidateThreadComments(maxComments); err != nil {
+		return nil, err
+	}
+	if maxComments == 0 {
+		maxComments = DefaultThreadComments
+	}
+
+	// 滚动加载评论的耗时随评论数增长，不受单页 60 秒超时限制
+	page := a.page.Context(ctx)
+
+	detail, err := NewFeedDetailAction(page).GetFeedDetail(ctx, feedID, xsecToken)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := loadThreadComments(page, feedID, maxComments)
+	if err != nil {
+		return nil, err
+	}
+	depthTruncated := limitCommentDepth(comments.List, 1, MaxCommentDepth)
+
+	list, capped := capComments(comments.List, maxComments)
+	if list == nil {
+		list = []Comment{}
+	}
+	thread := &NoteThread{
+		FeedID:            feedID,
+		Note:              detail.Note,
+		Comments:          list,
+		CommentCount:      countComments(list),
+		MaxComments:       maxComments,
+		CommentsTruncated: capped || comments.HasMore || depthTruncated,
+	}
+
+	logrus.Infof("笔记 %s 评论串: %d 条评论, truncated=%v", feedID, thread.CommentCount, thread.CommentsTruncated)
+	return thread, nil
+}
+
+// loadThreadComments 滚动评论区加载更多一级评论，再展开回复，返回页面已加载的评论
+func loadThreadComments(page *rod.Page, feedID string, maxComments int) (*CommentList, error) {
+	interval := configs.GetPageInterval()
+
+	comments, err := readCommentsFromState(page, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	idle := 0
+	for comments.HasMore && countComments(comments.List) < maxComments && idle < maxIdleScrolls {
+		before := len(comments.List)
+		page.MustEval(`() => {
+			const scroller = document.querySelector('.note-scroller') || document.scrollingElement;
+			scroller.scrollTop = scroller.scrollHeight;
+		}`)
+		time.Sleep(interval)
+
+		if comments, err = readCommentsFromState(page, feedID); err != nil {
+			return nil, err
+		}
+		if len(comments.List) == before {
+			idle++
+		} else {
+			idle = 0
+		}
+		logrus.Debugf("评论串滚动加载: 已加载 %d 条一级评论", len(comments.List))
+	}
+
+	expandReplies(page, threadReplyExpansions)
+	return readCommentsFromState(page, feedID)
+}
+
+// countComments 统计评论树中的评论数，含所有层级的回复
+func countComments(list []Comment) int {
+	n := 0
+	for i := range list {
+		n += 1 + countComments(list[i].SubComments)
+	}
+	return n
+}
+
+// capComments 按先序（一级评论在前、其回复紧随其后）保留至多 limit 条评论，返回是否有评论被裁掉。
+// 被裁掉部分回复的评论标记 RepliesTruncated。
+func capComments(list []Comment, limit int) ([]Comment, bool) {
+	kept, _, cut := capCommentTree(list, limit)
+	return kept, cut
+}
+
+func capCommentTree(list []Comment, limit int) ([]Comment, int, bool) {
+	var kept []Comment
+	used := 0
+	for _, c := range list {
+		if used >= limit {
+			return kept, used, true
+		}
+		used++
+
+		if len(c.SubComments) > 0 {
+			replies, n, cut := capCommentTree(c.SubComments, limit-used)
+			used += n
+			c.SubComments = replies
+			if cut {
+				c.RepliesTruncated = true
+				kept = append(kept, c)
+				return kept, used, true
+			}
+		}
+		kept = append(kept, c)
+	}
+	return kept, used, false
+}
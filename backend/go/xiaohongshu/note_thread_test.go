@@ -0,0 +1,51 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func threadFixture() []Comment {
+	return []Comment{
+		{ID: "c1", SubComments: []Comment{{ID: "r1"}, {ID: "r2"}}},
+		{ID: "c2"},
+		{ID: "c3", SubComments: []Comment{{ID: "r3"}}},
+	}
+}
+
+func TestCountComments(t *testing.T) {
+	require.Equal(t, 6, countComments(threadFixture()))
+	require.Equal(t, 0, countComments(nil))
+}
+
+func TestCapComments(t *testing.T) {
+	all, cut := capComments(threadFixture(), 10)
+	require.False(t, cut)
+	require.Equal(t, 6, countComments(all))
+	require.Nil(t, all[1].SubComments)
+
+	exact, cut := capComments(threadFixture(), 6)
+	require.False(t, cut)
+	require.Len(t, exact, 3)
+
+	// 在 c1 的回复中间截断：保留 c1 与 r1，c1 标记回复未全部返回
+	partial, cut := capComments(threadFixture(), 2)
+	require.True(t, cut)
+	require.Len(t, partial, 1)
+	require.Equal(t, []Comment{{ID: "r1"}}, partial[0].SubComments)
+	require.True(t, partial[0].RepliesTruncated)
+
+	// 在一级评论之间截断
+	top, cut := capComments(threadFixture(), 4)
+	require.True(t, cut)
+	require.Len(t, top, 2)
+	require.False(t, top[1].RepliesTruncated)
+}
+
+func TestValidateThreadComments(t *testing.T) {
+	require.NoError(t, ValidateThreadComments(0))
+	require.NoError(t, ValidateThreadComments(MaxThreadComments))
+	require.Error(t, ValidateThreadComments(-1))
+	require.Error(t, ValidateThreadComments(MaxThreadComments+1))
+}
@@ -0,0 +1,129 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 笔记类型
+const (
+	NoteTypeImage    = "image"
+	NoteTypeVideo    = "video"
+	NoteTypeCommerce = "commerce"
+	NoteTypeLive     = "live"
+)
+
+// NoteRef 笔记引用（笔记 ID + 访问令牌）
+type NoteRef struct {
+	FeedID    string `json:"feed_id"`
+	XsecToken string `json:"xsec_token"`
+}
+
+// NoteTypeResult 单篇笔记的类型检测结果
+type NoteTypeResult struct {
+	FeedID    string `json:"feed_id"`
+	Type      string `json:"type,omitempty"` // image | video | commerce | live
+	TypeLabel string `json:"type_label,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NoteTypeAction 快速检测笔记类型，只读取类型相关字段，不解析完整详情
+type NoteTypeAction struct {
+	page *rod.Page
+}
+
+func NewNoteTypeAction(page *rod.Page) *NoteTypeAction {
+	return &NoteTypeAction{page: page}
+}
+
+// GetNoteTypes 在同一页面上依次检测多篇笔记的类型，单篇失败不影响其他笔记
+func (a *NoteTypeAction) GetNoteTypes(ctx context.Context, refs []NoteRef) []NoteTypeResult {
+	results := make([]NoteTypeResult, 0, len(refs))
+
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			results = append(results, NoteTypeResult{FeedID: ref.FeedID, Error: ctx.Err().Error()})
+			continue
+		}
+
+		noteType, err := a.getNoteType(ctx, ref)
+		if err != nil {
+			logrus.Warnf("检测笔记 %s 类型失败: %v", ref.FeedID, err)
+			results = append(results, NoteTypeResult{FeedID: ref.FeedID, Error: err.Error()})
+			continue
+		}
+		results = append(results, NoteTypeResult{FeedID: ref.FeedID, Type: noteType, TypeLabel: EnumLabel(EnumNoteType, noteType)})
+	}
+
+	return results
+}
+
+// getNoteType 检测单篇笔记的类型，超时等页面错误只作为该笔记的错误返回，不影响批量中的其他笔记
+func (a *NoteTypeAction) getNoteType(ctx context.Context, ref NoteRef) (noteType string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("检测笔记类型失败: %v", r)
+		}
+	}()
+
+	page := a.page.Context(ctx).Timeout(30 * time.Second)
+
+	if err := page.Navigate(makeFeedDetailURL(ref.FeedID, ref.XsecToken)); err != nil {
+		return "", fmt.Errorf("打开笔记详情页失败: %w", err)
+	}
+	if err := page.Wait(rod.Eval(`() => window.__INITIAL_STATE__ !== undefined`)); err != nil {
+		return "", fmt.Errorf("等待笔记数据失败: %w", err)
+	}
+
+	if err := passContentGate(page); err != nil {
+		return "", err
+	}
+
+	// 只取类型相关的少量字段；直播卡片、商品卡片在 DOM 中也有标记
+	obj, err := page.Eval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		const detail = map && map[feedID];
+		if (!detail || !detail.note) {
+			return null;
+		}
+		const note = detail.note;
+		return {
+			type: note.type || "",
+			live: !!(note.liveInfo || note.live) || document.querySelector('.live-card, .note-live') !== null,
+			commerce: !!(note.goodsInfo || (note.goods && note.goods.length)) || document.querySelector('.goods-card, .note-goods') !== null,
+		};
+	}`, ref.FeedID)
+	if err != nil {
+		return "", fmt.Errorf("读取笔记类型失败: %w", err)
+	}
+
+	result := obj.Value
+	if result.Nil() {
+		return "", fmt.Errorf("feed %s not found in noteDetailMap", ref.FeedID)
+	}
+
+	return classifyNoteType(
+		result.Get("type").Str(),
+		result.Get("live").Bool(),
+		result.Get("commerce").Bool(),
+	), nil
+}
+
+// classifyNoteType 直播优先于商品，商品优先于普通图文/视频
+func classifyNoteType(rawType string, live, commerce bool) string {
+	switch {
+	case live:
+		return NoteTypeLive
+	case commerce:
+		return NoteTypeCommerce
+	case rawType == "video":
+		return NoteTypeVideo
+	default:
+		return NoteTypeImage
+	}
+}
@@ -0,0 +1,204 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 通知类别
+const (
+	NotificationLikes    = "likes"    // 赞和收藏
+	NotificationComments = "comments" // 评论和@
+	NotificationFollows  = "follows"  // 新增关注
+	NotificationMessages = "messages" // 私信
+	NotificationSystem   = "system"   // 系统通知
+)
+
+// 通知类别与设置页上开关所在行文本的对应关系
+var notificationCategoryLabels = map[string][]string{
+	NotificationLikes:    {"赞和收藏", "点赞", "收藏"},
+	NotificationComments: {"评论和@", "评论", "@我"},
+	NotificationFollows:  {"新增关注", "关注"},
+	NotificationMessages: {"私信", "消息"},
+	NotificationSystem:   {"系统通知", "官方通知"},
+}
+
+// 创作者中心通知设置入口可能使用的菜单名称
+var notificationMenuLabels = []string{"通知设置", "消息设置", "消息通知", "通知管理"}
+
+// NotificationCategories 返回所有可设置的通知类别
+func NotificationCategories() []string {
+	categories := make([]string, 0, len(notificationCategoryLabels))
+	for category := range notificationCategoryLabels {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// ValidateNotificationSettings 校验要修改的通知类别，至少指定一项
+func ValidateNotificationSettings(settings map[string]bool) error {
+	if len(settings) == 0 {
+		return fmt.Errorf("至少指定一个通知类别")
+	}
+	for category := range settings {
+		if _, ok := notificationCategoryLabels[category]; !ok {
+			return fmt.Errorf("无效的通知类别 %q，可选值: %s", category, strings.Join(NotificationCategories(), "|"))
+		}
+	}
+	return nil
+}
+
+// NotificationSettings 账号接收的通知类别设置
+type NotificationSettings struct {
+	Supported  bool              `json:"supported"`
+	Reason     string            `json:"reason,omitempty"` // 不支持时的原因
+	Categories map[string]bool   `json:"categories"`       // 类别 -> 是否接收，页面上没有的类别不返回
+	Labels     map[string]string `json:"labels,omitempty"` // 类别 -> 中文名称
+	Persisted  *bool             `json:"persisted,omitempty"`
+	Mismatched []string          `json:"mismatched,omitempty"` // 仅设置时返回：重新加载后与提交不一致的类别
+}
+
+// newNotificationSettings 创建 map 字段已初始化的结果，reason 为空表示支持。
+// 不支持时 categories 也是空对象而不是 null，与输出 schema 一致。
+func newNotificationSettings(reason string) *NotificationSettings {
+	return &NotificationSettings{
+		Supported:  reason == "",
+		Reason:     reason,
+		Categories: make(map[string]bool),
+		Labels:     make(map[string]string),
+	}
+}
+
+// NotificationSettingsAction 读取和修改通知设置
+type NotificationSettingsAction struct {
+	page *rod.Page
+}
+
+func NewNotificationSettingsAction(page *rod.Page) *NotificationSettingsAction {
+	pp := page.Timeout(60 * time.Second)
+	return &NotificationSettingsAction{page: pp}
+}
+
+// GetNotificationSettings 读取当前的通知设置。
+// 找不到设置页时不会报错，而是返回 Supported=false。
+func (a *NotificationSettingsAction) GetNotificationSettings(ctx context.Context) (*NotificationSettings, error) {
+	page := a.page.Context(ctx)
+
+	settings, _ := openNotificationSettings(page)
+	return settings, nil
+}
+
+// SetNotificationSettings 按 settings 切换对应类别的开关，未指定的类别保持不变；
+// 保存后重新加载页面读取，确认设置已生效。
+func (a *NotificationSettingsAction) SetNotificationSettings(ctx context.Context, settings map[string]bool) (*NotificationSettings, error) {
+	if err := ValidateNotificationSettings(settings); err != nil {
+		return nil, err
+	}
+
+	page := a.page.Context(ctx)
+
+	current, switches := openNotificationSettings(page)
+	if !current.Supported {
+		return current, nil
+	}
+
+	for category, enabled := range settings {
+		toggle, ok := switches[category]
+		if !ok {
+			return nil, fmt.Errorf("设置页上没有通知类别 %s", category)
+		}
+		if current.Categories[category] == enabled {
+			continue
+		}
+		toggle.MustClick()
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	// 部分页面切换开关即保存，没有保存按钮时忽略
+	if save, err := page.Timeout(5*time.Second).ElementR("button", "^(保存|确定|提交)$"); err == nil {
+		save.MustClick()
+		page.MustWaitStable()
+	}
+	time.Sleep(1 * time.Second)
+
+	result, _ := openNotificationSettings(page)
+	for category, enabled := range settings {
+		if got, ok := result.Categories[category]; !ok || got != enabled {
+			result.Mismatched = append(result.Mismatched, category)
+		}
+	}
+	sort.Strings(result.Mismatched)
+	persisted := result.Supported && len(result.Mismatched) == 0
+	result.Persisted = &persisted
+	if !persisted {
+		logrus.Warnf("通知设置未生效: %v", result.Mismatched)
+	}
+
+	return result, nil
+}
+
+// openNotificationSettings 打开创作者中心的通知设置，返回各类别的状态和对应开关
+func openNotificationSettings(page *rod.Page) (*NotificationSettings, map[string]*rod.Element) {
+	page.MustNavigate(urlOfCreatorHome).MustWaitIdle().MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if _, err := openCreatorSection(page, notificationMenuLabels); err != nil {
+		logrus.Infof("未找到通知设置入口: %v", err)
+		return newNotificationSettings("当前账号不支持在网页端修改通知设置，或创作者中心未提供该设置"), nil
+	}
+
+	toggles, err := page.Elements(`.d-switch, [class*="switch"], [role="switch"]`)
+	if err != nil || len(toggles) == 0 {
+		return newNotificationSettings("通知设置页面没有可用的开关"), nil
+	}
+
+	settings := newNotificationSettings("")
+	switches := make(map[string]*rod.Element)
+	for _, toggle := range toggles {
+		label, enabled := readSwitch(toggle)
+		category := matchNotificationCategory(label)
+		if category == "" {
+			continue
+		}
+		if _, exists := switches[category]; exists {
+			continue
+		}
+		switches[category] = toggle
+		settings.Categories[category] = enabled
+		settings.Labels[category] = EnumLabel(EnumNotification, category)
+	}
+
+	return settings, switches
+}
+
+// matchNotificationCategory 根据开关所在行的文本识别通知类别，无法识别时返回空。
+// 先匹配完整名称再匹配简称，避免“评论和@”被识别为其他类别。
+func matchNotificationCategory(label string) string {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return ""
+	}
+
+	for round := 0; round < 2; round++ {
+		for _, category := range NotificationCategories() {
+			labels := notificationCategoryLabels[category]
+			candidates := labels[:1]
+			if round == 1 {
+				candidates = labels[1:]
+			}
+			for _, l := range candidates {
+				if strings.Contains(label, l) {
+					return category
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchNotificationCategory(t *testing.T) {
+	require.Equal(t, NotificationComments, matchNotificationCategory("评论和@\n有人评论或@我时通知"))
+	require.Equal(t, NotificationFollows, matchNotificationCategory("新增关注"))
+	require.Equal(t, NotificationLikes, matchNotificationCategory("收到点赞时提醒"))
+	require.Equal(t, NotificationSystem, matchNotificationCategory("系统通知"))
+	require.Equal(t, NotificationMessages, matchNotificationCategory("私信"))
+	require.Empty(t, matchNotificationCategory("夜间免打扰"))
+	require.Empty(t, matchNotificationCategory(""))
+}
+
+func TestValidateNotificationSettings(t *testing.T) {
+	require.NoError(t, ValidateNotificationSettings(map[string]bool{NotificationLikes: false, NotificationSystem: true}))
+	require.Error(t, ValidateNotificationSettings(nil))
+	require.Error(t, ValidateNotificationSettings(map[string]bool{"ads": false}))
+}
+
+func TestNewNotificationSettingsUnsupported(t *testing.T) {
+	settings := newNotificationSettings("通知设置页面没有可用的开关")
+	require.False(t, settings.Supported)
+
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"categories":{}`)
+
+	require.True(t, newNotificationSettings("").Supported)
+}
@@ -0,0 +1,98 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// NoteOwnership 笔记作者与当前登录账号的比对结果
+type NoteOwnership struct {
+	FeedID        string `json:"feed_id"`
+	OwnerID       string `json:"owner_id"`
+	OwnerNickname string `json:"owner_nickname,omitempty"`
+	MyUserID      string `json:"my_user_id"`
+	IsMine        bool   `json:"is_mine"`
+}
+
+// OwnershipAction 检查笔记是否属于当前登录账号
+type OwnershipAction struct {
+	page *rod.Page
+}
+
+func NewOwnershipAction(page *rod.Page) *OwnershipAction {
+	pp := page.Timeout(60 * time.Second)
+	return &OwnershipAction{page: pp}
+}
+
+// CheckOwnership 打开笔记详情页，比对笔记作者与 __INITIAL_STATE__ 中的当前登录账号
+func (a *OwnershipAction) CheckOwnership(ctx context.Context, feedID, xsecToken string) (*NoteOwnership, error) {
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	raw := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		if (!state) return "";
+		const unwrap = v => (v && (v.value !== undefined ? v.value : v._value)) || v || {};
+		const map = state.note && state.note.noteDetailMap;
+		const note = map && map[feedID] && map[feedID].note;
+		const me = state.user && unwrap(state.user.userInfo);
+		return JSON.stringify({owner: (note && note.user) || null, me: me || null});
+	}`, feedID).String()
+	if raw == "" {
+		return nil, errors.ErrNoFeedDetail
+	}
+
+	result, err := parseOwnership(feedID, []byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("笔记 %s 作者 %s, 当前账号 %s, is_mine=%v", feedID, result.OwnerID, result.MyUserID, result.IsMine)
+	return result, nil
+}
+
+// parseOwnership 解析 {owner, me} 并比对用户 ID
+func parseOwnership(feedID string, raw []byte) (*NoteOwnership, error) {
+	var data struct {
+		Owner *User `json:"owner"`
+		Me    *struct {
+			UserID string `json:"userId"`
+		} `json:"me"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ownership data: %w", err)
+	}
+
+	if data.Owner == nil || data.Owner.UserID == "" {
+		return nil, errors.ErrNoFeedDetail
+	}
+	if data.Me == nil || data.Me.UserID == "" {
+		return nil, fmt.Errorf("无法获取当前登录账号，请先登录")
+	}
+
+	nickname := data.Owner.Nickname
+	if nickname == "" {
+		nickname = data.Owner.NickName
+	}
+
+	return &NoteOwnership{
+		FeedID:        feedID,
+		OwnerID:       data.Owner.UserID,
+		OwnerNickname: nickname,
+		MyUserID:      data.Me.UserID,
+		IsMine:        data.Owner.UserID == data.Me.UserID,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+func TestParseOwnership(t *testing.T) {
+	mine, err := parseOwnership("n1", []byte(`{"owner": {"userId": "u1", "nickName": "我"}, "me": {"userId": "u1"}}`))
+	require.NoError(t, err)
+	require.True(t, mine.IsMine)
+	require.Equal(t, "我", mine.OwnerNickname)
+
+	other, err := parseOwnership("n2", []byte(`{"owner": {"userId": "u2", "nickname": "别人"}, "me": {"userId": "u1"}}`))
+	require.NoError(t, err)
+	require.False(t, other.IsMine)
+	require.Equal(t, "u2", other.OwnerID)
+	require.Equal(t, "u1", other.MyUserID)
+
+	_, err = parseOwnership("n3", []byte(`{"owner": null, "me": {"userId": "u1"}}`))
+	require.ErrorIs(t, err, errors.ErrNoFeedDetail)
+
+	_, err = parseOwnership("n4", []byte(`{"owner": {"userId": "u2"}, "me": {}}`))
+	require.Error(t, err)
+}
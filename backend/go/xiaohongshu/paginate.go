@@ -0,0 +1,165 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 连续多少次滚动没有新内容时认为已经到底
+const maxIdleScrolls = 2
+
+// PaginateOption 自动翻页选项
+type PaginateOption struct {
+	AutoPaginate bool          // 是否自动滚动加载直到没有更多内容或达到上限
+	MaxItems     int           // 最多返回的条目数
+	Interval     time.Duration // 两次加载之间的间隔，用于控制访问频率
+}
+
+// PaginateResult 自动翻页结果
+type PaginateResult struct {
+	Feeds    []Feed
+	Complete bool // true 表示已加载到底；false 表示因达到 MaxItems 截断
+
+	ScrolledItems int  // 滚动过程中页面加载出的不同 feed 数，可能多于返回的条数
+	EndReached    bool // 页面出现了“到底了”等结束标记
+}
+
+// 页面底部表示没有更多内容的提示
+var endSentinelMarkers = []string{"THE END", "没有更多", "到底了", "暂时没有更多"}
+
+// scrollStats 一次滚动加载的统计
+type scrollStats struct {
+	scrolled   int  // 页面加载出的不同 feed 数
+	emitted    int  // 交给 emit 的 feed 数
+	endReached bool // 出现了结束标记
+	complete   bool // 已加载到底：出现结束标记，或连续多次滚动没有新内容
+}
+
+// collectFeedsByScroll 反复滚动页面并读取 feeds，直到没有新内容或达到 MaxItems。
+// read 每次返回页面当前已加载的全部 feeds（页面会在滚动后追加数据）。
+func collectFeedsByScroll(page *rod.Page, opt PaginateOption, read func(*rod.Page) ([]Feed, error)) (*PaginateResult, error) {
+	if !opt.AutoPaginate {
+		feeds, err := read(page)
+		if err != nil {
+			return nil, err
+		}
+		return &PaginateResult{Feeds: feeds, ScrolledItems: len(feeds), EndReached: hasEndSentinel(page)}, nil
+	}
+
+	var collected []Feed
+	stats, err := scrollFeeds(page, opt, read, func(feed Feed) error {
+		collected = append(collected, feed)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginateResult{
+		Feeds:         collected,
+		Complete:      stats.complete,
+		ScrolledItems: stats.scrolled,
+		EndReached:    stats.endReached,
+	}, nil
+}
+
+// streamFeedsByScroll 与 collectFeedsByScroll 相同地滚动加载，但每发现一条新 feed 就交给 emit，
+// 不在内存中保留已输出的数据（只记录 ID 用于去重）。返回是否已加载到底；
+// emit 返回错误时立即停止。
+func streamFeedsByScroll(page *rod.Page, opt PaginateOption, read func(*rod.Page) ([]Feed, error), emit func(Feed) error) (bool, error) {
+	stats, err := scrollFeeds(page, opt, read, emit)
+	if err != nil {
+		return false, err
+	}
+	return stats.complete, nil
+}
+
+// scrollFeeds 滚动加载的实现：达到 MaxItems、出现结束标记或连续多次没有新内容时停止
+func scrollFeeds(page *rod.Page, opt PaginateOption, read func(*rod.Page) ([]Feed, error), emit func(Feed) error) (*scrollStats, error) {
+	seen := make(map[string]bool)
+	stats := &scrollStats{}
+
+	emitNew := func(feeds []Feed) (int, error) {
+		added := 0
+		for _, feed := range feeds {
+			if feed.ID == "" || seen[feed.ID] {
+				continue
+			}
+			seen[feed.ID] = true
+			stats.scrolled++
+			if opt.MaxItems > 0 && stats.emitted >= opt.MaxItems {
+				continue
+			}
+			if err := emit(feed); err != nil {
+				return added, err
+			}
+			stats.emitted++
+			added++
+		}
+		return added, nil
+	}
+
+	feeds, err := read(page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := emitNew(feeds); err != nil {
+		return nil, err
+	}
+	stats.endReached = hasEndSentinel(page)
+
+	idle := 0
+	for stats.emitted < opt.MaxItems && idle < maxIdleScrolls && !stats.endReached {
+		page.MustEval(`() => window.scrollTo(0, document.body.scrollHeight)`)
+		time.Sleep(opt.Interval)
+
+		feeds, err := read(page)
+		if err != nil {
+			logrus.Warnf("自动翻页读取失败，返回已获取的 %d 条: %v", stats.emitted, err)
+			break
+		}
+
+		added, err := emitNew(feeds)
+		if err != nil {
+			return nil, err
+		}
+		if added == 0 {
+			idle++
+		} else {
+			idle = 0
+		}
+		stats.endReached = hasEndSentinel(page)
+		logrus.Debugf("自动翻页: 已获取 %d 条，页面已加载 %d 条", stats.emitted, stats.scrolled)
+	}
+
+	// 页面上还有因 MaxItems 没有返回的条目时不算加载到底
+	stats.complete = (stats.endReached || idle >= maxIdleScrolls) && stats.scrolled == stats.emitted
+	return stats, nil
+}
+
+// hasEndSentinel 页面底部是否显示了结束标记
+func hasEndSentinel(page *rod.Page) bool {
+	text := page.MustEval(`() => {
+		const nodes = document.querySelectorAll('.end-container, [class*="end-container"], [class*="no-more"], [class*="feeds-loading"]');
+		const texts = [];
+		for (const el of nodes) {
+			if (el.offsetParent !== null) texts.push(el.innerText.trim());
+		}
+		return texts.join("\n");
+	}`).Str()
+	return isEndSentinel(text)
+}
+
+// isEndSentinel 文本是否为结束标记
+func isEndSentinel(text string) bool {
+	text = strings.ToUpper(text)
+	for _, marker := range endSentinelMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsEndSentinel(t *testing.T) {
+	require.True(t, isEndSentinel("- THE END -"))
+	require.True(t, isEndSentinel("- the end -"))
+	require.True(t, isEndSentinel("没有更多内容了"))
+	require.True(t, isEndSentinel("加载中\n到底了"))
+	require.False(t, isEndSentinel("加载中"))
+	require.False(t, isEndSentinel(""))
+}
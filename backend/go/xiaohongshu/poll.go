@@ -0,0 +1,130 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 投票贴纸的限制
+const (
+	MinPollOptions        = 2
+	MaxPollOptions        = 6
+	MaxPollQuestionLength = 20
+	MaxPollOptionLength   = 15
+)
+
+// Poll 笔记中的投票贴纸
+type Poll struct {
+	Question string   `json:"question" jsonschema:"投票问题"`
+	Options  []string `json:"options" jsonschema:"投票选项，2-6个"`
+}
+
+// ValidatePoll 校验投票问题与选项的数量、长度，选项不能重复
+func ValidatePoll(poll Poll) error {
+	question := strings.TrimSpace(poll.Question)
+	if question == "" {
+		return fmt.Errorf("投票问题不能为空")
+	}
+	if n := utf8.RuneCountInString(question); n > MaxPollQuestionLength {
+		return fmt.Errorf("投票问题 %d 字，超过上限 %d 字", n, MaxPollQuestionLength)
+	}
+	if n := len(poll.Options); n < MinPollOptions || n > MaxPollOptions {
+		return fmt.Errorf("投票选项需要 %d-%d 个，实际 %d 个", MinPollOptions, MaxPollOptions, n)
+	}
+
+	seen := make(map[string]bool, len(poll.Options))
+	for i, option := range poll.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return fmt.Errorf("第 %d 个投票选项为空", i+1)
+		}
+		if n := utf8.RuneCountInString(option); n > MaxPollOptionLength {
+			return fmt.Errorf("投票选项 %q %d 字，超过上限 %d 字", option, n, MaxPollOptionLength)
+		}
+		if seen[option] {
+			return fmt.Errorf("投票选项重复: %s", option)
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+// addPoll 通过编辑器的互动贴纸添加投票，添加后确认投票卡片出现在编辑区。
+// 编辑器中没有投票入口时返回 ErrPollUnsupported。
+func addPoll(page *rod.Page, poll Poll) error {
+	entry, err := page.Timeout(5*time.Second).ElementR("button, div, span", "^(互动|贴纸|添加互动)$")
+	if err != nil {
+		return fmt.Errorf("%w: 没有找到互动贴纸入口", errors.ErrPollUnsupported)
+	}
+	entry.MustClick()
+	time.Sleep(500 * time.Millisecond)
+
+	vote, err := page.Timeout(5*time.Second).ElementR("button, div, span", "^投票$")
+	if err != nil {
+		return fmt.Errorf("%w: 互动贴纸中没有投票", errors.ErrPollUnsupported)
+	}
+	vote.MustClick()
+	time.Sleep(500 * time.Millisecond)
+
+	questionInput, err := page.Timeout(5 * time.Second).Element(`input[placeholder*="问题"], input[placeholder*="标题"], textarea[placeholder*="问题"]`)
+	if err != nil {
+		return fmt.Errorf("没有找到投票问题输入框: %w", err)
+	}
+	questionInput.MustSelectAllText().MustInput(strings.TrimSpace(poll.Question))
+
+	for i, option := range poll.Options {
+		inputs, err := page.Elements(`input[placeholder*="选项"]`)
+		if err != nil {
+			return fmt.Errorf("没有找到投票选项输入框: %w", err)
+		}
+		// 默认只有两个选项，其余通过“添加选项”补齐
+		if i >= len(inputs) {
+			more, err := page.Timeout(3*time.Second).ElementR("button, div, span", "添加选项")
+			if err != nil {
+				return fmt.Errorf("没有找到添加选项按钮: %w", err)
+			}
+			more.MustClick()
+			time.Sleep(300 * time.Millisecond)
+			if inputs, err = page.Elements(`input[placeholder*="选项"]`); err != nil || i >= len(inputs) {
+				return fmt.Errorf("添加第 %d 个投票选项失败", i+1)
+			}
+		}
+		inputs[i].MustSelectAllText().MustInput(strings.TrimSpace(option))
+	}
+
+	confirm, err := page.Timeout(5*time.Second).ElementR("button", "^(确定|完成|确认)$")
+	if err != nil {
+		return fmt.Errorf("没有找到投票确认按钮: %w", err)
+	}
+	confirm.MustClick()
+	time.Sleep(1 * time.Second)
+
+	card := page.MustEval(`() => {
+		const nodes = document.querySelectorAll('[class*="vote"], [class*="poll"]');
+		return Array.from(nodes).map(n => n.innerText.trim()).filter(Boolean).join("\n");
+	}`).String()
+	if missing := pollMissing(card, poll); len(missing) > 0 {
+		return fmt.Errorf("投票添加后未在编辑区找到: %s", strings.Join(missing, ", "))
+	}
+
+	logrus.Infof("已添加投票: %s (%d 个选项)", poll.Question, len(poll.Options))
+	return nil
+}
+
+// pollMissing 返回投票卡片文本中缺失的问题或选项，全部出现时返回 nil
+func pollMissing(card string, poll Poll) []string {
+	var missing []string
+	for _, text := range append([]string{poll.Question}, poll.Options...) {
+		text = strings.TrimSpace(text)
+		if !strings.Contains(card, text) {
+			missing = append(missing, text)
+		}
+	}
+	return missing
+}
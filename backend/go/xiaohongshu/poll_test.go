@@ -0,0 +1,28 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePoll(t *testing.T) {
+	require.NoError(t, ValidatePoll(Poll{Question: "周末去哪", Options: []string{"爬山", "看展"}}))
+
+	require.Error(t, ValidatePoll(Poll{Question: " ", Options: []string{"a", "b"}}))
+	require.Error(t, ValidatePoll(Poll{Question: strings.Repeat("问", MaxPollQuestionLength+1), Options: []string{"a", "b"}}))
+	require.Error(t, ValidatePoll(Poll{Question: "q", Options: []string{"a"}}))
+	require.Error(t, ValidatePoll(Poll{Question: "q", Options: []string{"1", "2", "3", "4", "5", "6", "7"}}))
+	require.Error(t, ValidatePoll(Poll{Question: "q", Options: []string{"a", " "}}))
+	require.Error(t, ValidatePoll(Poll{Question: "q", Options: []string{"a", " a "}}))
+	require.Error(t, ValidatePoll(Poll{Question: "q", Options: []string{"a", strings.Repeat("选", MaxPollOptionLength+1)}}))
+}
+
+func TestPollMissing(t *testing.T) {
+	poll := Poll{Question: "周末去哪", Options: []string{"爬山", "看展"}}
+
+	require.Empty(t, pollMissing("周末去哪\n爬山\n看展\n0人参与", poll))
+	require.Equal(t, []string{"看展"}, pollMissing("周末去哪\n爬山", poll))
+	require.Len(t, pollMissing("", poll), 3)
+}
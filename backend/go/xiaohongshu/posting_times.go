@@ -0,0 +1,175 @@
+package xiaohongshu
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 创作者中心可能展示粉丝活跃时间的菜单名称
+var postingTimesMenuLabels = []string{"粉丝数据", "数据中心", "数据分析", "数据看板"}
+
+// 推荐发布时间所在区块的标题关键字
+var postingTimesMarkers = []string{"最佳发布时间", "推荐发布时间", "粉丝活跃时间", "活跃时间段", "活跃时段"}
+
+var (
+	weekdayPattern    = regexp.MustCompile(`(周|星期)([一二三四五六日天])`)
+	hourRangePattern  = regexp.MustCompile(`(\d{1,2})(?::|：|点)?(\d{2})?\s*(?:-|~|–|至|到)\s*(\d{1,2})(?::|：|点)?(\d{2})?`)
+	singleHourPattern = regexp.MustCompile(`(\d{1,2})(?::|：)(\d{2})|(\d{1,2})点`)
+)
+
+var chineseWeekdays = map[string]time.Weekday{
+	"一": time.Monday, "二": time.Tuesday, "三": time.Wednesday, "四": time.Thursday,
+	"五": time.Friday, "六": time.Saturday, "日": time.Sunday, "天": time.Sunday,
+}
+
+// PostingTimeSlot 一个推荐发布时段，Weekday 为空表示每天
+type PostingTimeSlot struct {
+	Label     string    `json:"label"` // 页面原文
+	Weekday   string    `json:"weekday,omitempty"`
+	StartHour int       `json:"start_hour"`
+	EndHour   int       `json:"end_hour"` // 不含；单个时间点时为 StartHour+1，跨零点时大于 24
+	NextAt    time.Time `json:"next_at"`  // 该时段下一次开始的时间，可直接用作定时发布时间
+}
+
+// PostingTimes 推荐发布时间
+type PostingTimes struct {
+	Available bool              `json:"available"`
+	Reason    string            `json:"reason,omitempty"` // 不可用时的原因
+	Section   string            `json:"section,omitempty"`
+	Slots     []PostingTimeSlot `json:"slots"`
+}
+
+// PostingTimesAction 读取创作者中心根据粉丝活跃时间给出的发布时间建议
+type PostingTimesAction struct {
+	page *rod.Page
+}
+
+func NewPostingTimesAction(page *rod.Page) *PostingTimesAction {
+	pp := page.Timeout(60 * time.Second)
+	return &PostingTimesAction{page: pp}
+}
+
+// GetBestPostingTimes 打开创作者中心的数据页面，读取推荐发布时间。
+// 账号没有该功能时不会报错，而是返回 Available=false。
+func (a *PostingTimesAction) GetBestPostingTimes(ctx context.Context) (*PostingTimes, error) {
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(urlOfCreatorHome).MustWaitIdle().MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	result := &PostingTimes{Slots: []PostingTimeSlot{}}
+
+	section, err := openCreatorSection(page, postingTimesMenuLabels)
+	if err != nil {
+		result.Reason = "当前账号没有数据中心入口，无法获取推荐发布时间"
+		logrus.Infof("未找到数据中心入口: %v", err)
+		return result, nil
+	}
+	result.Section = section
+
+	texts := page.MustEval(`(markers) => {
+		const result = [];
+		document.querySelectorAll('div, section').forEach(el => {
+			const title = el.querySelector('[class*="title"], h3, h4');
+			if (!title || !markers.some(m => title.innerText.includes(m))) return;
+			el.querySelectorAll('[class*="item"], [class*="time"], li, span').forEach(item => {
+				const text = item.innerText.trim();
+				if (text && text.length <= 30) result.push(text);
+			});
+		});
+		return result;
+	}`, postingTimesMarkers).Arr()
+
+	lines := make([]string, 0, len(texts))
+	for _, t := range texts {
+		lines = append(lines, t.Str())
+	}
+
+	result.Slots = parsePostingTimes(lines, time.Now())
+	if len(result.Slots) == 0 {
+		result.Reason = "数据页面没有推荐发布时间，账号粉丝数可能不足"
+		return result, nil
+	}
+
+	result.Available = true
+	return result, nil
+}
+
+// parsePostingTimes 从页面文本中解析推荐时段，按原文去重并计算下一次开始时间
+func parsePostingTimes(lines []string, now time.Time) []PostingTimeSlot {
+	slots := []PostingTimeSlot{}
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+
+		slot, ok := parsePostingTimeSlot(line)
+		if !ok {
+			continue
+		}
+		seen[line] = true
+		slot.NextAt = nextSlotStart(now, slot)
+		slots = append(slots, slot)
+	}
+
+	return slots
+}
+
+// parsePostingTimeSlot 解析单条时段文本，如 "周三 20:00-22:00"、"每天 19点"
+func parsePostingTimeSlot(line string) (PostingTimeSlot, bool) {
+	slot := PostingTimeSlot{Label: line}
+
+	if m := weekdayPattern.FindStringSubmatch(line); m != nil {
+		slot.Weekday = chineseWeekdays[m[2]].String()
+	}
+
+	if m := hourRangePattern.FindStringSubmatch(line); m != nil {
+		slot.StartHour, _ = strconv.Atoi(m[1])
+		slot.EndHour, _ = strconv.Atoi(m[3])
+	} else if m := singleHourPattern.FindStringSubmatch(line); m != nil {
+		hour := m[1]
+		if hour == "" {
+			hour = m[3]
+		}
+		slot.StartHour, _ = strconv.Atoi(hour)
+		slot.EndHour = slot.StartHour + 1
+	} else {
+		return slot, false
+	}
+
+	if slot.StartHour > 23 || slot.EndHour > 24 {
+		return slot, false
+	}
+	if slot.EndHour <= slot.StartHour {
+		// 跨零点的时段，如 23:00-01:00
+		slot.EndHour += 24
+	}
+
+	return slot, true
+}
+
+// nextSlotStart 返回时段在 now 之后最近一次开始的时间
+func nextSlotStart(now time.Time, slot PostingTimeSlot) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), slot.StartHour, 0, 0, 0, now.Location())
+
+	for i := 0; i <= 7; i++ {
+		candidate := start.AddDate(0, 0, i)
+		if slot.Weekday != "" && candidate.Weekday().String() != slot.Weekday {
+			continue
+		}
+		if !candidate.Before(now) {
+			return candidate
+		}
+	}
+
+	return start.AddDate(0, 0, 7)
+}
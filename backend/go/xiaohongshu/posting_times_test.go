@@ -0,0 +1,36 @@
+package xiaohongshu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePostingTimes(t *testing.T) {
+	// 2024-05-01 是周三
+	now := time.Date(2024, 5, 1, 21, 0, 0, 0, time.UTC)
+
+	slots := parsePostingTimes([]string{
+		"周三 20:00-22:00",
+		"周三 20:00-22:00",
+		"每天 12点",
+		"23:00~01:00",
+		"粉丝活跃时间",
+	}, now)
+	require.Len(t, slots, 3)
+
+	require.Equal(t, "Wednesday", slots[0].Weekday)
+	require.Equal(t, 20, slots[0].StartHour)
+	require.Equal(t, 22, slots[0].EndHour)
+	require.Equal(t, time.Date(2024, 5, 8, 20, 0, 0, 0, time.UTC), slots[0].NextAt)
+
+	require.Empty(t, slots[1].Weekday)
+	require.Equal(t, 13, slots[1].EndHour)
+	require.Equal(t, time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC), slots[1].NextAt)
+
+	require.Equal(t, 25, slots[2].EndHour)
+	require.Equal(t, time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC), slots[2].NextAt)
+
+	require.Empty(t, parsePostingTimes([]string{"暂无数据"}, now))
+}
@@ -0,0 +1,202 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 小红书资料的长度限制
+const (
+	MaxNicknameLength = 24
+	MaxBioLength      = 100
+)
+
+// 头像支持的图片格式
+var avatarExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".webp": true}
+
+// 修改昵称过于频繁时页面提示中的关键字
+var nicknameCooldownMarkers = []string{"修改次数", "次数已用完", "天后再", "天后可", "暂不可修改", "冷却"}
+
+// ProfileUpdate 要修改的资料，字段为空表示保持不变
+type ProfileUpdate struct {
+	Nickname   string `json:"nickname,omitempty"`
+	Bio        string `json:"bio,omitempty"`
+	AvatarPath string `json:"avatar_path,omitempty"` // 本地图片绝对路径
+}
+
+// ProfileUpdateResult 修改资料的结果，修改后重新打开主页确认
+type ProfileUpdateResult struct {
+	Nickname      string   `json:"nickname"`
+	Bio           string   `json:"bio"`
+	AvatarUpdated bool     `json:"avatar_updated"`
+	Verified      bool     `json:"verified"`             // 重新加载后昵称与简介是否与提交一致
+	Mismatched    []string `json:"mismatched,omitempty"` // 不一致的字段
+}
+
+// ValidateProfileUpdate 校验资料修改，至少指定一项，长度不超过小红书的限制
+func ValidateProfileUpdate(update ProfileUpdate) error {
+	nickname := strings.TrimSpace(update.Nickname)
+	bio := strings.TrimSpace(update.Bio)
+
+	if nickname == "" && bio == "" && update.AvatarPath == "" {
+		return fmt.Errorf("至少指定 nickname、bio、avatar_path 中的一项")
+	}
+	if n := utf8.RuneCountInString(nickname); n > MaxNicknameLength {
+		return fmt.Errorf("昵称 %d 字，超过上限 %d 字", n, MaxNicknameLength)
+	}
+	if n := utf8.RuneCountInString(bio); n > MaxBioLength {
+		return fmt.Errorf("简介 %d 字，超过上限 %d 字", n, MaxBioLength)
+	}
+
+	if update.AvatarPath != "" {
+		if !filepath.IsAbs(update.AvatarPath) {
+			return fmt.Errorf("头像必须是本地图片的绝对路径: %s", update.AvatarPath)
+		}
+		if !avatarExtensions[strings.ToLower(filepath.Ext(update.AvatarPath))] {
+			return fmt.Errorf("不支持的头像格式 %s，可选: jpg|jpeg|png|webp", filepath.Ext(update.AvatarPath))
+		}
+		if _, err := os.Stat(update.AvatarPath); err != nil {
+			return fmt.Errorf("头像文件不存在或不可访问: %v", err)
+		}
+	}
+	return nil
+}
+
+// ProfileEditAction 修改当前账号的资料
+type ProfileEditAction struct {
+	page *rod.Page
+}
+
+func NewProfileEditAction(page *rod.Page) *ProfileEditAction {
+	pp := page.Timeout(60 * time.Second)
+	return &ProfileEditAction{page: pp}
+}
+
+// UpdateProfile 在个人主页的“编辑资料”中修改昵称、简介和头像并保存，
+// 随后重新打开主页读取昵称与简介，确认修改已生效。昵称处于修改冷却期时返回 ErrNicknameCooldown。
+func (a *ProfileEditAction) UpdateProfile(ctx context.Context, update ProfileUpdate) (*ProfileUpdateResult, error) {
+	if err := ValidateProfileUpdate(update); err != nil {
+		return nil, err
+	}
+	update.Nickname = strings.TrimSpace(update.Nickname)
+	update.Bio = strings.TrimSpace(update.Bio)
+
+	page := a.page.Context(ctx)
+
+	if err := NewNavigate(page).ToProfilePage(ctx); err != nil {
+		return nil, fmt.Errorf("failed to navigate to profile page: %w", err)
+	}
+	page.MustWaitStable()
+
+	edit, err := page.Timeout(10*time.Second).ElementR("button, div, span", "^编辑资料$")
+	if err != nil {
+		return nil, fmt.Errorf("没有找到编辑资料入口: %w", err)
+	}
+	edit.MustClick()
+	page.MustWaitStable()
+	time.Sleep(1 * time.Second)
+
+	if update.AvatarPath != "" {
+		input, err := page.Element(`input[type="file"]`)
+		if err != nil {
+			return nil, fmt.Errorf("没有找到头像上传入口: %w", err)
+		}
+		input.MustSetFiles(update.AvatarPath)
+		// 上传后可能弹出裁剪框
+		if confirm, err := page.Timeout(5*time.Second).ElementR("button", "^(确定|完成|确认)$"); err == nil {
+			confirm.MustClick()
+		}
+		page.MustWaitStable()
+	}
+
+	if update.Nickname != "" {
+		input, err := page.Element(`input[placeholder*="昵称"], [class*="nickname"] input`)
+		if err != nil {
+			return nil, fmt.Errorf("没有找到昵称输入框: %w", err)
+		}
+		if input.MustProperty("disabled").Bool() {
+			return nil, fmt.Errorf("%w: 昵称输入框不可编辑", errors.ErrNicknameCooldown)
+		}
+		input.MustSelectAllText().MustInput(update.Nickname)
+	}
+
+	if update.Bio != "" {
+		input, err := page.Element(`textarea[placeholder*="简介"], textarea[placeholder*="介绍"], [class*="desc"] textarea`)
+		if err != nil {
+			return nil, fmt.Errorf("没有找到简介输入框: %w", err)
+		}
+		input.MustSelectAllText().MustInput(update.Bio)
+	}
+
+	save, err := page.Timeout(10*time.Second).ElementR("button", "^(保存|确定|提交)$")
+	if err != nil {
+		return nil, fmt.Errorf("没有找到保存按钮: %w", err)
+	}
+	save.MustClick()
+	time.Sleep(1 * time.Second)
+
+	if msg := findNicknameCooldown(pageText(page)); update.Nickname != "" && msg != "" {
+		return nil, fmt.Errorf("%w: %s", errors.ErrNicknameCooldown, msg)
+	}
+	page.MustWaitStable()
+
+	profile, err := NewUserProfileAction(page).GetMyProfileViaSidebar(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("保存后读取资料失败: %w", err)
+	}
+
+	result := &ProfileUpdateResult{
+		Nickname:      profile.UserBasicInfo.Nickname,
+		Bio:           profile.UserBasicInfo.Desc,
+		AvatarUpdated: update.AvatarPath != "",
+	}
+	result.Mismatched = profileMismatches(update, result)
+	result.Verified = len(result.Mismatched) == 0
+	if !result.Verified {
+		logrus.Warnf("资料修改未生效: %v", result.Mismatched)
+	}
+
+	return result, nil
+}
+
+// pageText 读取页面上的提示文本（toast、弹窗等）
+func pageText(page *rod.Page) string {
+	return page.MustEval(`() => {
+		const nodes = document.querySelectorAll('[class*="toast"], [class*="message"], [class*="tip"], [role="alert"], [class*="error"]');
+		return Array.from(nodes).map(n => n.innerText.trim()).filter(Boolean).join("\n");
+	}`).String()
+}
+
+// findNicknameCooldown 在提示文本中查找昵称修改冷却提示，返回对应的一行
+func findNicknameCooldown(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		for _, marker := range nicknameCooldownMarkers {
+			if strings.Contains(line, marker) {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// profileMismatches 比较提交的资料与保存后读到的资料，返回不一致的字段
+func profileMismatches(update ProfileUpdate, got *ProfileUpdateResult) []string {
+	var mismatched []string
+	if update.Nickname != "" && strings.TrimSpace(got.Nickname) != update.Nickname {
+		mismatched = append(mismatched, "nickname")
+	}
+	if update.Bio != "" && strings.TrimSpace(got.Bio) != update.Bio {
+		mismatched = append(mismatched, "bio")
+	}
+	return mismatched
+}
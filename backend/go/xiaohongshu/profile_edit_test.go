@@ -0,0 +1,38 @@
+package xiaohongshu
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProfileUpdate(t *testing.T) {
+	avatar := filepath.Join(t.TempDir(), "avatar.png")
+	require.NoError(t, os.WriteFile(avatar, []byte("png"), 0644))
+
+	require.NoError(t, ValidateProfileUpdate(ProfileUpdate{Nickname: "小红薯"}))
+	require.NoError(t, ValidateProfileUpdate(ProfileUpdate{Bio: strings.Repeat("字", MaxBioLength)}))
+	require.NoError(t, ValidateProfileUpdate(ProfileUpdate{AvatarPath: avatar}))
+
+	require.Error(t, ValidateProfileUpdate(ProfileUpdate{Nickname: "  "}))
+	require.Error(t, ValidateProfileUpdate(ProfileUpdate{Nickname: strings.Repeat("名", MaxNicknameLength+1)}))
+	require.Error(t, ValidateProfileUpdate(ProfileUpdate{Bio: strings.Repeat("字", MaxBioLength+1)}))
+	require.Error(t, ValidateProfileUpdate(ProfileUpdate{AvatarPath: "avatar.png"}))
+	require.Error(t, ValidateProfileUpdate(ProfileUpdate{AvatarPath: filepath.Join(t.TempDir(), "avatar.gif")}))
+	require.Error(t, ValidateProfileUpdate(ProfileUpdate{AvatarPath: filepath.Join(t.TempDir(), "missing.jpg")}))
+}
+
+func TestFindNicknameCooldown(t *testing.T) {
+	require.Equal(t, "昵称修改次数已用完，30天后再试", findNicknameCooldown("保存成功\n 昵称修改次数已用完，30天后再试 "))
+	require.Empty(t, findNicknameCooldown("保存成功"))
+}
+
+func TestProfileMismatches(t *testing.T) {
+	update := ProfileUpdate{Nickname: "新昵称", Bio: "新简介"}
+	require.Empty(t, profileMismatches(update, &ProfileUpdateResult{Nickname: "新昵称", Bio: "新简介 "}))
+	require.Equal(t, []string{"nickname"}, profileMismatches(update, &ProfileUpdateResult{Nickname: "旧昵称", Bio: "新简介"}))
+	require.Empty(t, profileMismatches(ProfileUpdate{AvatarPath: "/a.png"}, &ProfileUpdateResult{Nickname: "x"}))
+}
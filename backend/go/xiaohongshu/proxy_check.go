@@ -0,0 +1,130 @@
+package xiaohongshu
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	urlOfExplore = `https://www.xiaohongshu.com/explore`
+
+	// 用于获取代理出口 IP 的公共服务
+	urlOfExitIP = `https://api.ipify.org?format=json`
+)
+
+// 代理检测状态
+const (
+	ProxyStatusOK          = "ok"
+	ProxyStatusBlocked     = "blocked"
+	ProxyStatusUnreachable = "unreachable"
+)
+
+// 页面被风控拦截时常见的跳转地址和提示文本。
+// 只匹配风控拦截页特有的文本：未登录的探索页总会弹出包含“获取验证码”的登录框，
+// 因此不能使用“验证码”“请稍后再试”这类通用文本。
+var (
+	blockedURLMarkers  = []string{"captcha", "website-login/error", "/404"}
+	blockedTextMarkers = []string{"安全限制", "访问频繁", "IP存在风险"}
+)
+
+// ProxyCheckResult 通过代理访问小红书的检测结果
+type ProxyCheckResult struct {
+	Status     string `json:"status"` // ok|blocked|unreachable
+	LatencyMs  int64  `json:"latency_ms"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	FinalURL   string `json:"final_url,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	ExitIP     string `json:"exit_ip,omitempty"`
+}
+
+// ProxyCheckAction 在通过代理启动的页面上检测小红书是否可访问
+type ProxyCheckAction struct {
+	page *rod.Page
+}
+
+func NewProxyCheckAction(page *rod.Page) *ProxyCheckAction {
+	return &ProxyCheckAction{page: page}
+}
+
+// Check 打开小红书探索页并判断是否被拦截，随后尝试获取出口 IP（失败不影响结果）
+func (a *ProxyCheckAction) Check(ctx context.Context) *ProxyCheckResult {
+	result := &ProxyCheckResult{}
+	page := a.page.Context(ctx).Timeout(30 * time.Second)
+
+	start := time.Now()
+	if err := page.Navigate(urlOfExplore); err != nil {
+		result.Status = ProxyStatusUnreachable
+		result.Reason = err.Error()
+		return result
+	}
+	if err := page.WaitLoad(); err != nil {
+		result.Status = ProxyStatusUnreachable
+		result.Reason = err.Error()
+		return result
+	}
+	result.LatencyMs = time.Since(start).Milliseconds()
+
+	info := page.MustEval(`() => {
+		const nav = performance.getEntriesByType('navigation')[0];
+		return {
+			status: nav && nav.responseStatus ? nav.responseStatus : 0,
+			url: location.href,
+			text: document.body ? document.body.innerText.slice(0, 2000) : "",
+		};
+	}`)
+	result.HTTPStatus = info.Get("status").Int()
+	result.FinalURL = info.Get("url").Str()
+
+	result.Status, result.Reason = classifyProxyPage(result.HTTPStatus, result.FinalURL, info.Get("text").Str())
+
+	result.ExitIP = a.exitIP(ctx)
+	return result
+}
+
+// exitIP 通过公共服务读取出口 IP，获取不到时返回空字符串
+func (a *ProxyCheckAction) exitIP(ctx context.Context) string {
+	page := a.page.Context(ctx).Timeout(10 * time.Second)
+
+	if err := page.Navigate(urlOfExitIP); err != nil {
+		logrus.Debugf("获取出口 IP 失败: %v", err)
+		return ""
+	}
+	if err := page.WaitLoad(); err != nil {
+		return ""
+	}
+
+	ip, err := page.Eval(`() => {
+		try { return JSON.parse(document.body.innerText).ip || ""; } catch (e) { return ""; }
+	}`)
+	if err != nil {
+		return ""
+	}
+	return ip.Value.Str()
+}
+
+// classifyProxyPage 根据状态码、最终地址和页面文本判断是否被拦截
+func classifyProxyPage(httpStatus int, finalURL, text string) (string, string) {
+	if httpStatus == 403 || httpStatus == 429 || httpStatus == 461 || httpStatus == 471 {
+		return ProxyStatusBlocked, "HTTP 状态码异常"
+	}
+	if httpStatus >= 500 {
+		return ProxyStatusUnreachable, "服务端错误"
+	}
+
+	for _, marker := range blockedURLMarkers {
+		if strings.Contains(finalURL, marker) {
+			return ProxyStatusBlocked, "跳转到拦截页: " + finalURL
+		}
+	}
+	for _, marker := range blockedTextMarkers {
+		if strings.Contains(text, marker) {
+			return ProxyStatusBlocked, "页面提示: " + marker
+		}
+	}
+
+	return ProxyStatusOK, ""
+}
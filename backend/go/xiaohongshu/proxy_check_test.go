@@ -0,0 +1,33 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyProxyPage(t *testing.T) {
+	status, _ := classifyProxyPage(200, "https://www.xiaohongshu.com/explore", "发现 推荐")
+	require.Equal(t, ProxyStatusOK, status)
+
+	status, _ = classifyProxyPage(461, "https://www.xiaohongshu.com/explore", "")
+	require.Equal(t, ProxyStatusBlocked, status)
+
+	status, _ = classifyProxyPage(200, "https://www.xiaohongshu.com/website-login/captcha?redirectPath=x", "")
+	require.Equal(t, ProxyStatusBlocked, status)
+
+	status, reason := classifyProxyPage(200, "https://www.xiaohongshu.com/explore", "当前访问频繁，请稍后再试")
+	require.Equal(t, ProxyStatusBlocked, status)
+	require.Contains(t, reason, "访问频繁")
+
+	// 未登录时探索页弹出的登录框不代表被拦截
+	loginModal := "发现 推荐\n登录后推荐更懂你的笔记\n手机号登录\n获取验证码\n请稍后再试"
+	status, _ = classifyProxyPage(200, "https://www.xiaohongshu.com/explore", loginModal)
+	require.Equal(t, ProxyStatusOK, status)
+
+	status, _ = classifyProxyPage(200, "https://www.xiaohongshu.com/explore", "安全限制\nIP存在风险，请切换可靠网络环境后重试")
+	require.Equal(t, ProxyStatusBlocked, status)
+
+	status, _ = classifyProxyPage(502, "https://www.xiaohongshu.com/explore", "")
+	require.Equal(t, ProxyStatusUnreachable, status)
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"math/rand"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
 // PublishImageContent 发布图文内容
@@ -21,6 +23,10 @@ type PublishImageContent struct {
 	Content    string
 	Tags       []string
 	ImagePaths []string
+	Visibility string // 可见范围，如 仅自己可见；为空使用平台默认（公开可见）
+	Poll       *Poll  // 投票贴纸，为空不添加
+	// Permissions 评论等权限开关，未指定的项保持编辑器默认
+	Permissions PublishPermissions
 }
 
 type PublishAction struct {
@@ -50,30 +56,36 @@ func NewPublishImageAction(page *rod.Page) (*PublishAction, error) {
 	}, nil
 }
 
-func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) error {
+// Publish 上传图片并发布，返回上传阶段的重试情况；上传失败时同样返回已进行的重试
+func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) (*UploadReport, error) {
 	if len(content.ImagePaths) == 0 {
-		return errors.New("图片不能为空")
+		return nil, errors.New("图片不能为空")
+	}
+	if content.Poll != nil {
+		if err := ValidatePoll(*content.Poll); err != nil {
+			return nil, err
+		}
+	}
+	if err := ValidatePublishPermissions(NoteTypeImage, content.Permissions); err != nil {
+		return nil, err
 	}
 
 	page := p.page.Context(ctx)
 
-	if err := uploadImages(page, content.ImagePaths); err != nil {
-		return errors.Wrap(err, "小红书上传图片失败")
+	upload, err := uploadImages(page, content.ImagePaths)
+	if err != nil {
+		return upload, errors.Wrapf(err, "小红书上传图片失败（已重试 %d 次）", upload.Retries)
 	}
 
-	tags := content.Tags
-	if len(tags) >= 10 {
-		logrus.Warnf("标签数量超过10，截取前10个标签")
-		tags = tags[:10]
-	}
+	tags := limitTags(content.Tags)
 
 	logrus.Infof("发布内容: title=%s, images=%v, tags=%v", content.Title, len(content.ImagePaths), tags)
 
-	if err := submitPublish(page, content.Title, content.Content, tags); err != nil {
-		return errors.Wrap(err, "小红书发布失败")
+	if err := submitPublish(page, content.Title, content.Content, tags, content.Visibility, content.Poll, content.Permissions); err != nil {
+		return upload, errors.Wrap(err, "小红书发布失败")
 	}
 
-	return nil
+	return upload, nil
 }
 
 func removePopCover(page *rod.Page) {
@@ -183,9 +195,7 @@ func isElementBlocked(elem *rod.Element) (bool, error) {
 	return result.Value.Bool(), nil
 }
 
-func uploadImages(page *rod.Page, imagesPaths []string) error {
-	pp := page.Timeout(30 * time.Second)
-
+func uploadImages(page *rod.Page, imagesPaths []string) (*UploadReport, error) {
 	// 验证文件路径有效性
 	validPaths := make([]string, 0, len(imagesPaths))
 	for _, path := range imagesPaths {
@@ -198,48 +208,70 @@ func uploadImages(page *rod.Page, imagesPaths []string) error {
 		logrus.Infof("获取有效图片：%s", path)
 	}
 
-	// 等待上传输入框出现
-	uploadInput := pp.MustElement(".upload-input")
+	timeout := configs.GetUploadTimeout(defaultImageUploadTimeout)
+	report := &UploadReport{Files: len(validPaths)}
+	pending := validPaths
+	for {
+		report.Attempts++
+
+		// 等待上传输入框出现
+		uploadInput, err := findFileInput(page.Timeout(30 * time.Second))
+		if err != nil {
+			return report, err
+		}
 
-	// 上传多个文件
-	uploadInput.MustSetFiles(validPaths...)
+		// 上传多个文件
+		uploadInput.MustSetFiles(pending...)
 
-	// 等待并验证上传完成
-	return waitForUploadComplete(pp, len(validPaths))
+		// 等待并验证上传完成
+		retryFrom, err := waitForUploadComplete(page, len(validPaths), timeout)
+		if err == nil {
+			return report, nil
+		}
+		if report.Retries >= configs.GetUploadRetries() {
+			return report, err
+		}
+
+		// 只重新上传失败的图片；删除其后已上传的图片一并重传，保持图片顺序
+		if rerr := removeUploadPreviews(page, retryFrom); rerr != nil {
+			return report, errors.Wrapf(err, "无法重试: %v", rerr)
+		}
+		pending = validPaths[retryFrom:]
+		report.Retries++
+		report.RetriedFiles = append(report.RetriedFiles, pending...)
+		logrus.Warnf("图片上传未完成: %v，第 %d 次重新上传第 %d 张起的 %d 张图片", err, report.Retries, retryFrom+1, len(pending))
+	}
 }
 
-// waitForUploadComplete 等待并验证上传完成
-func waitForUploadComplete(page *rod.Page, expectedCount int) error {
-	maxWaitTime := 60 * time.Second
+// waitForUploadComplete 等待并验证上传完成，失败时返回需要重新上传的第一张图片的下标
+func waitForUploadComplete(page *rod.Page, expectedCount int, maxWaitTime time.Duration) (int, error) {
 	checkInterval := 500 * time.Millisecond
 	start := time.Now()
 
-	slog.Info("开始等待图片上传完成", "expected_count", expectedCount)
+	slog.Info("开始等待图片上传完成", "expected_count", expectedCount, "timeout", maxWaitTime)
 
+	retryFrom := 0
 	for time.Since(start) < maxWaitTime {
-		// 使用具体的pr类名检查已上传的图片
-		uploadedImages, err := page.Elements(".img-preview-area .pr")
-
-		slog.Info("uploadedImages", "uploadedImages", uploadedImages)
+		states := readUploadPreviews(page)
+		slog.Info("检测到已上传图片", "current_count", len(states), "expected_count", expectedCount)
 
-		if err == nil {
-			currentCount := len(uploadedImages)
-			slog.Info("检测到已上传图片", "current_count", currentCount, "expected_count", expectedCount)
-			if currentCount >= expectedCount {
-				slog.Info("所有图片上传完成", "count", currentCount)
-				return nil
-			}
-		} else {
-			slog.Debug("未找到已上传图片元素")
+		done, failed, from := checkUploadPreviews(states, expectedCount)
+		if done {
+			slog.Info("所有图片上传完成", "count", len(states))
+			return 0, nil
+		}
+		retryFrom = from
+		if failed {
+			return retryFrom, errors.Errorf("第 %d 张图片上传失败", retryFrom+1)
 		}
 
 		time.Sleep(checkInterval)
 	}
 
-	return errors.New("上传超时，请检查网络连接和图片大小")
+	return retryFrom, errors.New("上传超时，请检查网络连接和图片大小")
 }
 
-func submitPublish(page *rod.Page, title, content string, tags []string) error {
+func submitPublish(page *rod.Page, title, content string, tags []string, visibility string, poll *Poll, permissions PublishPermissions) error {
 
 	titleElem := page.MustElement("div.d-input input")
 	titleElem.MustInput(title)
@@ -247,7 +279,9 @@ func submitPublish(page *rod.Page, title, content string, tags []string) error {
 	time.Sleep(1 * time.Second)
 
 	if contentElem, ok := getContentElement(page); ok {
-		contentElem.MustInput(content)
+		if err := inputBody(contentElem, content); err != nil {
+			return err
+		}
 
 		inputTags(contentElem, tags)
 
@@ -257,6 +291,22 @@ func submitPublish(page *rod.Page, title, content string, tags []string) error {
 
 	time.Sleep(1 * time.Second)
 
+	if poll != nil {
+		if err := addPoll(page, *poll); err != nil {
+			return err
+		}
+	}
+
+	if visibility != "" {
+		if err := setVisibility(page, visibility); err != nil {
+			return err
+		}
+	}
+
+	if err := setPublishPermissions(page, permissions); err != nil {
+		return err
+	}
+
 	submitButton := page.MustElement("div.submit div.d-button-content")
 	submitButton.MustClick()
 
@@ -265,6 +315,25 @@ func submitPublish(page *rod.Page, title, content string, tags []string) error {
 	return nil
 }
 
+// setVisibility 在发布页的可见范围下拉框中选择指定选项
+func setVisibility(page *rod.Page, visibility string) error {
+	selector, err := page.Timeout(5 * time.Second).Element(".permission-card-select, .permission-select")
+	if err != nil {
+		return errors.Wrap(err, "没有找到可见范围设置")
+	}
+	selector.MustClick()
+	time.Sleep(500 * time.Millisecond)
+
+	option, err := page.Timeout(5*time.Second).ElementR(".d-options .d-option", "^"+regexp.QuoteMeta(visibility)+"$")
+	if err != nil {
+		return errors.Errorf("没有找到可见范围选项 - %s", visibility)
+	}
+	option.MustClick()
+	time.Sleep(500 * time.Millisecond)
+
+	return nil
+}
+
 // 查找内容输入框 - 使用Race方法处理两种样式
 func getContentElement(page *rod.Page) (*rod.Element, bool) {
 	var foundElement *rod.Element
@@ -291,6 +360,15 @@ func getContentElement(page *rod.Page) (*rod.Element, bool) {
 	return nil, false
 }
 
+// limitTags 编辑器最多添加 EditorMaxTags 个标签，超出时截取前 EditorMaxTags 个
+func limitTags(tags []string) []string {
+	if len(tags) > EditorMaxTags {
+		logrus.Warnf("标签数量超过%d，截取前%d个标签", EditorMaxTags, EditorMaxTags)
+		return tags[:EditorMaxTags]
+	}
+	return tags
+}
+
 func inputTags(contentElem *rod.Element, tags []string) {
 	if len(tags) == 0 {
 		return
@@ -0,0 +1,177 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 发布时可设置的权限开关
+const (
+	PermissionComments = "comments_enabled" // 允许评论
+	PermissionDuet     = "allow_duet"       // 允许合拍
+	PermissionCopy     = "allow_copy"       // 允许复制正文
+)
+
+// 各权限在编辑器中开关所在行的文本，开关打开表示允许
+var publishPermissionLabels = map[string][]string{
+	PermissionComments: {"允许评论", "开启评论"},
+	PermissionDuet:     {"允许合拍"},
+	PermissionCopy:     {"允许正文复制", "允许复制"},
+}
+
+// 各笔记类型支持设置的权限
+var publishPermissionsByType = map[string][]string{
+	NoteTypeImage: {PermissionComments, PermissionCopy},
+	NoteTypeVideo: {PermissionComments, PermissionDuet, PermissionCopy},
+}
+
+// 编辑器中收起权限开关的入口可能使用的名称
+var publishSettingsPanelLabels = []string{"更多设置", "高级设置", "高级选项"}
+
+// PublishPermissions 发布时设置的权限开关，为 null 的项保持编辑器默认
+type PublishPermissions struct {
+	CommentsEnabled *bool `json:"comments_enabled,omitempty" jsonschema:"是否允许评论（可选），false 为关闭评论，不指定保持默认；指定后发布完成会读取线上笔记确认，结果见 verification"`
+	AllowDuet       *bool `json:"allow_duet,omitempty" jsonschema:"是否允许合拍（可选，仅视频笔记），不指定保持默认"`
+	AllowCopy       *bool `json:"allow_copy,omitempty" jsonschema:"是否允许复制正文（可选），不指定保持默认"`
+}
+
+// IsEmpty 没有指定任何权限时返回 true
+func (p PublishPermissions) IsEmpty() bool {
+	return len(p.toggles()) == 0
+}
+
+// toggles 返回指定了的权限及目标状态
+func (p PublishPermissions) toggles() map[string]bool {
+	toggles := make(map[string]bool)
+	for name, v := range map[string]*bool{
+		PermissionComments: p.CommentsEnabled,
+		PermissionDuet:     p.AllowDuet,
+		PermissionCopy:     p.AllowCopy,
+	} {
+		if v != nil {
+			toggles[name] = *v
+		}
+	}
+	return toggles
+}
+
+// ValidatePublishPermissions 校验笔记类型是否支持指定的权限，不支持时返回 ErrPermissionUnsupported
+func ValidatePublishPermissions(noteType string, p PublishPermissions) error {
+	supported := publishPermissionsByType[noteType]
+	names := make([]string, 0, 3)
+	for name := range p.toggles() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !slices.Contains(supported, name) {
+			return fmt.Errorf("%w: %s笔记不支持设置 %s，可设置: %s", errors.ErrPermissionUnsupported,
+				EnumLabel(EnumNoteType, noteType), name, strings.Join(supported, "|"))
+		}
+	}
+	return nil
+}
+
+// setPublishPermissions 在编辑器中切换指定的权限开关，切换后重新读取确认状态。
+// 编辑器中没有对应开关时返回 ErrPermissionUnsupported，此时尚未提交发布。
+func setPublishPermissions(page *rod.Page, p PublishPermissions) error {
+	toggles := p.toggles()
+	if len(toggles) == 0 {
+		return nil
+	}
+
+	// 权限开关可能收在折叠面板中，没有折叠入口时忽略
+	for _, label := range publishSettingsPanelLabels {
+		if entry, err := page.Timeout(2*time.Second).ElementR("div, span, button", "^"+label+"$"); err == nil {
+			entry.MustClick()
+			time.Sleep(500 * time.Millisecond)
+			break
+		}
+	}
+
+	switches := findPermissionSwitches(page)
+	for name, want := range toggles {
+		toggle, ok := switches[name]
+		if !ok {
+			return fmt.Errorf("%w: 编辑器中没有找到「%s」开关", errors.ErrPermissionUnsupported, publishPermissionLabels[name][0])
+		}
+		if _, enabled := readSwitch(toggle); enabled == want {
+			continue
+		}
+		toggle.MustClick()
+		time.Sleep(500 * time.Millisecond)
+		if _, enabled := readSwitch(toggle); enabled != want {
+			return fmt.Errorf("切换「%s」开关失败", publishPermissionLabels[name][0])
+		}
+		logrus.Infof("发布权限 %s 已设置为 %v", name, want)
+	}
+	return nil
+}
+
+// findPermissionSwitches 读取编辑器中的开关，按所在行文本识别对应的权限
+func findPermissionSwitches(page *rod.Page) map[string]*rod.Element {
+	switches := make(map[string]*rod.Element)
+	toggles, err := page.Elements(`.d-switch, [class*="switch"], [role="switch"]`)
+	if err != nil {
+		return switches
+	}
+	for _, toggle := range toggles {
+		label, _ := readSwitch(toggle)
+		name := matchPublishPermission(label)
+		if name == "" {
+			continue
+		}
+		if _, exists := switches[name]; !exists {
+			switches[name] = toggle
+		}
+	}
+	return switches
+}
+
+// matchPublishPermission 根据开关所在行的文本识别权限，无法识别时返回空
+func matchPublishPermission(label string) string {
+	for _, name := range []string{PermissionComments, PermissionDuet, PermissionCopy} {
+		for _, l := range publishPermissionLabels[name] {
+			if strings.Contains(label, l) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// readSwitch 返回开关所在行的文本与是否打开
+func readSwitch(toggle *rod.Element) (string, bool) {
+	state := toggle.MustEval(`() => {
+		const row = this.closest('[class*="item"], [class*="row"], li') || this.parentElement;
+		return {
+			label: row ? row.innerText.trim() : "",
+			hasAria: this.hasAttribute('aria-checked'),
+			ariaChecked: this.getAttribute('aria-checked') || "",
+			cls: typeof this.className === "string" ? this.className : "",
+		};
+	}`)
+	enabled := switchEnabled(state.Get("ariaChecked").Str(), state.Get("hasAria").Bool(), state.Get("cls").Str())
+	return state.Get("label").Str(), enabled
+}
+
+// switchOnClass 表示开关打开的 class：完整的 checked/active/on，或以 -checked 等结尾的 class，
+// 不会匹配 unchecked、d-switch-unchecked
+var switchOnClass = regexp.MustCompile(`(^|[\s-])(checked|active|on)(\s|$)`)
+
+// switchEnabled 判断开关是否打开：有 aria-checked 时以它为准，否则看 class
+func switchEnabled(ariaChecked string, hasAria bool, cls string) bool {
+	if hasAria {
+		return ariaChecked == "true"
+	}
+	return switchOnClass.MatchString(cls)
+}
@@ -0,0 +1,61 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+func TestValidatePublishPermissions(t *testing.T) {
+	off, on := false, true
+
+	require.NoError(t, ValidatePublishPermissions(NoteTypeImage, PublishPermissions{}))
+	require.NoError(t, ValidatePublishPermissions(NoteTypeImage, PublishPermissions{CommentsEnabled: &off, AllowCopy: &on}))
+	require.NoError(t, ValidatePublishPermissions(NoteTypeVideo, PublishPermissions{CommentsEnabled: &off, AllowDuet: &off}))
+
+	err := ValidatePublishPermissions(NoteTypeImage, PublishPermissions{AllowDuet: &on})
+	require.ErrorIs(t, err, errors.ErrPermissionUnsupported)
+	require.Contains(t, err.Error(), PermissionDuet)
+}
+
+func TestPublishPermissionsIsEmpty(t *testing.T) {
+	off := false
+	require.True(t, PublishPermissions{}.IsEmpty())
+	require.False(t, PublishPermissions{CommentsEnabled: &off}.IsEmpty())
+}
+
+func TestMatchPublishPermission(t *testing.T) {
+	require.Equal(t, PermissionComments, matchPublishPermission("允许评论\n关闭后其他人无法评论"))
+	require.Equal(t, PermissionDuet, matchPublishPermission("允许合拍"))
+	require.Equal(t, PermissionCopy, matchPublishPermission("允许正文复制"))
+	require.Empty(t, matchPublishPermission("定时发布"))
+}
+
+func TestSwitchEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		ariaChecked string
+		hasAria     bool
+		cls         string
+		want        bool
+	}{
+		{name: "aria-checked 为 true", ariaChecked: "true", hasAria: true, cls: "d-switch", want: true},
+		{name: "aria-checked 优先于 class", ariaChecked: "false", hasAria: true, cls: "d-switch checked", want: false},
+		{name: "checked", cls: "d-switch checked", want: true},
+		{name: "带前缀的 checked", cls: "d-switch d-switch-checked", want: true},
+		{name: "active", cls: "switch active", want: true},
+		{name: "on", cls: "switch-on", want: true},
+		{name: "unchecked", cls: "d-switch unchecked", want: false},
+		{name: "带前缀的 unchecked", cls: "d-switch d-switch-unchecked", want: false},
+		{name: "inactive", cls: "switch inactive", want: false},
+		{name: "包含 on 的单词", cls: "switch-container option", want: false},
+		{name: "没有 class", cls: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, switchEnabled(tt.ariaChecked, tt.hasAria, tt.cls))
+		})
+	}
+}
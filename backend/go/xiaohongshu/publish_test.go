@@ -23,10 +23,19 @@ func TestPublish(t *testing.T) {
 	action, err := NewPublishImageAction(page)
 	require.NoError(t, err)
 
-	err = action.Publish(context.Background(), PublishImageContent{
+	_, err = action.Publish(context.Background(), PublishImageContent{
 		Title:      "Hello World",
 		Content:    "Hello World",
 		ImagePaths: []string{"/tmp/1.jpg"},
 	})
 	assert.NoError(t, err)
 }
+
+func TestLimitTags(t *testing.T) {
+	tags := make([]string, EditorMaxTags+2)
+	for i := range tags {
+		tags[i] = string(rune('a' + i))
+	}
+	require.Equal(t, tags[:EditorMaxTags], limitTags(tags))
+	require.Equal(t, []string{"a"}, limitTags([]string{"a"}))
+}
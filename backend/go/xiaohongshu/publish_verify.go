@@ -0,0 +1,144 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 发布后笔记需要一段时间才出现在个人主页，按固定间隔重试
+const (
+	publishVerifyAttempts = 5
+	publishVerifyInterval = 3 * time.Second
+)
+
+// PublishVerification 发布后从个人主页找到并读取的线上笔记
+type PublishVerification struct {
+	Verified  bool        `json:"verified"` // 找到线上笔记且标题、正文与提交一致
+	FeedID    string      `json:"feed_id,omitempty"`
+	XsecToken string      `json:"xsec_token,omitempty"`
+	URL       string      `json:"url,omitempty"`
+	Note      *FeedDetail `json:"note,omitempty"`
+	Attempts  int         `json:"attempts"`
+	// CommentsEnabled 发布时指定了 comments_enabled 才读取：线上笔记是否允许评论，无法判断时为 null
+	CommentsEnabled *bool `json:"comments_enabled,omitempty"`
+	// Mismatches 线上笔记与提交内容不一致的字段: title|content|comments_enabled
+	Mismatches []string `json:"mismatches,omitempty"`
+	Reason     string   `json:"reason,omitempty"` // 未能验证时的原因
+}
+
+// PublishVerifyAction 发布后读取线上笔记
+type PublishVerifyAction struct {
+	page *rod.Page
+}
+
+func NewPublishVerifyAction(page *rod.Page) *PublishVerifyAction {
+	return &PublishVerifyAction{page: page}
+}
+
+// VerifyPublished 在个人主页中按标题查找刚发布的笔记并读取详情，笔记尚未出现时按间隔重试。
+// commentsEnabled 不为 nil 时同时确认线上笔记的评论权限与之一致。
+// 找不到笔记或读取失败不返回错误，而是返回 Verified=false 并说明原因，发布本身已经成功。
+func (a *PublishVerifyAction) VerifyPublished(ctx context.Context, title, content string, commentsEnabled *bool) *PublishVerification {
+	result := &PublishVerification{}
+
+	var lastErr error
+	for attempt := 1; attempt <= publishVerifyAttempts; attempt++ {
+		result.Attempts = attempt
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				result.Reason = "验证被取消: " + ctx.Err().Error()
+				return result
+			case <-time.After(publishVerifyInterval):
+			}
+		}
+
+		feed, err := a.findPublished(ctx, title)
+		if err != nil {
+			lastErr = err
+			logrus.Infof("发布验证第 %d 次未找到笔记: %v", attempt, err)
+			continue
+		}
+
+		detail, err := NewFeedDetailAction(a.page).GetFeedDetail(ctx, feed.ID, feed.XsecToken)
+		if err != nil {
+			lastErr = err
+			logrus.Infof("发布验证第 %d 次读取笔记 %s 失败: %v", attempt, feed.ID, err)
+			continue
+		}
+
+		result.FeedID = feed.ID
+		result.XsecToken = feed.XsecToken
+		result.URL = makeFeedDetailURL(feed.ID, feed.XsecToken)
+		result.Note = &detail.Note
+		result.Mismatches = publishMismatches(detail.Note, title, content)
+		unconfirmed := ""
+		if commentsEnabled != nil {
+			result.CommentsEnabled = ReadNoteSettings(a.page.Context(ctx), feed.ID).CommentsEnabled
+			if result.CommentsEnabled == nil {
+				unconfirmed = "线上笔记没有展示评论权限，无法确认 comments_enabled 已生效"
+			} else if *result.CommentsEnabled != *commentsEnabled {
+				result.Mismatches = append(result.Mismatches, PermissionComments)
+			}
+		}
+
+		result.Verified = len(result.Mismatches) == 0 && unconfirmed == ""
+		if len(result.Mismatches) > 0 {
+			result.Reason = "线上笔记与提交内容不一致: " + strings.Join(result.Mismatches, ",")
+		} else if unconfirmed != "" {
+			result.Reason = unconfirmed
+		}
+		return result
+	}
+
+	result.Reason = fmt.Sprintf("%d 次尝试后仍未读取到线上笔记，可能仍在审核中: %v", publishVerifyAttempts, lastErr)
+	return result
+}
+
+// findPublished 打开个人主页，按标题查找最新的笔记
+func (a *PublishVerifyAction) findPublished(ctx context.Context, title string) (*Feed, error) {
+	profile, err := NewUserProfileAction(a.page).GetMyProfileViaSidebar(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, ok := matchPublishedFeed(profile.Feeds, title)
+	if !ok {
+		return nil, fmt.Errorf("个人主页中没有标题为 %q 的笔记", title)
+	}
+	return feed, nil
+}
+
+// matchPublishedFeed 在个人主页的笔记中按标题查找，主页按发布时间倒序，返回第一条匹配的笔记
+func matchPublishedFeed(feeds []Feed, title string) (*Feed, bool) {
+	title = strings.TrimSpace(title)
+	for i := range feeds {
+		if strings.TrimSpace(feeds[i].NoteCard.DisplayTitle) == title && feeds[i].ID != "" {
+			return &feeds[i], true
+		}
+	}
+	return nil, false
+}
+
+// publishMismatches 比较线上笔记与提交的标题、正文。
+// 平台会在正文末尾追加话题标签，因此只要求线上正文包含提交的正文。
+func publishMismatches(note FeedDetail, title, content string) []string {
+	var mismatches []string
+	if strings.TrimSpace(note.Title) != strings.TrimSpace(title) {
+		mismatches = append(mismatches, "title")
+	}
+	if !strings.Contains(normalizeSpace(note.Desc), normalizeSpace(content)) {
+		mismatches = append(mismatches, "content")
+	}
+	return mismatches
+}
+
+// normalizeSpace 去掉首尾空白并把连续空白合并为一个空格，避免换行格式差异造成误判
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,30 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPublishedFeed(t *testing.T) {
+	feeds := []Feed{
+		{ID: "new", NoteCard: NoteCard{DisplayTitle: "周末去哪儿"}},
+		{ID: "old", NoteCard: NoteCard{DisplayTitle: "周末去哪儿"}},
+		{ID: "other", NoteCard: NoteCard{DisplayTitle: "早餐"}},
+	}
+
+	feed, ok := matchPublishedFeed(feeds, " 周末去哪儿 ")
+	require.True(t, ok)
+	require.Equal(t, "new", feed.ID)
+
+	_, ok = matchPublishedFeed(feeds, "不存在")
+	require.False(t, ok)
+}
+
+func TestPublishMismatches(t *testing.T) {
+	note := FeedDetail{Title: "周末去哪儿", Desc: "城郊徒步\n路线推荐 #徒步[话题]#"}
+
+	require.Empty(t, publishMismatches(note, "周末去哪儿", "城郊徒步 路线推荐"))
+	require.Equal(t, []string{"title"}, publishMismatches(note, "周末", "城郊徒步"))
+	require.Equal(t, []string{"content"}, publishMismatches(note, "周末去哪儿", "海边露营"))
+}
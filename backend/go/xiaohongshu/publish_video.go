@@ -4,12 +4,13 @@ import (
 	"context"
 	"log/slog"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
 )
 
 // PublishVideoContent 发布视频内容
@@ -18,6 +19,8 @@ type PublishVideoContent struct {
 	Content   string
 	Tags      []string
 	VideoPath string
+	// Permissions 评论、合拍等权限开关，未指定的项保持编辑器默认
+	Permissions PublishPermissions
 }
 
 // NewPublishVideoAction 进入发布页并切换到“上传视频”
@@ -36,52 +39,78 @@ func NewPublishVideoAction(page *rod.Page) (*PublishAction, error) {
 	return &PublishAction{page: pp}, nil
 }
 
-// PublishVideo 上传视频并提交
-func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) error {
+// PublishVideo 上传视频并提交，返回上传阶段的重试情况
+func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) (*UploadReport, error) {
 	if content.VideoPath == "" {
-		return errors.New("视频不能为空")
+		return nil, errors.New("视频不能为空")
+	}
+	if err := ValidatePublishPermissions(NoteTypeVideo, content.Permissions); err != nil {
+		return nil, err
 	}
 
 	page := p.page.Context(ctx)
 
-	if err := uploadVideo(page, content.VideoPath); err != nil {
-		return errors.Wrap(err, "小红书上传视频失败")
+	upload, err := uploadVideo(page, content.VideoPath)
+	if err != nil {
+		return upload, errors.Wrapf(err, "小红书上传视频失败（已重试 %d 次）", upload.Retries)
 	}
 
-	if err := submitPublishVideo(page, content.Title, content.Content, content.Tags); err != nil {
-		return errors.Wrap(err, "小红书发布失败")
+	if err := submitPublishVideo(page, content.Title, content.Content, limitTags(content.Tags), content.Permissions); err != nil {
+		return upload, errors.Wrap(err, "小红书发布失败")
 	}
-	return nil
+	return upload, nil
 }
 
-// uploadVideo 上传单个本地视频
-func uploadVideo(page *rod.Page, videoPath string) error {
-	pp := page.Timeout(5 * time.Minute) // 视频处理耗时更长
-
+// uploadVideo 上传单个本地视频，上传失败或超时后按 -upload-retries 重新上传
+func uploadVideo(page *rod.Page, videoPath string) (*UploadReport, error) {
+	report := &UploadReport{Files: 1}
 	if _, err := os.Stat(videoPath); os.IsNotExist(err) {
-		return errors.Wrapf(err, "视频文件不存在: %s", videoPath)
+		return report, errors.Wrapf(err, "视频文件不存在: %s", videoPath)
 	}
 
-	// 寻找文件上传输入框（与图文一致的 class，或退回到 input[type=file]）
-	var fileInput *rod.Element
-	var err error
-	fileInput, err = pp.Element(".upload-input")
-	if err != nil || fileInput == nil {
-		fileInput, err = pp.Element("input[type='file']")
-		if err != nil || fileInput == nil {
-			return errors.New("未找到视频上传输入框")
+	timeout := configs.GetUploadTimeout(defaultVideoUploadTimeout)
+	for {
+		report.Attempts++
+
+		fileInput, err := findFileInput(page.Timeout(30 * time.Second))
+		if err != nil {
+			return report, errors.Wrap(err, "未找到视频上传输入框")
+		}
+		fileInput.MustSetFiles(videoPath)
+
+		// 对于视频，等待发布按钮变为可点击即表示处理完成
+		btn, err := waitForVideoUpload(page, timeout)
+		if err == nil {
+			slog.Info("视频上传/处理完成，发布按钮可点击", "btn", btn)
+			return report, nil
+		}
+		if report.Retries >= configs.GetUploadRetries() {
+			return report, err
 		}
+
+		report.Retries++
+		report.RetriedFiles = append(report.RetriedFiles, videoPath)
+		logrus.Warnf("视频上传未完成: %v，第 %d 次重新上传", err, report.Retries)
 	}
+}
 
-	fileInput.MustSetFiles(videoPath)
+// waitForVideoUpload 等待视频上传处理完成（发布按钮可点击），编辑器提示上传失败时提前返回
+func waitForVideoUpload(page *rod.Page, maxWait time.Duration) (*rod.Element, error) {
+	interval := 1 * time.Second
+	start := time.Now()
 
-	// 对于视频，等待发布按钮变为可点击即表示处理完成
-	btn, err := waitForPublishButtonClickable(pp)
-	if err != nil {
-		return err
+	slog.Info("开始等待视频上传完成", "timeout", maxWait)
+
+	for time.Since(start) < maxWait {
+		if videoUploadFailed(page) {
+			return nil, errors.New("视频上传失败")
+		}
+		if btn, ok := publishButtonClickable(page); ok {
+			return btn, nil
+		}
+		time.Sleep(interval)
 	}
-	slog.Info("视频上传/处理完成，发布按钮可点击", "btn", btn)
-	return nil
+	return nil, errors.New("视频上传超时，请检查网络连接和视频大小")
 }
 
 // waitForPublishButtonClickable 等待发布按钮可点击
@@ -89,34 +118,37 @@ func waitForPublishButtonClickable(page *rod.Page) (*rod.Element, error) {
 	maxWait := 10 * time.Minute
 	interval := 1 * time.Second
 	start := time.Now()
-	selector := "button.publishBtn"
 
 	slog.Info("开始等待发布按钮可点击(视频)")
 
 	for time.Since(start) < maxWait {
-		btn, err := page.Element(selector)
-		if err == nil && btn != nil {
-			// 可见性
-			vis, verr := btn.Visible()
-			if verr == nil && vis {
-				// 检查 disabled 属性
-				if disabled, _ := btn.Attribute("disabled"); disabled == nil {
-					// 再通过 class 名粗略判断不在禁用态
-					if cls, _ := btn.Attribute("class"); cls != nil && !strings.Contains(*cls, "disabled") {
-						return btn, nil
-					}
-					// 即使 class 包含 disabled，只要没有 disabled 属性，也尝试点击一次以确认
-					return btn, nil
-				}
-			}
+		if btn, ok := publishButtonClickable(page); ok {
+			return btn, nil
 		}
 		time.Sleep(interval)
 	}
 	return nil, errors.New("等待发布按钮可点击超时")
 }
 
+// publishButtonClickable 发布按钮是否可见且未禁用
+func publishButtonClickable(page *rod.Page) (*rod.Element, bool) {
+	btn, err := page.Element("button.publishBtn")
+	if err != nil || btn == nil {
+		return nil, false
+	}
+	// 可见性
+	if vis, verr := btn.Visible(); verr != nil || !vis {
+		return nil, false
+	}
+	// 检查 disabled 属性；即使 class 包含 disabled，只要没有 disabled 属性，也尝试点击一次以确认
+	if disabled, _ := btn.Attribute("disabled"); disabled != nil {
+		return nil, false
+	}
+	return btn, true
+}
+
 // submitPublishVideo 填写标题、正文、标签并点击发布（等待按钮可点击后再提交）
-func submitPublishVideo(page *rod.Page, title, content string, tags []string) error {
+func submitPublishVideo(page *rod.Page, title, content string, tags []string, permissions PublishPermissions) error {
 	// 标题
 	titleElem := page.MustElement("div.d-input input")
 	titleElem.MustInput(title)
@@ -124,7 +156,9 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string) er
 
 	// 正文 + 标签
 	if contentElem, ok := getContentElement(page); ok {
-		contentElem.MustInput(content)
+		if err := inputBody(contentElem, content); err != nil {
+			return err
+		}
 		inputTags(contentElem, tags)
 	} else {
 		return errors.New("没有找到内容输入框")
@@ -132,6 +166,10 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string) er
 
 	time.Sleep(1 * time.Second)
 
+	if err := setPublishPermissions(page, permissions); err != nil {
+		return err
+	}
+
 	// 等待发布按钮可点击
 	btn, err := waitForPublishButtonClickable(page)
 	if err != nil {
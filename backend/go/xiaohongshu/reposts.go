@@ -0,0 +1,181 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 笔记数据中可能存放“相同图片/内容也出现在”笔记列表的字段，平台不同版本字段名不一致
+var repostKeys = []string{"sameImageNotes", "similarImageNotes", "repostNotes", "duplicateNotes", "alsoPostedNotes"}
+
+// 详情页上展示相同图片笔记的区块标题关键字
+var repostSectionMarkers = []string{"相同图片", "同款图片", "图片也出现在", "也发布了", "相似内容"}
+
+// NoteRepost 复用了相同图片或内容的另一篇笔记
+type NoteRepost struct {
+	FeedID    string `json:"feed_id"`
+	XsecToken string `json:"xsec_token,omitempty"`
+	Title     string `json:"title,omitempty"`
+	User      User   `json:"user"`
+}
+
+// NoteReposts 笔记的转载/搬运线索，平台没有展示时 Reposts 为空列表
+type NoteReposts struct {
+	FeedID  string       `json:"feed_id"`
+	Source  string       `json:"source,omitempty"` // state: 来自 __INITIAL_STATE__；dom: 来自页面区块
+	Reposts []NoteRepost `json:"reposts"`
+}
+
+// RepostsAction 获取复用相同图片/内容的笔记
+type RepostsAction struct {
+	page *rod.Page
+}
+
+func NewRepostsAction(page *rod.Page) *RepostsAction {
+	return &RepostsAction{page: page}
+}
+
+// GetReposts 打开笔记详情页，读取平台展示的“相同图片也出现在”笔记，
+// 先读 __INITIAL_STATE__，没有时再读页面上的对应区块
+func (a *RepostsAction) GetReposts(ctx context.Context, feedID, xsecToken string) (*NoteReposts, error) {
+	page := a.page.Context(ctx).Timeout(60 * time.Second)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	raw := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		if (state && state.note && state.note.noteDetailMap && state.note.noteDetailMap[feedID]) {
+			return JSON.stringify(state.note.noteDetailMap[feedID].note || {});
+		}
+		return "";
+	}`, feedID).String()
+	if raw == "" {
+		return nil, fmt.Errorf("feed %s not found in noteDetailMap", feedID)
+	}
+
+	result, err := parseReposts(feedID, []byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Reposts) > 0 {
+		result.Source = "state"
+		logrus.Infof("笔记 %s 相同图片笔记: %d 篇", feedID, len(result.Reposts))
+		return result, nil
+	}
+
+	links := page.MustEval(`(markers) => {
+		const result = [];
+		document.querySelectorAll('div, section').forEach(el => {
+			const title = el.querySelector('[class*="title"], h3, h4');
+			if (!title || !markers.some(m => title.innerText.includes(m))) return;
+			el.querySelectorAll('a[href*="/explore/"], a[href*="/discovery/item/"]').forEach(a => {
+				result.push({href: a.href, title: a.innerText.trim()});
+			});
+		});
+		return result;
+	}`, repostSectionMarkers).Arr()
+
+	for _, link := range links {
+		repost, ok := parseRepostLink(link.Get("href").Str(), link.Get("title").Str())
+		if ok && repost.FeedID != feedID {
+			result.Reposts = appendRepost(result.Reposts, repost)
+		}
+	}
+	if len(result.Reposts) > 0 {
+		result.Source = "dom"
+	}
+
+	logrus.Infof("笔记 %s 相同图片笔记: %d 篇", feedID, len(result.Reposts))
+	return result, nil
+}
+
+// parseReposts 从笔记原始 JSON 中提取相同图片/内容的笔记，按笔记 ID 去重并排除自身
+func parseReposts(feedID string, raw []byte) (*NoteReposts, error) {
+	var note map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &note); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal note: %w", err)
+	}
+
+	result := &NoteReposts{FeedID: feedID, Reposts: []NoteRepost{}}
+
+	for _, key := range repostKeys {
+		var items []struct {
+			NoteID       string `json:"noteId"`
+			ID           string `json:"id"`
+			XsecToken    string `json:"xsecToken"`
+			Title        string `json:"title"`
+			DisplayTitle string `json:"displayTitle"`
+			User         User   `json:"user"`
+		}
+		if err := json.Unmarshal(note[key], &items); err != nil {
+			continue
+		}
+		for _, item := range items {
+			repost := NoteRepost{
+				FeedID:    item.NoteID,
+				XsecToken: item.XsecToken,
+				Title:     item.Title,
+				User:      item.User,
+			}
+			if repost.FeedID == "" {
+				repost.FeedID = item.ID
+			}
+			if repost.Title == "" {
+				repost.Title = item.DisplayTitle
+			}
+			if repost.FeedID == "" || repost.FeedID == feedID {
+				continue
+			}
+			result.Reposts = appendRepost(result.Reposts, repost)
+		}
+	}
+
+	return result, nil
+}
+
+// parseRepostLink 从笔记链接中解析笔记 ID 与 xsec_token
+func parseRepostLink(href, title string) (NoteRepost, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return NoteRepost{}, false
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || idx == len(path)-1 {
+		return NoteRepost{}, false
+	}
+	prefix := path[:idx]
+	if !strings.HasSuffix(prefix, "/explore") && !strings.HasSuffix(prefix, "/discovery/item") {
+		return NoteRepost{}, false
+	}
+
+	return NoteRepost{
+		FeedID:    path[idx+1:],
+		XsecToken: u.Query().Get("xsec_token"),
+		Title:     strings.TrimSpace(title),
+	}, true
+}
+
+// appendRepost 追加笔记，已存在相同 ID 时忽略
+func appendRepost(reposts []NoteRepost, repost NoteRepost) []NoteRepost {
+	for _, r := range reposts {
+		if r.FeedID == repost.FeedID {
+			return reposts
+		}
+	}
+	return append(reposts, repost)
+}
@@ -0,0 +1,46 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReposts(t *testing.T) {
+	raw := []byte(`{
+		"noteId": "n1",
+		"sameImageNotes": [
+			{"noteId": "n2", "xsecToken": "t2", "title": "搬运", "user": {"userId": "u2", "nickname": "小红"}},
+			{"noteId": "n1", "title": "自己"},
+			{"noteId": ""}
+		],
+		"repostNotes": [{"id": "n3", "displayTitle": "转载"}, {"noteId": "n2"}]
+	}`)
+
+	result, err := parseReposts("n1", raw)
+	require.NoError(t, err)
+	require.Len(t, result.Reposts, 2)
+	require.Equal(t, NoteRepost{FeedID: "n2", XsecToken: "t2", Title: "搬运", User: User{UserID: "u2", Nickname: "小红"}}, result.Reposts[0])
+	require.Equal(t, "n3", result.Reposts[1].FeedID)
+	require.Equal(t, "转载", result.Reposts[1].Title)
+
+	empty, err := parseReposts("n4", []byte(`{"noteId": "n4"}`))
+	require.NoError(t, err)
+	require.NotNil(t, empty.Reposts)
+	require.Empty(t, empty.Reposts)
+}
+
+func TestParseRepostLink(t *testing.T) {
+	repost, ok := parseRepostLink("https://www.xiaohongshu.com/explore/abc123?xsec_token=tok&xsec_source=pc_feed", " 标题 ")
+	require.True(t, ok)
+	require.Equal(t, NoteRepost{FeedID: "abc123", XsecToken: "tok", Title: "标题"}, repost)
+
+	repost, ok = parseRepostLink("https://www.xiaohongshu.com/discovery/item/def456", "")
+	require.True(t, ok)
+	require.Equal(t, "def456", repost.FeedID)
+
+	_, ok = parseRepostLink("https://www.xiaohongshu.com/user/profile/u1", "")
+	require.False(t, ok)
+	_, ok = parseRepostLink("https://www.xiaohongshu.com/explore/", "")
+	require.False(t, ok)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/errors"
 )
 
@@ -156,7 +157,8 @@ func validateInternalFilterOption(filter internalFilterOption) error {
 }
 
 type SearchAction struct {
-	page *rod.Page
+	page        *rod.Page
+	keepFilters bool
 }
 
 func NewSearchAction(page *rod.Page) *SearchAction {
@@ -165,7 +167,22 @@ func NewSearchAction(page *rod.Page) *SearchAction {
 	return &SearchAction{page: pp}
 }
 
+// KeepFilters 搜索前不重置平台记住的筛选条件。默认会先把筛选恢复为默认值，保证结果可复现
+func (s *SearchAction) KeepFilters(keep bool) *SearchAction {
+	s.keepFilters = keep
+	return s
+}
+
 func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...FilterOption) ([]Feed, error) {
+	result, err := s.SearchWithPagination(ctx, keyword, PaginateOption{}, filters...)
+	if err != nil {
+		return nil, err
+	}
+	return result.Feeds, nil
+}
+
+// SearchWithPagination 搜索并按 opt 自动滚动加载更多结果
+func (s *SearchAction) SearchWithPagination(ctx context.Context, keyword string, opt PaginateOption, filters ...FilterOption) (*PaginateResult, error) {
 	page := s.page.Context(ctx)
 
 	searchURL := makeSearchURL(keyword)
@@ -174,46 +191,104 @@ func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...Fi
 
 	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
 
-	// 如果有筛选条件，则应用筛选
-	if len(filters) > 0 {
-		// 将所有 FilterOption 转换为内部筛选选项
-		var allInternalFilters []internalFilterOption
-		for _, filter := range filters {
-			internalFilters, err := convertToInternalFilters(filter)
-			if err != nil {
-				return nil, fmt.Errorf("筛选选项转换失败: %w", err)
-			}
-			allInternalFilters = append(allInternalFilters, internalFilters...)
-		}
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareFilters(page, filters); err != nil {
+		return nil, err
+	}
+
+	return collectFeedsByScroll(page, opt, readSearchFeeds)
+}
+
+// StreamSearch 搜索并滚动加载结果，每发现一条新 feed 就交给 emit，用于大批量导出。
+// 长时间导出不受构造时 60 秒超时限制，由 ctx 控制取消。返回是否已加载到底。
+func (s *SearchAction) StreamSearch(ctx context.Context, keyword string, opt PaginateOption, emit func(Feed) error, filters ...FilterOption) (bool, error) {
+	page := s.page.CancelTimeout().Context(ctx)
+
+	page.MustNavigate(makeSearchURL(keyword))
+	page.MustWaitStable()
+
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	if err := passContentGate(page); err != nil {
+		return false, err
+	}
+
+	if err := s.prepareFilters(page, filters); err != nil {
+		return false, err
+	}
 
-		// 验证所有内部筛选选项
-		for _, filter := range allInternalFilters {
-			if err := validateInternalFilterOption(filter); err != nil {
-				return nil, fmt.Errorf("筛选选项验证失败: %w", err)
+	return streamFeedsByScroll(page, opt, readSearchFeeds, emit)
+}
+
+// prepareFilters 按需重置记住的筛选，再应用本次指定的筛选。
+// 重置失败只在本次指定了筛选时返回错误，否则记录日志后按页面当前的筛选继续搜索。
+func (s *SearchAction) prepareFilters(page *rod.Page, filters []FilterOption) error {
+	if !s.keepFilters {
+		if err := resetSearchFilters(page); err != nil {
+			if len(filters) > 0 {
+				return fmt.Errorf("重置搜索筛选失败: %w", err)
 			}
+			logrus.Warnf("重置搜索筛选失败，按页面当前的筛选继续搜索: %v", err)
 		}
+	}
+	return applySearchFilters(page, filters)
+}
 
-		// 悬停在筛选按钮上
-		filterButton := page.MustElement(`div.filter`)
-		filterButton.MustHover()
+// applySearchFilters 在搜索结果页上应用筛选条件
+func applySearchFilters(page *rod.Page, filters []FilterOption) error {
+	if len(filters) == 0 {
+		return nil
+	}
 
-		// 等待筛选面板出现
-		page.MustWait(`() => document.querySelector('div.filter-panel') !== null`)
+	// 将所有 FilterOption 转换为内部筛选选项
+	var allInternalFilters []internalFilterOption
+	for _, filter := range filters {
+		internalFilters, err := convertToInternalFilters(filter)
+		if err != nil {
+			return fmt.Errorf("筛选选项转换失败: %w", err)
+		}
+		allInternalFilters = append(allInternalFilters, internalFilters...)
+	}
 
-		// 应用所有筛选条件
-		for _, filter := range allInternalFilters {
-			selector := fmt.Sprintf(`div.filter-panel div.filters:nth-child(%d) div.tags:nth-child(%d)`,
-				filter.FiltersIndex, filter.TagsIndex)
-			option := page.MustElement(selector)
-			option.MustClick()
+	// 验证所有内部筛选选项
+	for _, filter := range allInternalFilters {
+		if err := validateInternalFilterOption(filter); err != nil {
+			return fmt.Errorf("筛选选项验证失败: %w", err)
 		}
+	}
 
-		// 等待页面更新
-		page.MustWaitStable()
-		// 重新等待 __INITIAL_STATE__ 更新
-		page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+	if len(allInternalFilters) == 0 {
+		return nil
 	}
 
+	// 悬停在筛选按钮上
+	filterButton := page.MustElement(`div.filter`)
+	filterButton.MustHover()
+
+	// 等待筛选面板出现
+	page.MustWait(`() => document.querySelector('div.filter-panel') !== null`)
+
+	// 应用所有筛选条件
+	for _, filter := range allInternalFilters {
+		selector := fmt.Sprintf(`div.filter-panel div.filters:nth-child(%d) div.tags:nth-child(%d)`,
+			filter.FiltersIndex, filter.TagsIndex)
+		option := page.MustElement(selector)
+		option.MustClick()
+	}
+
+	// 等待页面更新
+	page.MustWaitStable()
+	// 重新等待 __INITIAL_STATE__ 更新
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	return nil
+}
+
+// readSearchFeeds 读取搜索结果页当前已加载的 feeds
+func readSearchFeeds(page *rod.Page) ([]Feed, error) {
 	result := page.MustEval(`() => {
 		if (window.__INITIAL_STATE__ &&
 		    window.__INITIAL_STATE__.search &&
@@ -231,8 +306,13 @@ func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...Fi
 		return nil, errors.ErrNoFeeds
 	}
 
+	return parseFeeds(result)
+}
+
+// parseFeeds 解析 __INITIAL_STATE__ 中序列化的 feeds 数组
+func parseFeeds(data string) ([]Feed, error) {
 	var feeds []Feed
-	if err := json.Unmarshal([]byte(result), &feeds); err != nil {
+	if err := json.Unmarshal([]byte(data), &feeds); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal feeds: %w", err)
 	}
 
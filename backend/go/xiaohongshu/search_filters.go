@@ -0,0 +1,131 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// SearchFilterState 搜索结果页上当前生效的筛选条件
+type SearchFilterState struct {
+	Keyword   string       `json:"keyword"`
+	Filters   FilterOption `json:"filters"`
+	IsDefault bool         `json:"is_default"` // 所有筛选都是默认值（第一项）
+}
+
+// GetSearchFilters 打开关键词的搜索结果页，读取平台记住的筛选条件，不做修改
+func (s *SearchAction) GetSearchFilters(ctx context.Context, keyword string) (*SearchFilterState, error) {
+	page := s.page.Context(ctx)
+
+	page.MustNavigate(makeSearchURL(keyword))
+	page.MustWaitStable()
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	texts, err := readFilterTexts(page)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, isDefault := filterStateFromTexts(texts)
+	return &SearchFilterState{Keyword: keyword, Filters: filters, IsDefault: isDefault}, nil
+}
+
+// resetSearchFilters 把不是默认值的筛选组点回第一项。小红书会在会话之间记住上次的筛选，
+// 不重置时同一关键词的结果会受之前搜索的影响。页面没有筛选面板时不做处理。
+func resetSearchFilters(page *rod.Page) error {
+	has, _, err := page.Has(`div.filter`)
+	if err != nil || !has {
+		return nil
+	}
+
+	texts, err := readFilterTexts(page)
+	if err != nil {
+		return err
+	}
+
+	reset := 0
+	for i, text := range texts {
+		group := i + 1
+		options, ok := filterOptionsMap[group]
+		if !ok || text == "" || text == options[0].Text {
+			continue
+		}
+		selector := fmt.Sprintf(`div.filter-panel div.filters:nth-child(%d) div.tags:nth-child(1)`, group)
+		option, err := page.Element(selector)
+		if err != nil {
+			return fmt.Errorf("没有找到第 %d 组筛选的默认项: %w", group, err)
+		}
+		if err := option.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return fmt.Errorf("点击第 %d 组筛选的默认项失败: %w", group, err)
+		}
+		reset++
+	}
+
+	if reset > 0 {
+		logrus.Infof("已重置 %d 项记住的搜索筛选", reset)
+		page.MustWaitStable()
+		page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+	}
+	return nil
+}
+
+// readFilterTexts 悬停打开筛选面板，按筛选组顺序返回各组选中项的文本，未选中的组为空
+func readFilterTexts(page *rod.Page) ([]string, error) {
+	button, err := page.Element(`div.filter`)
+	if err != nil {
+		return nil, fmt.Errorf("没有找到搜索筛选按钮: %w", err)
+	}
+	button.MustHover()
+
+	if err := page.Timeout(5 * time.Second).Wait(rod.Eval(`() => document.querySelector('div.filter-panel') !== null`)); err != nil {
+		return nil, fmt.Errorf("筛选面板没有出现: %w", err)
+	}
+
+	var texts []string
+	result := page.MustEval(`() => {
+		const panel = document.querySelector('div.filter-panel');
+		return Array.from(panel.querySelectorAll('div.filters')).map(group => {
+			const active = group.querySelector('div.tags.active');
+			return active ? active.innerText.trim() : "";
+		});
+	}`)
+	for _, v := range result.Arr() {
+		texts = append(texts, v.Str())
+	}
+	return texts, nil
+}
+
+// filterStateFromTexts 把各筛选组选中项的文本转换为 FilterOption，未识别或未选中的组视为默认值
+func filterStateFromTexts(texts []string) (FilterOption, bool) {
+	selected := make(map[int]string, len(filterOptionsMap))
+	isDefault := true
+
+	for group, options := range filterOptionsMap {
+		text := options[0].Text
+		if group-1 < len(texts) {
+			if _, err := findInternalOption(group, texts[group-1]); err == nil {
+				text = texts[group-1]
+			}
+		}
+		if text != options[0].Text {
+			isDefault = false
+		}
+		selected[group] = text
+	}
+
+	return FilterOption{
+		SortBy:      selected[1],
+		NoteType:    selected[2],
+		PublishTime: selected[3],
+		SearchScope: selected[4],
+		Location:    selected[5],
+	}, isDefault
+}
@@ -0,0 +1,22 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterStateFromTexts(t *testing.T) {
+	filters, isDefault := filterStateFromTexts([]string{"综合", "不限", "不限", "不限", "不限"})
+	require.True(t, isDefault)
+	require.Equal(t, "综合", filters.SortBy)
+
+	filters, isDefault = filterStateFromTexts([]string{"最新", "", "一周内"})
+	require.False(t, isDefault)
+	require.Equal(t, FilterOption{SortBy: "最新", NoteType: "不限", PublishTime: "一周内", SearchScope: "不限", Location: "不限"}, filters)
+
+	// 无法识别的文本按默认值处理
+	filters, isDefault = filterStateFromTexts([]string{"综合", "直播"})
+	require.True(t, isDefault)
+	require.Equal(t, "不限", filters.NoteType)
+}
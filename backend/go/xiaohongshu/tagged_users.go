@@ -0,0 +1,160 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// 笔记数据中存放被标记用户的字段（不含正文里的 @ 提及 atUserList）
+var taggedUserNoteKeys = []string{"taggedUsers", "tagUsers", "userTagList"}
+
+// 图片上的标签字段，元素中带用户 ID 的是用户标记，其余为地点、商品等标签
+var taggedUserImageKeys = []string{"tagList", "tags", "stickers"}
+
+// 用户标记所在的位置
+const (
+	TaggedInNote  = "note"  // 笔记级别的标记
+	TaggedInImage = "image" // 图片上的标记
+)
+
+// TaggedUser 笔记中被标记的用户
+type TaggedUser struct {
+	UserID      string `json:"user_id"`
+	Nickname    string `json:"nickname"`
+	XsecToken   string `json:"xsec_token,omitempty"` // 访问其主页时使用
+	Source      string `json:"source"`               // note | image
+	SourceLabel string `json:"source_label"`
+}
+
+// NoteTaggedUsers 笔记中被标记的用户，没有时为空列表
+type NoteTaggedUsers struct {
+	FeedID string       `json:"feed_id"`
+	Users  []TaggedUser `json:"users"`
+}
+
+// TaggedUsersAction 获取笔记中被标记的用户
+type TaggedUsersAction struct {
+	page *rod.Page
+}
+
+func NewTaggedUsersAction(page *rod.Page) *TaggedUsersAction {
+	pp := page.Timeout(60 * time.Second)
+	return &TaggedUsersAction{page: pp}
+}
+
+// GetTaggedUsers 打开笔记详情页，从 __INITIAL_STATE__ 中读取笔记和图片上标记的用户
+func (a *TaggedUsersAction) GetTaggedUsers(ctx context.Context, feedID, xsecToken string) (*NoteTaggedUsers, error) {
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	raw := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		if (state && state.note && state.note.noteDetailMap && state.note.noteDetailMap[feedID]) {
+			return JSON.stringify(state.note.noteDetailMap[feedID].note || {});
+		}
+		return "";
+	}`, feedID).String()
+	if raw == "" {
+		return nil, fmt.Errorf("feed %s not found in noteDetailMap", feedID)
+	}
+
+	result, err := parseTaggedUsers(feedID, []byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("笔记 %s 标记用户 %d 个", feedID, len(result.Users))
+	return result, nil
+}
+
+// taggedUserEntry 标记用户的原始数据，平台不同版本字段名不一致
+type taggedUserEntry struct {
+	UserID    string `json:"userId"`
+	UserIDAlt string `json:"user_id"`
+	Nickname  string `json:"nickname"`
+	NickName  string `json:"nickName"`
+	Name      string `json:"name"`
+	XsecToken string `json:"xsecToken"`
+	User      *struct {
+		UserID    string `json:"userId"`
+		Nickname  string `json:"nickname"`
+		NickName  string `json:"nickName"`
+		XsecToken string `json:"xsecToken"`
+	} `json:"user"`
+}
+
+// toTaggedUser 转换为 TaggedUser，没有用户 ID 时返回 false（如地点、商品标签）
+func (e taggedUserEntry) toTaggedUser(source string) (TaggedUser, bool) {
+	user := TaggedUser{Source: source, SourceLabel: EnumLabel(EnumTaggedUserSource, source), XsecToken: e.XsecToken}
+	user.UserID = firstNonEmpty(e.UserID, e.UserIDAlt)
+	user.Nickname = firstNonEmpty(e.Nickname, e.NickName, e.Name)
+	if e.User != nil {
+		user.UserID = firstNonEmpty(user.UserID, e.User.UserID)
+		user.Nickname = firstNonEmpty(user.Nickname, e.User.Nickname, e.User.NickName)
+		user.XsecToken = firstNonEmpty(user.XsecToken, e.User.XsecToken)
+	}
+	return user, user.UserID != ""
+}
+
+// parseTaggedUsers 从笔记原始 JSON 中提取被标记的用户，按用户 ID 去重
+func parseTaggedUsers(feedID string, raw []byte) (*NoteTaggedUsers, error) {
+	var note map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &note); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal note: %w", err)
+	}
+
+	result := &NoteTaggedUsers{FeedID: feedID, Users: []TaggedUser{}}
+	seen := make(map[string]bool)
+	add := func(entries []taggedUserEntry, source string) {
+		for _, entry := range entries {
+			user, ok := entry.toTaggedUser(source)
+			if !ok || seen[user.UserID] {
+				continue
+			}
+			seen[user.UserID] = true
+			result.Users = append(result.Users, user)
+		}
+	}
+
+	for _, key := range taggedUserNoteKeys {
+		var entries []taggedUserEntry
+		if err := json.Unmarshal(note[key], &entries); err == nil {
+			add(entries, TaggedInNote)
+		}
+	}
+
+	var images []map[string]json.RawMessage
+	if err := json.Unmarshal(note["imageList"], &images); err == nil {
+		for _, image := range images {
+			for _, key := range taggedUserImageKeys {
+				var entries []taggedUserEntry
+				if err := json.Unmarshal(image[key], &entries); err == nil {
+					add(entries, TaggedInImage)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaggedUsers(t *testing.T) {
+	raw := []byte(`{
+		"noteId": "n1",
+		"atUserList": [{"userId": "mention", "nickname": "正文提及"}],
+		"taggedUsers": [{"userId": "u1", "nickname": "小明", "xsecToken": "t1"}],
+		"imageList": [
+			{"urlDefault": "a.jpg", "tagList": [{"type": "location", "name": "上海"}, {"type": "user", "user": {"userId": "u2", "nickName": "小红"}}]},
+			{"urlDefault": "b.jpg", "stickers": [{"user_id": "u1", "name": "小明"}]}
+		]
+	}`)
+
+	result, err := parseTaggedUsers("n1", raw)
+	require.NoError(t, err)
+	require.Equal(t, []TaggedUser{
+		{UserID: "u1", Nickname: "小明", XsecToken: "t1", Source: "note", SourceLabel: "笔记"},
+		{UserID: "u2", Nickname: "小红", Source: "image", SourceLabel: "图片"},
+	}, result.Users)
+
+	empty, err := parseTaggedUsers("n2", []byte(`{"noteId": "n2", "imageList": [{"urlDefault": "a.jpg"}]}`))
+	require.NoError(t, err)
+	require.NotNil(t, empty.Users)
+	require.Empty(t, empty.Users)
+}
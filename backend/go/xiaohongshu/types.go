@@ -89,8 +89,16 @@ type VideoCapability struct {
 type FeedDetailResponse struct {
 	Note     FeedDetail  `json:"note"`
 	Comments CommentList `json:"comments"`
+	// Source 详情的来源: state 为页面数据；dom 为回退解析页面元素，此时 comments 为空，不代表笔记没有评论
+	Source string `json:"source"`
 }
 
+// 笔记详情的提取方式
+const (
+	DetailSourceState = "state"
+	DetailSourceDOM   = "dom"
+)
+
 // FeedDetail 表示详情页的笔记内容
 type FeedDetail struct {
 	NoteID       string            `json:"noteId"`
@@ -119,6 +127,9 @@ type CommentList struct {
 	List    []Comment `json:"list"`
 	Cursor  string    `json:"cursor"`
 	HasMore bool      `json:"hasMore"`
+
+	// DepthTruncated 为 true 表示有评论的回复因深度限制或未展开而没有全部返回
+	DepthTruncated bool `json:"depthTruncated,omitempty"`
 }
 
 // Comment 表示单条评论
@@ -134,6 +145,9 @@ type Comment struct {
 	SubCommentCount string    `json:"subCommentCount"`
 	SubComments     []Comment `json:"subComments"`
 	ShowTags        []string  `json:"showTags"`
+
+	// RepliesTruncated 为 true 表示该评论的回复没有全部返回（超过深度限制或未加载）
+	RepliesTruncated bool `json:"repliesTruncated,omitempty"`
 }
 
 // UserProfileResponse 用户详情页完整响应
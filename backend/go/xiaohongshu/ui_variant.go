@@ -0,0 +1,165 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+// 界面版本名称
+const (
+	UIVariantDefault = "default"
+	UIVariantClassic = "classic"
+)
+
+// SelectorSet 笔记详情页 DOM 解析和评论操作使用的选择器
+type SelectorSet struct {
+	DetailContainer     string
+	DetailTitle         string
+	DetailDesc          string
+	AuthorName          string
+	LikeCount           string
+	CollectCount        string
+	CommentCount        string
+	MediaImages         string
+	Video               string
+	CommentSortItem     string
+	CommentInputTrigger string
+	CommentInput        string
+	CommentSubmit       string
+}
+
+// UIVariant 一种界面版本（A/B 实验布局）及其选择器
+type UIVariant struct {
+	Name      string
+	Marker    string // 页面上存在该元素时认为是此版本；为空表示兜底版本
+	Selectors SelectorSet
+}
+
+// 已知的界面版本，按顺序匹配，最后一个为兜底版本
+var uiVariants = []UIVariant{
+	{
+		Name:   UIVariantClassic,
+		Marker: ".note-detail-mask .note-scroller .engage-bar",
+		Selectors: SelectorSet{
+			DetailContainer:     "#noteContainer, .note-detail-mask",
+			DetailTitle:         ".note-content .title",
+			DetailDesc:          ".note-content .desc",
+			AuthorName:          ".author-container .username",
+			LikeCount:           ".engage-bar .like-wrapper .count",
+			CollectCount:        ".engage-bar .collect-wrapper .count",
+			CommentCount:        ".engage-bar .chat-wrapper .count",
+			MediaImages:         ".slider-container img, .media-container img",
+			Video:               ".player-container video, xg-video-container",
+			CommentSortItem:     ".comments-el .sort-item",
+			CommentInputTrigger: ".engage-bar .input-box .content-edit span",
+			CommentInput:        ".engage-bar .input-box .content-edit p.content-input",
+			CommentSubmit:       ".engage-bar button.submit",
+		},
+	},
+	{
+		Name: UIVariantDefault,
+		Selectors: SelectorSet{
+			DetailContainer:     "#noteContainer, .note-container",
+			DetailTitle:         "#detail-title",
+			DetailDesc:          "#detail-desc",
+			AuthorName:          ".author-wrapper .username",
+			LikeCount:           ".interact-container .like-wrapper .count",
+			CollectCount:        ".interact-container .collect-wrapper .count",
+			CommentCount:        ".interact-container .chat-wrapper .count",
+			MediaImages:         ".media-container .swiper-slide img, .media-container img",
+			Video:               ".player-container video, xg-video-container",
+			CommentSortItem:     ".comments-container .sort-container .sort-item, .comments-el .sort-item",
+			CommentInputTrigger: "div.input-box div.content-edit span",
+			CommentInput:        "div.input-box div.content-edit p.content-input",
+			CommentSubmit:       "div.bottom button.submit",
+		},
+	},
+}
+
+// UIVariantState 最近一次识别到的界面版本
+type UIVariantState struct {
+	Configured string     `json:"configured"` // auto 或强制指定的版本
+	Detected   string     `json:"detected,omitempty"`
+	Page       string     `json:"page,omitempty"`
+	DetectedAt *time.Time `json:"detected_at,omitempty"`
+}
+
+var (
+	uiVariantMu    sync.Mutex
+	uiVariantState UIVariantState
+)
+
+// ValidateUIVariant 校验界面版本配置
+func ValidateUIVariant(name string) error {
+	if name == configs.UIVariantAuto {
+		return nil
+	}
+	var names []string
+	for _, v := range uiVariants {
+		if v.Name == name {
+			return nil
+		}
+		names = append(names, v.Name)
+	}
+	return fmt.Errorf("未知的界面版本 %q，可选: auto|%s", name, strings.Join(names, "|"))
+}
+
+// CurrentUIVariant 返回最近一次识别到的界面版本
+func CurrentUIVariant() UIVariantState {
+	uiVariantMu.Lock()
+	defer uiVariantMu.Unlock()
+
+	state := uiVariantState
+	state.Configured = configs.GetUIVariant()
+	return state
+}
+
+// detectUIVariant 根据页面标记识别界面版本并返回对应选择器；配置为强制版本时直接使用该版本
+func detectUIVariant(page *rod.Page) *UIVariant {
+	variant := matchUIVariant(configs.GetUIVariant(), func(marker string) bool {
+		has, _, err := page.Has(marker)
+		return err == nil && has
+	})
+
+	info, _ := page.Info()
+	pageURL := ""
+	if info != nil {
+		pageURL = info.URL
+	}
+
+	uiVariantMu.Lock()
+	if uiVariantState.Detected != variant.Name {
+		logrus.Infof("识别到小红书界面版本: %s (page=%s)", variant.Name, pageURL)
+	}
+	uiVariantState.Detected = variant.Name
+	uiVariantState.Page = pageURL
+	now := time.Now()
+	uiVariantState.DetectedAt = &now
+	uiVariantMu.Unlock()
+
+	return variant
+}
+
+// matchUIVariant 按顺序匹配界面版本，has 判断页面上是否存在某个标记
+func matchUIVariant(configured string, has func(marker string) bool) *UIVariant {
+	if configured != configs.UIVariantAuto {
+		for i := range uiVariants {
+			if uiVariants[i].Name == configured {
+				return &uiVariants[i]
+			}
+		}
+	}
+
+	for i := range uiVariants {
+		if uiVariants[i].Marker == "" || has(uiVariants[i].Marker) {
+			return &uiVariants[i]
+		}
+	}
+	return &uiVariants[len(uiVariants)-1]
+}
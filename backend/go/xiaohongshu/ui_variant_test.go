@@ -0,0 +1,23 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+)
+
+func TestMatchUIVariant(t *testing.T) {
+	none := func(string) bool { return false }
+	all := func(string) bool { return true }
+
+	require.Equal(t, UIVariantDefault, matchUIVariant(configs.UIVariantAuto, none).Name)
+	require.Equal(t, UIVariantClassic, matchUIVariant(configs.UIVariantAuto, all).Name)
+
+	// 强制指定时忽略页面标记
+	require.Equal(t, UIVariantDefault, matchUIVariant(UIVariantDefault, all).Name)
+
+	require.NoError(t, ValidateUIVariant(configs.UIVariantAuto))
+	require.NoError(t, ValidateUIVariant(UIVariantClassic))
+	require.Error(t, ValidateUIVariant("unknown"))
+}
@@ -0,0 +1,121 @@
+package xiaohongshu
+
+import (
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+)
+
+// 未设置 -upload-timeout 时每次上传等待完成的时间
+const (
+	defaultImageUploadTimeout = 60 * time.Second
+	defaultVideoUploadTimeout = 10 * time.Minute
+)
+
+// 编辑器中图片预览的上传状态
+const (
+	uploadStateDone      = "done"
+	uploadStateUploading = "uploading"
+	uploadStateFailed    = "failed"
+)
+
+// UploadReport 发布时上传素材阶段的情况
+type UploadReport struct {
+	Files    int `json:"files"`    // 上传的文件数
+	Attempts int `json:"attempts"` // 上传尝试次数，含第一次
+	Retries  int `json:"retries"`  // 重新上传的次数，0 表示一次上传成功
+	// RetriedFiles 重新上传过的文件，按重试顺序排列，同一文件可能出现多次
+	RetriedFiles []string `json:"retried_files,omitempty"`
+}
+
+// findFileInput 寻找文件上传输入框（图文与视频一致的 class，或退回到 input[type=file]）
+func findFileInput(page *rod.Page) (*rod.Element, error) {
+	if input, err := page.Element(".upload-input"); err == nil && input != nil {
+		return input, nil
+	}
+	if input, err := page.Element("input[type='file']"); err == nil && input != nil {
+		return input, nil
+	}
+	return nil, errors.New("未找到上传输入框")
+}
+
+// readUploadPreviews 按页面顺序读取图片预览的上传状态。
+// 预览里常常预先渲染好隐藏的失败提示，只有可见的失败标记才算上传失败。
+func readUploadPreviews(page *rod.Page) []string {
+	res := page.MustEval(`() => Array.from(document.querySelectorAll('.img-preview-area .pr'), el => {
+		const text = el.innerText || "";
+		const failMarker = Array.from(el.querySelectorAll('[class*="fail"], [class*="error"]')).some(m => m.offsetParent !== null);
+		if (/失败|重新上传|重试/.test(text) || failMarker) {
+			return "failed";
+		}
+		const busy = el.querySelector('[class*="progress"], [class*="loading"], [class*="uploading"]');
+		if ((busy && busy.offsetParent !== null) || /\d+%/.test(text)) {
+			return "uploading";
+		}
+		return "done";
+	})`)
+	states := make([]string, 0, len(res.Arr()))
+	for _, s := range res.Arr() {
+		states = append(states, s.Str())
+	}
+	return states
+}
+
+// checkUploadPreviews 根据图片预览的状态判断上传进度。
+// done 表示 expected 张图片都已上传完成；failed 表示有图片上传失败且其余图片都已不在上传中，无需继续等待。
+// retryFrom 为需要重新上传的第一张图片的下标：有失败时为第一张失败的图片，否则为第一张未完成或缺失的图片。
+func checkUploadPreviews(states []string, expected int) (done, failed bool, retryFrom int) {
+	firstFailed, firstPending := -1, -1
+	uploading := false
+	for i, state := range states {
+		if i >= expected {
+			break
+		}
+		switch state {
+		case uploadStateFailed:
+			if firstFailed < 0 {
+				firstFailed = i
+			}
+		case uploadStateUploading:
+			uploading = true
+		}
+		if state != uploadStateDone && firstPending < 0 {
+			firstPending = i
+		}
+	}
+
+	switch {
+	case firstFailed >= 0:
+		return false, !uploading, firstFailed
+	case firstPending >= 0:
+		return false, false, firstPending
+	case len(states) < expected:
+		return false, false, len(states)
+	}
+	return true, false, expected
+}
+
+// removeUploadPreviews 从后往前删除下标 from 起的图片预览，重新上传时保持图片顺序不变
+func removeUploadPreviews(page *rod.Page, from int) error {
+	page.MustEval(`(from) => {
+		const previews = Array.from(document.querySelectorAll('.img-preview-area .pr'));
+		for (let i = previews.length - 1; i >= from; i--) {
+			const btn = previews[i].querySelector('[class*="delete"], [class*="close"], [class*="remove"]');
+			if (btn) btn.click();
+		}
+	}`, from)
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		if len(readUploadPreviews(page)) <= from {
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return errors.Errorf("无法删除第 %d 张起的图片预览", from+1)
+}
+
+// videoUploadFailed 编辑器是否展示了视频上传失败的提示
+func videoUploadFailed(page *rod.Page) bool {
+	return page.MustEval(`() => /上传失败/.test(document.body.innerText || "")`).Bool()
+}
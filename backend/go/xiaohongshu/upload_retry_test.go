@@ -0,0 +1,42 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUploadPreviews(t *testing.T) {
+	const (
+		d = uploadStateDone
+		u = uploadStateUploading
+		f = uploadStateFailed
+	)
+
+	tests := []struct {
+		name      string
+		states    []string
+		expected  int
+		done      bool
+		failed    bool
+		retryFrom int
+	}{
+		{name: "全部完成", states: []string{d, d, d}, expected: 3, done: true, retryFrom: 3},
+		{name: "尚未出现预览", states: nil, expected: 2, retryFrom: 0},
+		{name: "部分缺失", states: []string{d}, expected: 3, retryFrom: 1},
+		{name: "仍在上传", states: []string{d, u, d}, expected: 3, retryFrom: 1},
+		{name: "失败且其余已结束", states: []string{d, f, d}, expected: 3, failed: true, retryFrom: 1},
+		{name: "失败但仍有上传中", states: []string{d, f, u}, expected: 3, retryFrom: 1},
+		{name: "失败优先于前面的上传中", states: []string{u, d, f}, expected: 3, retryFrom: 2},
+		{name: "忽略多出的预览", states: []string{d, d, f}, expected: 2, done: true, retryFrom: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, failed, retryFrom := checkUploadPreviews(tt.states, tt.expected)
+			require.Equal(t, tt.done, done)
+			require.Equal(t, tt.failed, failed)
+			require.Equal(t, tt.retryFrom, retryFrom)
+		})
+	}
+}
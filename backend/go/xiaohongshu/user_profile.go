@@ -26,6 +26,10 @@ func (u *UserProfileAction) UserProfile(ctx context.Context, userID, xsecToken s
 	page.MustNavigate(searchURL)
 	page.MustWaitStable()
 
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
 	return u.extractUserProfileData(page)
 }
 
@@ -51,6 +55,32 @@ func (u *UserProfileAction) extractUserProfileData(page *rod.Page) (*UserProfile
 	}
 
 	// 2. 获取用户帖子：window.__INITIAL_STATE__.user.notes.value
+	feeds, err := readUserNotes(page)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析用户信息
+	var userPageData struct {
+		Interactions []UserInteractions `json:"interactions"`
+		BasicInfo    UserBasicInfo      `json:"basicInfo"`
+	}
+	if err := json.Unmarshal([]byte(userDataResult), &userPageData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal userPageData: %w", err)
+	}
+
+	// 组装响应
+	response := &UserProfileResponse{
+		UserBasicInfo: userPageData.BasicInfo,
+		Interactions:  userPageData.Interactions,
+		Feeds:         feeds,
+	}
+
+	return response, nil
+}
+
+// readUserNotes 读取用户主页当前已加载的帖子（展平双重数组）
+func readUserNotes(page *rod.Page) ([]Feed, error) {
 	notesResult := page.MustEval(`() => {
 		if (window.__INITIAL_STATE__ &&
 		    window.__INITIAL_STATE__.user &&
@@ -69,35 +99,34 @@ func (u *UserProfileAction) extractUserProfileData(page *rod.Page) (*UserProfile
 		return nil, fmt.Errorf("user.notes.value not found in __INITIAL_STATE__")
 	}
 
-	// 解析用户信息
-	var userPageData struct {
-		Interactions []UserInteractions `json:"interactions"`
-		BasicInfo    UserBasicInfo      `json:"basicInfo"`
-	}
-	if err := json.Unmarshal([]byte(userDataResult), &userPageData); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal userPageData: %w", err)
-	}
-
 	// 解析帖子数据（帖子为双重数组）
 	var notesFeeds [][]Feed
 	if err := json.Unmarshal([]byte(notesResult), &notesFeeds); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal notes: %w", err)
 	}
 
-	// 组装响应
-	response := &UserProfileResponse{
-		UserBasicInfo: userPageData.BasicInfo,
-		Interactions:  userPageData.Interactions,
+	var feeds []Feed
+	for _, group := range notesFeeds {
+		feeds = append(feeds, group...)
 	}
+	return feeds, nil
+}
 
-	// 添加用户帖子（展平双重数组）
-	for _, feeds := range notesFeeds {
-		if len(feeds) != 0 {
-			response.Feeds = append(response.Feeds, feeds...)
-		}
+// StreamUserNotes 打开用户主页并滚动加载帖子，每发现一条新帖子就交给 emit。
+// 长时间导出不受构造时 60 秒超时限制，由 ctx 控制取消。返回是否已加载到底。
+func (u *UserProfileAction) StreamUserNotes(ctx context.Context, userID, xsecToken string, opt PaginateOption, emit func(Feed) error) (bool, error) {
+	page := u.page.CancelTimeout().Context(ctx)
+
+	page.MustNavigate(makeUserProfileURL(userID, xsecToken))
+	page.MustWaitStable()
+
+	if err := passContentGate(page); err != nil {
+		return false, err
 	}
 
-	return response, nil
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	return streamFeedsByScroll(page, opt, readUserNotes, emit)
 }
 
 func makeUserProfileURL(userID, xsecToken string) string {
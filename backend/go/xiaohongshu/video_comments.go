@@ -0,0 +1,223 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// 视频加载后等待弹幕接口返回的时间
+const danmakuCaptureWindow = 8 * time.Second
+
+// 弹幕接口地址中的关键字
+var danmakuURLMarkers = []string{"danmaku", "danmu", "bullet"}
+
+// 弹幕内容字段与时间字段，时间字段分为毫秒和秒两类
+var (
+	danmakuContentKeys = []string{"content", "text", "danmaku_content"}
+	danmakuMsKeys      = []string{"offset_ms", "time_ms", "progress", "playtime"}
+	danmakuSecondKeys  = []string{"offset", "time", "start_time", "position"}
+)
+
+// VideoComment 视频弹幕（随播放时间出现的评论）
+type VideoComment struct {
+	Content  string `json:"content"`
+	OffsetMs int64  `json:"offset_ms"` // 相对视频开头的出现时间
+	UserID   string `json:"user_id,omitempty"`
+}
+
+// VideoCommentsResult 视频弹幕结果，非视频笔记或没有弹幕时 Comments 为空
+type VideoCommentsResult struct {
+	FeedID   string         `json:"feed_id"`
+	IsVideo  bool           `json:"is_video"`
+	Comments []VideoComment `json:"comments"`
+	Count    int            `json:"count"`
+}
+
+// VideoCommentsAction 获取视频笔记的弹幕
+type VideoCommentsAction struct {
+	page *rod.Page
+}
+
+func NewVideoCommentsAction(page *rod.Page) *VideoCommentsAction {
+	return &VideoCommentsAction{page: page}
+}
+
+// GetVideoComments 打开视频笔记并监听弹幕接口，返回按时间排序的弹幕。
+// 弹幕与普通评论列表相互独立。
+func (a *VideoCommentsAction) GetVideoComments(ctx context.Context, feedID, xsecToken string) (*VideoCommentsResult, error) {
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	page := a.page.Context(listenCtx).Timeout(60 * time.Second)
+
+	var mu sync.Mutex
+	var requestIDs []proto.NetworkRequestID
+
+	wait := page.EachEvent(func(e *proto.NetworkResponseReceived) {
+		if !isDanmakuURL(e.Response.URL) {
+			return
+		}
+		mu.Lock()
+		requestIDs = append(requestIDs, e.RequestID)
+		mu.Unlock()
+	})
+	go wait()
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	result := &VideoCommentsResult{FeedID: feedID, Comments: []VideoComment{}}
+
+	result.IsVideo = page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		const note = map && map[feedID] && map[feedID].note;
+		return (note && note.type === "video") || document.querySelector('video') !== null;
+	}`, feedID).Bool()
+	if !result.IsVideo {
+		return result, nil
+	}
+
+	// 弹幕通常在开始播放后才会请求
+	page.MustEval(`() => { const v = document.querySelector('video'); if (v) { v.muted = true; v.play().catch(() => {}); } }`)
+	time.Sleep(danmakuCaptureWindow)
+
+	mu.Lock()
+	ids := append([]proto.NetworkRequestID(nil), requestIDs...)
+	mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		body, err := proto.NetworkGetResponseBody{RequestID: id}.Call(page)
+		if err != nil {
+			logrus.Debugf("读取弹幕响应失败: %v", err)
+			continue
+		}
+
+		data := []byte(body.Body)
+		if body.Base64Encoded {
+			if data, err = base64.StdEncoding.DecodeString(body.Body); err != nil {
+				continue
+			}
+		}
+
+		for _, c := range parseDanmakuPayload(data) {
+			key := fmt.Sprintf("%d|%s", c.OffsetMs, c.Content)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result.Comments = append(result.Comments, c)
+		}
+	}
+
+	sort.SliceStable(result.Comments, func(i, j int) bool {
+		return result.Comments[i].OffsetMs < result.Comments[j].OffsetMs
+	})
+	result.Count = len(result.Comments)
+
+	logrus.Infof("feed %s 捕获弹幕 %d 条（接口响应 %d 个）", feedID, result.Count, len(ids))
+	return result, nil
+}
+
+func isDanmakuURL(url string) bool {
+	lower := strings.ToLower(url)
+	for _, marker := range danmakuURLMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDanmakuPayload 在接口返回的 JSON 中查找同时带有内容字段和时间字段的对象
+func parseDanmakuPayload(data []byte) []VideoComment {
+	var payload any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil
+	}
+
+	var comments []VideoComment
+	var walk func(v any)
+	walk = func(v any) {
+		switch node := v.(type) {
+		case []any:
+			for _, item := range node {
+				walk(item)
+			}
+		case map[string]any:
+			if c, ok := danmakuFromObject(node); ok {
+				comments = append(comments, c)
+				return
+			}
+			for _, item := range node {
+				walk(item)
+			}
+		}
+	}
+	walk(payload)
+
+	return comments
+}
+
+func danmakuFromObject(obj map[string]any) (VideoComment, bool) {
+	var c VideoComment
+
+	for _, key := range danmakuContentKeys {
+		if s, ok := obj[key].(string); ok && s != "" {
+			c.Content = s
+			break
+		}
+	}
+	if c.Content == "" {
+		return c, false
+	}
+
+	offset, ok := numberField(obj, danmakuMsKeys)
+	if ok {
+		c.OffsetMs = int64(offset)
+	} else if offset, ok = numberField(obj, danmakuSecondKeys); ok {
+		c.OffsetMs = int64(offset * 1000)
+	} else {
+		return c, false
+	}
+
+	if uid, ok := obj["user_id"].(string); ok {
+		c.UserID = uid
+	} else if uid, ok := obj["userId"].(string); ok {
+		c.UserID = uid
+	}
+
+	return c, true
+}
+
+// numberField 返回第一个存在的数值字段，兼容以字符串表示的数字
+func numberField(obj map[string]any, keys []string) (float64, bool) {
+	for _, key := range keys {
+		switch v := obj[key].(type) {
+		case float64:
+			return v, true
+		case string:
+			var f float64
+			if _, err := fmt.Sscanf(v, "%g", &f); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,30 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDanmakuPayload(t *testing.T) {
+	payload := []byte(`{
+		"code": 0,
+		"data": {
+			"danmaku_list": [
+				{"content": "前方高能", "offset_ms": 1500, "user_id": "u1"},
+				{"text": "好看", "time": 3.2},
+				{"content": "没有时间字段"}
+			],
+			"cursor": "abc"
+		}
+	}`)
+
+	comments := parseDanmakuPayload(payload)
+	require.Len(t, comments, 2)
+	require.Equal(t, VideoComment{Content: "前方高能", OffsetMs: 1500, UserID: "u1"}, comments[0])
+	require.Equal(t, int64(3200), comments[1].OffsetMs)
+
+	require.Empty(t, parseDanmakuPayload([]byte(`not json`)))
+	require.True(t, isDanmakuURL("https://edith.xiaohongshu.com/api/sns/web/v1/danmaku/list?note_id=1"))
+	require.False(t, isDanmakuURL("https://edith.xiaohongshu.com/api/sns/web/v2/comment/page"))
+}
@@ -0,0 +1,121 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 视频封面来源
+const (
+	VideoCoverCustom     = "custom"      // 作者上传或选择的封面
+	VideoCoverFirstFrame = "first_frame" // 平台自动截取的首帧
+)
+
+// VideoCover 视频笔记的封面
+type VideoCover struct {
+	FeedID      string `json:"feed_id"`
+	URL         string `json:"url"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Source      string `json:"source"` // custom | first_frame
+	SourceLabel string `json:"source_label"`
+	LocalPath   string `json:"local_path,omitempty"` // 要求下载时的本地文件路径
+}
+
+// VideoCoverAction 只读取视频笔记的封面，不解析完整详情和评论
+type VideoCoverAction struct {
+	page *rod.Page
+}
+
+func NewVideoCoverAction(page *rod.Page) *VideoCoverAction {
+	pp := page.Timeout(30 * time.Second)
+	return &VideoCoverAction{page: pp}
+}
+
+// GetVideoCover 打开笔记详情页，从 __INITIAL_STATE__ 中读取封面，不等待页面渲染完成
+func (a *VideoCoverAction) GetVideoCover(ctx context.Context, feedID, xsecToken string) (*VideoCover, error) {
+	page := a.page.Context(ctx)
+
+	page.MustNavigate(makeFeedDetailURL(feedID, xsecToken))
+	page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
+
+	if err := passContentGate(page); err != nil {
+		return nil, err
+	}
+
+	raw := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		const map = state && state.note && state.note.noteDetailMap;
+		const note = map && map[feedID] && map[feedID].note;
+		if (!note) return "";
+		const video = note.video || {};
+		return JSON.stringify({
+			type: note.type || "",
+			cover: (note.imageList && note.imageList[0]) || null,
+			firstFrame: (video.image && (video.image.firstFrameFileid || video.image.first_frame_fileid)) || "",
+		});
+	}`, feedID).Str()
+	if raw == "" {
+		return nil, errors.ErrNoFeedDetail
+	}
+
+	return parseVideoCover(feedID, []byte(raw))
+}
+
+// parseVideoCover 解析封面数据。视频笔记 imageList 的第一张即封面，
+// 封面文件不是平台截取的首帧时视为作者自定义的封面。
+func parseVideoCover(feedID string, raw []byte) (*VideoCover, error) {
+	var data struct {
+		Type  string `json:"type"`
+		Cover *struct {
+			Width      int    `json:"width"`
+			Height     int    `json:"height"`
+			URLDefault string `json:"urlDefault"`
+			URLPre     string `json:"urlPre"`
+			FileID     string `json:"fileId"`
+			TraceID    string `json:"traceId"`
+		} `json:"cover"`
+		FirstFrame string `json:"firstFrame"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal video cover: %w", err)
+	}
+
+	if data.Type != NoteTypeVideo {
+		return nil, fmt.Errorf("笔记 %s 不是视频笔记", feedID)
+	}
+	if data.Cover == nil || (data.Cover.URLDefault == "" && data.Cover.URLPre == "") {
+		return nil, fmt.Errorf("笔记 %s 没有封面数据", feedID)
+	}
+
+	cover := &VideoCover{
+		FeedID: feedID,
+		URL:    data.Cover.URLDefault,
+		Width:  data.Cover.Width,
+		Height: data.Cover.Height,
+		Source: VideoCoverFirstFrame,
+	}
+	if cover.URL == "" {
+		cover.URL = data.Cover.URLPre
+	}
+
+	// 没有首帧信息时无法区分，按首帧处理
+	if data.FirstFrame != "" {
+		fileID := data.Cover.FileID
+		if fileID == "" {
+			fileID = data.Cover.TraceID
+		}
+		if fileID != data.FirstFrame && !strings.Contains(cover.URL, data.FirstFrame) {
+			cover.Source = VideoCoverCustom
+		}
+	}
+	cover.SourceLabel = EnumLabel(EnumVideoCoverSource, cover.Source)
+
+	return cover, nil
+}
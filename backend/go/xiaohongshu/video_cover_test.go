@@ -0,0 +1,31 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVideoCover(t *testing.T) {
+	auto, err := parseVideoCover("v1", []byte(`{"type": "video", "firstFrame": "ff1",
+		"cover": {"width": 1080, "height": 1440, "urlDefault": "https://sns-webpic.xhscdn.com/ff1!nd_dft", "traceId": "ff1"}}`))
+	require.NoError(t, err)
+	require.Equal(t, VideoCoverFirstFrame, auto.Source)
+	require.Equal(t, 1440, auto.Height)
+
+	custom, err := parseVideoCover("v2", []byte(`{"type": "video", "firstFrame": "ff2",
+		"cover": {"urlPre": "https://sns-webpic.xhscdn.com/cc2!nd_prv", "fileId": "cc2"}}`))
+	require.NoError(t, err)
+	require.Equal(t, VideoCoverCustom, custom.Source)
+	require.Equal(t, "https://sns-webpic.xhscdn.com/cc2!nd_prv", custom.URL)
+
+	unknown, err := parseVideoCover("v3", []byte(`{"type": "video", "cover": {"urlDefault": "https://x/c.jpg"}}`))
+	require.NoError(t, err)
+	require.Equal(t, VideoCoverFirstFrame, unknown.Source)
+
+	_, err = parseVideoCover("n1", []byte(`{"type": "normal", "cover": {"urlDefault": "https://x/c.jpg"}}`))
+	require.Error(t, err)
+
+	_, err = parseVideoCover("v4", []byte(`{"type": "video", "cover": null}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,243 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// ViewHistoryPageSize 每页返回的浏览记录条数
+const ViewHistoryPageSize = 20
+
+// 个人主页上浏览记录标签可能使用的名称
+var viewHistoryTabLabels = []string{"浏览记录", "历史记录", "最近浏览", "足迹"}
+
+var (
+	relativeTimePattern = regexp.MustCompile(`(\d+)\s*(分钟|小时|天)前`)
+	clockPattern        = regexp.MustCompile(`(\d{1,2}):(\d{2})`)
+	fullDatePattern     = regexp.MustCompile(`(\d{4})-(\d{1,2})-(\d{1,2})`)
+	shortDatePattern    = regexp.MustCompile(`(\d{1,2})-(\d{1,2})`)
+)
+
+// ViewedNote 浏览过的笔记
+type ViewedNote struct {
+	FeedID      string `json:"feed_id"`
+	XsecToken   string `json:"xsec_token,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Author      string `json:"author,omitempty"`
+	ViewedLabel string `json:"viewed_label,omitempty"` // 页面上的浏览时间原文，如 "3天前"
+	ViewedAt    int64  `json:"viewed_at,omitempty"`    // 浏览时间，毫秒时间戳；无法识别时为 0
+}
+
+// ViewHistory 一页浏览记录
+type ViewHistory struct {
+	Available bool         `json:"available"`
+	Reason    string       `json:"reason,omitempty"` // 不可用时的原因
+	Notes     []ViewedNote `json:"notes"`
+	Cursor    string       `json:"cursor,omitempty"` // 下一页的游标，没有更多时为空
+	HasMore   bool         `json:"has_more"`
+}
+
+// ViewHistoryAction 读取账号的笔记浏览记录
+type ViewHistoryAction struct {
+	page *rod.Page
+}
+
+func NewViewHistoryAction(page *rod.Page) *ViewHistoryAction {
+	pp := page.Timeout(60 * time.Second)
+	return &ViewHistoryAction{page: pp}
+}
+
+// GetViewHistory 打开个人主页的浏览记录，返回 cursor 之后的一页。
+// 网页端没有浏览记录入口或记录为空时不会报错，而是返回 Available=false 并说明原因。
+func (a *ViewHistoryAction) GetViewHistory(ctx context.Context, cursor string) (*ViewHistory, error) {
+	offset, err := parseHistoryCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page := a.page.Context(ctx)
+
+	if err := NewNavigate(page).ToProfilePage(ctx); err != nil {
+		return nil, fmt.Errorf("failed to navigate to profile page: %w", err)
+	}
+	page.MustWaitStable()
+
+	result := &ViewHistory{Notes: []ViewedNote{}}
+
+	tab, err := page.Timeout(5*time.Second).ElementR(`.reds-tab-item, [class*="tab"]`, "^("+strings.Join(viewHistoryTabLabels, "|")+")$")
+	if err != nil {
+		result.Reason = "网页端个人主页没有浏览记录入口，平台可能未开放该数据"
+		logrus.Infof("未找到浏览记录入口: %v", err)
+		return result, nil
+	}
+	tab.MustClick()
+	page.MustWaitStable()
+	time.Sleep(1 * time.Second)
+
+	// 滚动加载直到覆盖请求的这一页，多取一条用于判断是否还有下一页
+	want := offset + ViewHistoryPageSize + 1
+	notes := readViewedNotes(page, time.Now())
+	for idle := 0; len(notes) < want && idle < maxIdleScrolls; {
+		page.Mouse.MustScroll(0, 2000)
+		time.Sleep(1500 * time.Millisecond)
+		more := readViewedNotes(page, time.Now())
+		if len(more) <= len(notes) {
+			idle++
+		} else {
+			idle = 0
+		}
+		notes = more
+	}
+
+	if len(notes) == 0 {
+		result.Reason = "浏览记录为空，或平台只保留了有限的浏览记录"
+		return result, nil
+	}
+
+	result.Available = true
+	result.Notes, result.Cursor, result.HasMore = pageViewHistory(notes, offset, ViewHistoryPageSize)
+	logrus.Infof("浏览记录: offset=%d 返回 %d 条, has_more=%v", offset, len(result.Notes), result.HasMore)
+	return result, nil
+}
+
+// readViewedNotes 读取浏览记录页当前已加载的笔记卡片，按笔记 ID 去重
+func readViewedNotes(page *rod.Page, now time.Time) []ViewedNote {
+	cards := page.MustEval(`() => {
+		const result = [];
+		document.querySelectorAll('section.note-item, [class*="note-item"]').forEach(card => {
+			const link = card.querySelector('a[href*="/explore/"], a[href*="/discovery/item/"]');
+			if (!link) return;
+			const text = sel => {
+				const el = card.querySelector(sel);
+				return el ? el.innerText.trim() : "";
+			};
+			result.push({
+				href: link.href,
+				title: text('.title, [class*="title"]'),
+				author: text('.author .name, [class*="author"] [class*="name"]'),
+				time: text('.time, [class*="time"], [class*="date"]'),
+			});
+		});
+		return result;
+	}`).Arr()
+
+	notes := make([]ViewedNote, 0, len(cards))
+	seen := make(map[string]bool)
+	for _, card := range cards {
+		ref, ok := parseRepostLink(card.Get("href").Str(), card.Get("title").Str())
+		if !ok || seen[ref.FeedID] {
+			continue
+		}
+		seen[ref.FeedID] = true
+
+		label := strings.TrimSpace(card.Get("time").Str())
+		note := ViewedNote{
+			FeedID:      ref.FeedID,
+			XsecToken:   ref.XsecToken,
+			Title:       ref.Title,
+			Author:      strings.TrimSpace(card.Get("author").Str()),
+			ViewedLabel: label,
+		}
+		if t, ok := parseViewedAt(label, now); ok {
+			note.ViewedAt = t.UnixMilli()
+		}
+		notes = append(notes, note)
+	}
+	return notes
+}
+
+// ValidateViewHistoryCursor 校验浏览记录游标
+func ValidateViewHistoryCursor(cursor string) error {
+	_, err := parseHistoryCursor(cursor)
+	return err
+}
+
+// parseHistoryCursor 解析游标，游标为已返回的条数，为空表示第一页
+func parseHistoryCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("无效的游标 %q，请使用上一页返回的 cursor", cursor)
+	}
+	return offset, nil
+}
+
+// pageViewHistory 从已加载的记录中取出 offset 开始的一页，返回下一页游标与是否还有更多
+func pageViewHistory(notes []ViewedNote, offset, size int) ([]ViewedNote, string, bool) {
+	if offset >= len(notes) {
+		return []ViewedNote{}, "", false
+	}
+
+	end := offset + size
+	if end >= len(notes) {
+		return notes[offset:], "", false
+	}
+	return notes[offset:end], strconv.Itoa(end), true
+}
+
+// parseViewedAt 解析页面上的浏览时间，支持 "刚刚"、"N分钟前"、"昨天 12:30"、"2024-05-01"、"05-01"
+func parseViewedAt(label string, now time.Time) (time.Time, bool) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return time.Time{}, false
+	}
+
+	if label == "刚刚" {
+		return now, true
+	}
+
+	if m := relativeTimePattern.FindStringSubmatch(label); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "分钟":
+			return now.Add(-time.Duration(n) * time.Minute), true
+		case "小时":
+			return now.Add(-time.Duration(n) * time.Hour), true
+		default:
+			return now.AddDate(0, 0, -n), true
+		}
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case strings.HasPrefix(label, "今天"):
+	case strings.HasPrefix(label, "昨天"):
+		day = day.AddDate(0, 0, -1)
+	case strings.HasPrefix(label, "前天"):
+		day = day.AddDate(0, 0, -2)
+	default:
+		if m := fullDatePattern.FindStringSubmatch(label); m != nil {
+			year, _ := strconv.Atoi(m[1])
+			month, _ := strconv.Atoi(m[2])
+			d, _ := strconv.Atoi(m[3])
+			return time.Date(year, time.Month(month), d, 0, 0, 0, 0, now.Location()), true
+		}
+		if m := shortDatePattern.FindStringSubmatch(label); m != nil {
+			month, _ := strconv.Atoi(m[1])
+			d, _ := strconv.Atoi(m[2])
+			t := time.Date(now.Year(), time.Month(month), d, 0, 0, 0, 0, now.Location())
+			if t.After(now) {
+				// 没有年份的日期不会在未来，说明是去年
+				t = t.AddDate(-1, 0, 0)
+			}
+			return t, true
+		}
+		return time.Time{}, false
+	}
+
+	if m := clockPattern.FindStringSubmatch(label); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		day = day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	return day, true
+}
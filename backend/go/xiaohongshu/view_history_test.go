@@ -0,0 +1,77 @@
+package xiaohongshu
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHistoryCursor(t *testing.T) {
+	offset, err := parseHistoryCursor("")
+	require.NoError(t, err)
+	require.Equal(t, 0, offset)
+
+	offset, err = parseHistoryCursor("40")
+	require.NoError(t, err)
+	require.Equal(t, 40, offset)
+
+	_, err = parseHistoryCursor("abc")
+	require.Error(t, err)
+	_, err = parseHistoryCursor("-1")
+	require.Error(t, err)
+}
+
+func TestPageViewHistory(t *testing.T) {
+	notes := make([]ViewedNote, 45)
+	for i := range notes {
+		notes[i].FeedID = strconv.Itoa(i)
+	}
+
+	page, cursor, hasMore := pageViewHistory(notes, 0, 20)
+	require.Len(t, page, 20)
+	require.Equal(t, "20", cursor)
+	require.True(t, hasMore)
+
+	page, cursor, hasMore = pageViewHistory(notes, 40, 20)
+	require.Len(t, page, 5)
+	require.Equal(t, "40", page[0].FeedID)
+	require.Empty(t, cursor)
+	require.False(t, hasMore)
+
+	page, _, hasMore = pageViewHistory(notes, 100, 20)
+	require.NotNil(t, page)
+	require.Empty(t, page)
+	require.False(t, hasMore)
+}
+
+func TestParseViewedAt(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		label string
+		want  time.Time
+	}{
+		{"刚刚", now},
+		{"5分钟前", now.Add(-5 * time.Minute)},
+		{"2小时前", now.Add(-2 * time.Hour)},
+		{"3天前", time.Date(2024, 3, 7, 15, 0, 0, 0, time.UTC)},
+		{"今天 09:30", time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)},
+		{"昨天 21:05", time.Date(2024, 3, 9, 21, 5, 0, 0, time.UTC)},
+		{"2023-12-01", time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"12-25", time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseViewedAt(tt.label, now)
+		require.True(t, ok, tt.label)
+		require.Equal(t, tt.want, got, tt.label)
+	}
+
+	_, ok := parseViewedAt("", now)
+	require.False(t, ok)
+	_, ok = parseViewedAt("很久以前", now)
+	require.False(t, ok)
+}
@@ -21,7 +21,9 @@ export function getGoBackendConfig(): GoBackendServiceOptions {
   return {
     port: config.port,
     browserBin: config.browserBin,
-    cookiesPath: config.cookiesPath ?? path.join(app.getPath('userData'), 'cookies', 'cookies.json')
+    cookiesPath: config.cookiesPath ?? path.join(app.getPath('userData'), 'cookies', 'cookies.json'),
+    secretSource: config.secretSource,
+    secretService: config.secretService
   };
 }
 
@@ -25,7 +25,10 @@ export const AppConfigSchema = z.object({
     go: z.object({
       port: z.string().default('0'),
       browserBin: z.string().optional(),
-      cookiesPath: z.string().optional()
+      cookiesPath: z.string().optional(),
+      // 回调地址等敏感配置的读取来源，keychain 表示优先读取系统钥匙串
+      secretSource: z.enum(['env', 'keychain']).optional(),
+      secretService: z.string().optional()
     }),
     python: z.object({
       port: z.number().default(18061),
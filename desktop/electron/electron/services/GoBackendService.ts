@@ -12,6 +12,8 @@ export interface GoBackendServiceOptions {
   port?: string;
   browserBin?: string;
   cookiesPath?: string;
+  secretSource?: 'env' | 'keychain';
+  secretService?: string;
 }
 
 export class GoBackendService extends BaseBackendService {
@@ -93,6 +95,14 @@ export class GoBackendService extends BaseBackendService {
     const requestedPort = this.options.port ?? process.env.MCP_SERVER_PORT ?? '0';
     args.push('--port', requestedPort);
 
+    // 敏感配置从系统钥匙串读取，不出现在进程参数中
+    if (this.options.secretSource) {
+      args.push('--secret-source', this.options.secretSource);
+      if (this.options.secretService) {
+        args.push('--secret-service', this.options.secretService);
+      }
+    }
+
     // 准备环境变量
     const env = {
       ...process.env,